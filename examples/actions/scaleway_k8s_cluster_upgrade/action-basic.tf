@@ -0,0 +1,10 @@
+### Upgrade a cluster through several minor versions, system pool first
+
+action "scaleway_k8s_cluster_upgrade" "upgrade" {
+  config {
+    cluster_id                    = scaleway_k8s_cluster.cluster.id
+    version                       = "1.35"
+    pool_order                    = ["system", "workers"]
+    allow_multiple_minor_versions = true
+  }
+}
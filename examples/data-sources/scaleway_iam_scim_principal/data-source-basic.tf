@@ -0,0 +1,22 @@
+### Bind a group pushed by the identity provider to a policy
+
+resource "scaleway_iam_scim" "main" {}
+
+resource "scaleway_iam_scim_token" "main" {
+  scim_id = scaleway_iam_scim.main.id
+}
+
+data "scaleway_iam_scim_principal" "admins" {
+  endpoint     = "https://scim.example.com/v2" # SCIM base URL displayed in the console
+  bearer_token = scaleway_iam_scim_token.main.bearer_token
+  group_name   = "Platform Admins"
+}
+
+resource "scaleway_iam_policy" "admins" {
+  name     = "platform-admins"
+  group_id = data.scaleway_iam_scim_principal.admins.iam_id
+  rule {
+    organization_id      = scaleway_iam_scim.main.organization_id
+    permission_set_names = ["IAMManager"]
+  }
+}
@@ -0,0 +1,12 @@
+# Check which rule of the ACL of a VPC applies to an SSH connection
+data "scaleway_vpc_acl_evaluate" "ssh" {
+  vpc_id      = "fr-par/11111111-1111-1111-1111-111111111111"
+  source      = "192.168.1.10"
+  destination = "172.16.0.5"
+  protocol    = "TCP"
+  dst_port    = 22
+}
+
+output "ssh_action" {
+  value = data.scaleway_vpc_acl_evaluate.ssh.action
+}
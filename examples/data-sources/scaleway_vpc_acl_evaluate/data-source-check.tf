@@ -0,0 +1,15 @@
+# Fail the plan when the ACL does not accept HTTPS traffic to the web servers
+data "scaleway_vpc_acl_evaluate" "https" {
+  vpc_id      = scaleway_vpc.main.id
+  source      = "203.0.113.10"
+  destination = "172.16.0.5"
+  protocol    = "TCP"
+  dst_port    = 443
+}
+
+check "https_accepted" {
+  assert {
+    condition     = data.scaleway_vpc_acl_evaluate.https.action == "accept"
+    error_message = "The ACL drops HTTPS traffic to the web servers"
+  }
+}
@@ -0,0 +1,31 @@
+### Configure the Helm provider with a short-lived kubeconfig that is never persisted in the state file
+
+resource "scaleway_k8s_cluster" "cluster" {
+  name                        = "tf-cluster"
+  version                     = "1.35.3"
+  cni                         = "cilium"
+  private_network_id          = scaleway_vpc_private_network.pn.id
+  delete_additional_resources = false
+  ephemeral_kubeconfig        = true
+}
+
+resource "scaleway_iam_application" "deployer" {
+  name = "cluster-deployer"
+}
+
+ephemeral "scaleway_k8s_kubeconfig" "cluster" {
+  cluster_id = scaleway_k8s_cluster.cluster.id
+
+  iam_token = {
+    application_id = scaleway_iam_application.deployer.id
+    ttl            = "30m"
+  }
+}
+
+provider "helm" {
+  kubernetes = {
+    host                   = ephemeral.scaleway_k8s_kubeconfig.cluster.host
+    token                  = ephemeral.scaleway_k8s_kubeconfig.cluster.token
+    cluster_ca_certificate = base64decode(ephemeral.scaleway_k8s_kubeconfig.cluster.cluster_ca_certificate)
+  }
+}
@@ -0,0 +1,24 @@
+### Rotate the API key of an application every 30 days
+
+resource "scaleway_iam_application" "ci" {
+  name = "ci"
+}
+
+resource "scaleway_secret" "ci_key" {
+  name = "ci-api-key"
+}
+
+# Each new key is written as a new version of the secret
+resource "scaleway_iam_api_key_rotation" "ci" {
+  application_id  = scaleway_iam_application.ci.id
+  secret_id       = scaleway_secret.ci_key.id
+  description     = "CI pipelines"
+  rotation_period = "720h"
+  overlap         = "48h"
+}
+
+# The consumers read the current key without storing it in the state
+ephemeral "scaleway_secret_version" "ci_key" {
+  secret_id = scaleway_secret.ci_key.id
+  revision  = scaleway_iam_api_key_rotation.ci.current.secret_revision
+}
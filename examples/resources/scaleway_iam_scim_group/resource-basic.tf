@@ -0,0 +1,34 @@
+### Provision a group through SCIM and bind it to a policy
+
+resource "scaleway_iam_scim" "main" {}
+
+resource "scaleway_iam_scim_token" "main" {
+  scim_id = scaleway_iam_scim.main.id
+}
+
+locals {
+  scim_endpoint = "https://scim.example.com/v2" # SCIM base URL displayed in the console
+}
+
+resource "scaleway_iam_scim_user" "jane" {
+  endpoint     = local.scim_endpoint
+  bearer_token = scaleway_iam_scim_token.main.bearer_token
+  user_name    = "jane.doe@example.com"
+  email        = "jane.doe@example.com"
+}
+
+resource "scaleway_iam_scim_group" "developers" {
+  endpoint     = local.scim_endpoint
+  bearer_token = scaleway_iam_scim_token.main.bearer_token
+  display_name = "developers"
+  member_ids   = [scaleway_iam_scim_user.jane.id]
+}
+
+resource "scaleway_iam_policy" "developers" {
+  name     = "developers"
+  group_id = scaleway_iam_scim_group.developers.iam_group_id
+  rule {
+    organization_id      = scaleway_iam_scim.main.organization_id
+    permission_set_names = ["ProjectReadOnly"]
+  }
+}
@@ -0,0 +1,29 @@
+### Provision a user through SCIM and grant it access to a project
+
+resource "scaleway_iam_scim" "main" {}
+
+resource "scaleway_iam_scim_token" "main" {
+  scim_id = scaleway_iam_scim.main.id
+}
+
+resource "scaleway_iam_scim_user" "jane" {
+  endpoint     = "https://scim.example.com/v2" # SCIM base URL displayed in the console
+  bearer_token = scaleway_iam_scim_token.main.bearer_token
+  user_name    = "jane.doe@example.com"
+  email        = "jane.doe@example.com"
+  given_name   = "Jane"
+  family_name  = "Doe"
+}
+
+resource "scaleway_account_project" "main" {
+  name = "main"
+}
+
+resource "scaleway_iam_policy" "jane" {
+  name    = "jane"
+  user_id = scaleway_iam_scim_user.jane.iam_user_id
+  rule {
+    project_ids          = [scaleway_account_project.main.id]
+    permission_set_names = ["InstancesReadOnly"]
+  }
+}
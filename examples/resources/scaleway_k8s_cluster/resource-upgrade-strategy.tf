@@ -0,0 +1,28 @@
+resource "scaleway_vpc_private_network" "pn" {}
+
+resource "scaleway_k8s_cluster" "cluster" {
+  name                        = "tf-cluster"
+  version                     = "1.35.3"
+  cni                         = "cilium"
+  private_network_id          = scaleway_vpc_private_network.pn.id
+  delete_additional_resources = false
+
+  upgrade_strategy {
+    pool_order                    = ["system"]
+    allow_multiple_minor_versions = true
+  }
+}
+
+resource "scaleway_k8s_pool" "system" {
+  cluster_id = scaleway_k8s_cluster.cluster.id
+  name       = "system"
+  node_type  = "DEV1-M"
+  size       = 1
+}
+
+resource "scaleway_k8s_pool" "workers" {
+  cluster_id = scaleway_k8s_cluster.cluster.id
+  name       = "workers"
+  node_type  = "DEV1-M"
+  size       = 3
+}
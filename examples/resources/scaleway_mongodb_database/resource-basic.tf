@@ -0,0 +1,31 @@
+### Basic database creation
+
+resource "scaleway_mongodb_instance" "main" {
+  name              = "test-mongodb-database"
+  version           = "7.0.12"
+  node_type         = "MGDB-PLAY2-NANO"
+  node_number       = 1
+  user_name         = "initial_user"
+  password          = "initial_password123"
+  volume_size_in_gb = 5
+}
+
+resource "scaleway_mongodb_database" "main" {
+  instance_id = scaleway_mongodb_instance.main.id
+  name        = "my_database"
+  collections = ["users", "orders"]
+
+  user_name = scaleway_mongodb_instance.main.user_name
+  password  = scaleway_mongodb_instance.main.password
+}
+
+resource "scaleway_mongodb_user" "app" {
+  instance_id = scaleway_mongodb_instance.main.id
+  name        = "app"
+  password    = "app_password123"
+
+  roles {
+    role          = "read_write"
+    database_name = scaleway_mongodb_database.main.name
+  }
+}
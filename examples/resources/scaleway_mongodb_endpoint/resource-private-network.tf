@@ -0,0 +1,23 @@
+### Private network endpoint
+
+resource "scaleway_vpc_private_network" "pn" {
+  name = "my-private-network"
+}
+
+resource "scaleway_mongodb_instance" "main" {
+  name                      = "test-mongodb-endpoint"
+  version                   = "7.0.12"
+  node_type                 = "MGDB-PLAY2-NANO"
+  node_number               = 1
+  user_name                 = "initial_user"
+  password                  = "initial_password123"
+  ignore_external_endpoints = true
+}
+
+resource "scaleway_mongodb_endpoint" "private" {
+  instance_id = scaleway_mongodb_instance.main.id
+
+  private_network {
+    pn_id = scaleway_vpc_private_network.pn.id
+  }
+}
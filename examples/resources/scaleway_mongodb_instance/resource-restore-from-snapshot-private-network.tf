@@ -0,0 +1,21 @@
+### MongoDB instance restored from Snapshot in a Private Network
+
+resource "scaleway_vpc_private_network" "pn" {
+  name = "my-private-network"
+}
+
+resource "scaleway_mongodb_instance" "restored_instance" {
+  name              = "restored-mongodb-from-snapshot"
+  node_type         = "MGDB-PLAY2-NANO"
+  node_number       = 1
+  volume_size_in_gb = 10
+  tags              = ["restored"]
+
+  restore_from_snapshot {
+    snapshot_id = scaleway_mongodb_snapshot.main_snapshot.id
+  }
+
+  private_network {
+    pn_id = scaleway_vpc_private_network.pn.id
+  }
+}
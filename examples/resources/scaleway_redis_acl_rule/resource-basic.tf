@@ -0,0 +1,23 @@
+### ACL rules owned by different teams
+
+resource "scaleway_redis_cluster" "main" {
+  name      = "test_redis_acl_rule"
+  version   = "7.2.5"
+  node_type = "RED1-XS"
+  user_name = "my_initial_user"
+  password  = "thiZ_is_v&ry_s3cret"
+
+  ignore_external_acl_rules = true
+}
+
+resource "scaleway_redis_acl_rule" "office" {
+  cluster_id  = scaleway_redis_cluster.main.id
+  ip          = "203.0.113.0/24"
+  description = "office"
+}
+
+resource "scaleway_redis_acl_rule" "ci" {
+  cluster_id  = scaleway_redis_cluster.main.id
+  ip          = "198.51.100.7/32"
+  description = "ci runner"
+}
@@ -0,0 +1,17 @@
+### Settings managed outside of the cluster
+
+resource "scaleway_redis_cluster" "main" {
+  name      = "test_redis_setting"
+  version   = "7.2.5"
+  node_type = "RED1-XS"
+  user_name = "my_initial_user"
+  password  = "thiZ_is_v&ry_s3cret"
+
+  ignore_external_settings = true
+}
+
+resource "scaleway_redis_setting" "maxclients" {
+  cluster_id = scaleway_redis_cluster.main.id
+  name       = "maxclients"
+  value      = "1000"
+}
@@ -0,0 +1,27 @@
+resource "scaleway_vpc" "vpc01" {
+  name = "my-vpc"
+}
+
+resource "scaleway_vpc_acl_rule" "deny_ssh" {
+  vpc_id        = scaleway_vpc.vpc01.id
+  priority      = 10
+  protocol      = "TCP"
+  source        = "0.0.0.0/0"
+  destination   = "0.0.0.0/0"
+  dst_port_low  = 22
+  dst_port_high = 22
+  action        = "drop"
+  description   = "Deny SSH traffic"
+}
+
+resource "scaleway_vpc_acl_rule" "allow_https" {
+  vpc_id        = scaleway_vpc.vpc01.id
+  priority      = 20
+  protocol      = "TCP"
+  source        = "0.0.0.0/0"
+  destination   = "172.16.0.0/12"
+  dst_port_low  = 443
+  dst_port_high = 443
+  action        = "accept"
+  description   = "Allow HTTPS traffic"
+}
@@ -0,0 +1,13 @@
+resource "scaleway_vpc" "vpc01" {
+  name = "my-vpc"
+}
+
+resource "scaleway_vpc_acl_rule" "deny_icmp" {
+  vpc_id      = scaleway_vpc.vpc01.id
+  is_ipv6     = true
+  priority    = 10
+  protocol    = "ICMP"
+  source      = "::/0"
+  destination = "::/0"
+  action      = "drop"
+}
@@ -0,0 +1,17 @@
+resource "scaleway_vpc" "vpc01" {
+  name = "my-vpc"
+}
+
+resource "scaleway_vpc_subnet_allocation" "app" {
+  vpc_id        = scaleway_vpc.vpc01.id
+  supernet      = "172.16.0.0/16"
+  prefix_length = 24
+}
+
+resource "scaleway_vpc_private_network" "app" {
+  name   = "app"
+  vpc_id = scaleway_vpc.vpc01.id
+  ipv4_subnet {
+    subnet = scaleway_vpc_subnet_allocation.app.subnet
+  }
+}
@@ -0,0 +1,7 @@
+resource "scaleway_vpc_subnet_allocation" "db" {
+  vpc_id        = scaleway_vpc.vpc01.id
+  supernet      = "10.0.0.0/16"
+  prefix_length = 26
+  # Ranges routed to the on-premise network
+  exclude = ["10.0.0.0/20", "10.0.64.0/18"]
+}
@@ -0,0 +1,185 @@
+package connection
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Engines a DSN template can be built for
+const (
+	EnginePostgreSQL = "postgresql"
+	EngineMySQL      = "mysql"
+	EngineMongoDB    = "mongodb"
+	EngineRedis      = "redis"
+	EngineClickHouse = "clickhouse"
+)
+
+// Kinds of endpoint, private networks are preferred over public ones
+const (
+	KindPrivateNetwork = "private_network"
+	KindPublicNetwork  = "public_network"
+)
+
+// Placeholders of a DSN template, they are replaced by the escaped credentials
+const (
+	PlaceholderUser     = "{user}"
+	PlaceholderPassword = "{password}"
+	PlaceholderDatabase = "{database}"
+)
+
+// Endpoint is an address a database can be reached on
+type Endpoint struct {
+	Kind string
+	Host string
+	Port int
+}
+
+// Connection describes how to connect to a database, it does not hold any secret
+type Connection struct {
+	Engine   string
+	Endpoint Endpoint
+	CACert   string
+	TLS      bool
+}
+
+// Schema returns the computed connection block shared by the managed database resources
+func Schema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "How to connect to the database, the private network endpoint is preferred over the public one",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"engine": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The engine of the DSN template, one of postgresql, mysql, mongodb, redis or clickhouse",
+				},
+				"endpoint_kind": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The kind of the preferred endpoint, private_network or public_network",
+				},
+				"host": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The host of the preferred endpoint",
+				},
+				"port": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The port of the preferred endpoint",
+				},
+				"ca_cert": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The PEM-encoded CA certificate to verify the TLS connection, empty when a public CA is used",
+				},
+				"dsn_template": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The connection URI with {user}, {password} and {database} placeholders, see the database_dsn function",
+				},
+			},
+		},
+	}
+}
+
+// Preferred returns the first private network endpoint, or the first public one when there is none.
+// Endpoints without host or port are skipped.
+func Preferred(endpoints []Endpoint) (Endpoint, bool) {
+	for _, kind := range []string{KindPrivateNetwork, KindPublicNetwork} {
+		for _, endpoint := range endpoints {
+			if endpoint.Kind == kind && endpoint.Host != "" && endpoint.Port != 0 {
+				return endpoint, true
+			}
+		}
+	}
+
+	return Endpoint{}, false
+}
+
+// Flatten returns the connection block of the preferred endpoint, it is empty when the database cannot be reached yet
+func Flatten(engine string, endpoints []Endpoint, caCert string, tls bool) []map[string]any {
+	endpoint, ok := Preferred(endpoints)
+	if !ok {
+		return nil
+	}
+
+	conn := Connection{
+		Engine:   engine,
+		Endpoint: endpoint,
+		CACert:   caCert,
+		TLS:      tls,
+	}
+
+	return []map[string]any{{
+		"engine":        conn.Engine,
+		"endpoint_kind": conn.Endpoint.Kind,
+		"host":          conn.Endpoint.Host,
+		"port":          conn.Endpoint.Port,
+		"ca_cert":       conn.CACert,
+		"dsn_template":  conn.DSNTemplate(),
+	}}
+}
+
+// DSNTemplate returns the engine specific URI of the connection with placeholders for the credentials
+func (c Connection) DSNTemplate() string {
+	hostPort := net.JoinHostPort(c.Endpoint.Host, strconv.Itoa(c.Endpoint.Port))
+	userInfo := PlaceholderUser + ":" + PlaceholderPassword + "@"
+
+	switch c.Engine {
+	case EnginePostgreSQL:
+		return "postgresql://" + userInfo + hostPort + "/" + PlaceholderDatabase + "?sslmode=require"
+	case EngineMySQL:
+		return "mysql://" + userInfo + hostPort + "/" + PlaceholderDatabase + "?ssl-mode=REQUIRED"
+	case EngineMongoDB:
+		// the users of managed instances are defined in the admin database, not in the database of the URI
+		return "mongodb://" + userInfo + hostPort + "/" + PlaceholderDatabase + "?authSource=admin&tls=true"
+	case EngineRedis:
+		scheme := "redis://"
+		if c.TLS {
+			scheme = "rediss://"
+		}
+
+		return scheme + userInfo + hostPort + "/0"
+	case EngineClickHouse:
+		return "clickhouse://" + userInfo + hostPort + "/" + PlaceholderDatabase + "?secure=true"
+	default:
+		return ""
+	}
+}
+
+// EngineFromRDB returns the engine of an RDB engine name such as PostgreSQL-15 or MySQL-8
+func EngineFromRDB(engine string) string {
+	switch name, _, _ := strings.Cut(strings.ToLower(engine), "-"); name {
+	case EnginePostgreSQL, EngineMySQL:
+		return name
+	default:
+		return ""
+	}
+}
+
+// BuildDSN replaces the placeholders of a template with the escaped credentials.
+// The user info is removed when user is empty and the password when password is empty.
+func BuildDSN(template, user, password, database string) string {
+	switch {
+	case user == "":
+		template = strings.Replace(template, PlaceholderUser+":"+PlaceholderPassword+"@", "", 1)
+	case password == "":
+		template = strings.Replace(template, ":"+PlaceholderPassword, "", 1)
+	}
+
+	escapedUser := url.User(user).String()
+	escapedPassword := strings.TrimPrefix(url.UserPassword("", password).String(), ":")
+
+	return strings.NewReplacer(
+		PlaceholderUser, escapedUser,
+		PlaceholderPassword, escapedPassword,
+		PlaceholderDatabase, url.PathEscape(database),
+	).Replace(template)
+}
@@ -10,6 +10,8 @@ const (
 	TestDomain = "TF_TEST_DOMAIN"
 	// TestDomainZone is the DNS zone used during our tests
 	TestDomainZone = "TF_TEST_DOMAIN_ZONE"
+	// TestPostgresURL is the URL of a PostgreSQL server, such as a local container, used by the tests of the pgsql package
+	TestPostgresURL = "TF_TEST_POSTGRES_URL"
 	// AppendUserAgent is appended to the user agent of the underlying SDK go
 	AppendUserAgent = "TF_APPEND_USER_AGENT"
 	// AccDomainRegistration if set to "true" will trigger acceptance test for domain registration
@@ -0,0 +1,141 @@
+package functions
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
+)
+
+var (
+	_ function.Function = &DatabaseDSN{}
+
+	errNoConnection = errors.New("no connection block found, expected a scaleway_rdb_instance, scaleway_mongodb_instance, scaleway_redis_cluster or scaleway_datawarehouse_deployment or its connection block")
+	errUnknown      = errors.New("value is not known yet")
+)
+
+type DatabaseDSN struct{}
+
+func NewDatabaseDSN() function.Function {
+	return &DatabaseDSN{}
+}
+
+func (f *DatabaseDSN) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "database_dsn"
+}
+
+func (f *DatabaseDSN) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the connection URI of a managed database",
+		Description: "Given a managed database resource (or its connection block), returns the engine specific connection URI " +
+			"of its preferred endpoint with the escaped credentials. Pass an ephemeral password to get an ephemeral URI " +
+			"that can be used in write-only arguments without being stored in the state.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "resource",
+				Description: "scaleway_rdb_instance, scaleway_mongodb_instance, scaleway_redis_cluster or scaleway_datawarehouse_deployment, or its connection block",
+			},
+			function.StringParameter{
+				Name:           "user",
+				Description:    "user to connect with, the user info is omitted when null or empty",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:           "password",
+				Description:    "password of the user, omitted when null or empty",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:           "database",
+				Description:    "database to connect to, ignored by redis",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DatabaseDSN) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		resource types.Dynamic
+		user     types.String
+		password types.String
+		database types.String
+	)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &resource, &user, &password, &database))
+	if resp.Error != nil {
+		return
+	}
+
+	template, err := dsnTemplate(resource)
+	if errors.Is(err, errUnknown) {
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, basetypes.NewStringUnknown()))
+
+		return
+	}
+
+	dsn := connection.BuildDSN(template, user.ValueString(), password.ValueString(), database.ValueString())
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.StringValue(dsn)))
+}
+
+// dsnTemplate looks for the dsn_template of a resource, its connection list or a connection block
+func dsnTemplate(value attr.Value) (string, error) {
+	if value == nil || value.IsNull() {
+		return "", errNoConnection
+	}
+
+	if value.IsUnknown() {
+		return "", errUnknown
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return dsnTemplate(v.UnderlyingValue())
+	case basetypes.ListValue:
+		return firstDSNTemplate(v.Elements())
+	case basetypes.TupleValue:
+		return firstDSNTemplate(v.Elements())
+	case basetypes.ObjectValue:
+		attributes := v.Attributes()
+
+		if conn, ok := attributes["connection"]; ok {
+			return dsnTemplate(conn)
+		}
+
+		template, ok := attributes["dsn_template"]
+		if !ok {
+			return "", errNoConnection
+		}
+
+		if template.IsUnknown() {
+			return "", errUnknown
+		}
+
+		if s, ok := template.(basetypes.StringValue); ok && s.ValueString() != "" {
+			return s.ValueString(), nil
+		}
+	}
+
+	return "", errNoConnection
+}
+
+func firstDSNTemplate(elements []attr.Value) (string, error) {
+	if len(elements) == 0 {
+		return "", errNoConnection
+	}
+
+	return dsnTemplate(elements[0])
+}
@@ -124,6 +124,10 @@ func ExtractHTTPClient(m any) *http.Client {
 	return m.(*Meta).HTTPClient()
 }
 
+func ExtractDNSProtectRecords(m any) []string {
+	return m.(*Meta).DNSProtectRecords()
+}
+
 func getKeyInRawConfigMap(rawConfig map[string]cty.Value, key string, ty cty.Type) (any, bool) {
 	if key == "" {
 		return rawConfig, false
@@ -48,6 +48,8 @@ type Meta struct {
 	httpClient *http.Client
 	// credentialsSource stores information about the source (env, profile, etc.) of each credential
 	credentialsSource *CredentialsSource
+	// dnsProtectRecords are the patterns of the DNS records that must not be deleted, see the dns_protect_records provider attribute
+	dnsProtectRecords []string
 }
 
 // NewMeta creates the Meta object containing the SDK client.
@@ -92,7 +94,22 @@ func NewMeta(ctx context.Context, config *Config) (*Meta, error) {
 	// Return scaleway client
 	////
 
-	return NewMetaFromProfile(ctx, profile, credentialsSource, config.TerraformVersion, config.HTTPClient)
+	m, err := NewMetaFromProfile(ctx, profile, credentialsSource, config.TerraformVersion, config.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ProviderSchema != nil {
+		for _, pattern := range config.ProviderSchema.Get("dns_protect_records").([]any) {
+			m.dnsProtectRecords = append(m.dnsProtectRecords, pattern.(string))
+		}
+	}
+
+	if config.ForceDNSProtectRecords != nil {
+		m.dnsProtectRecords = config.ForceDNSProtectRecords
+	}
+
+	return m, nil
 }
 
 // NewMetaFromFrameworkConfig creates a Meta object from FrameworkProviderConfig
@@ -102,7 +119,14 @@ func NewMetaFromFrameworkConfig(ctx context.Context, config *FrameworkProviderCo
 		return nil, err
 	}
 
-	return NewMetaFromProfile(ctx, profile, credentialsSource, terraformVersion, nil)
+	m, err := NewMetaFromProfile(ctx, profile, credentialsSource, terraformVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m.dnsProtectRecords = config.DNSProtectRecords
+
+	return m, nil
 }
 
 func NewMetaFromProfile(ctx context.Context, profile *scw.Profile, credentialsSource *CredentialsSource, terraformVersion string, httpClient *http.Client) (*Meta, error) {
@@ -160,6 +184,10 @@ func (m Meta) OrganizationIDSource() string {
 	return m.credentialsSource.OrganizationID
 }
 
+func (m Meta) DNSProtectRecords() []string {
+	return m.dnsProtectRecords
+}
+
 // HasMultipleVariableSources return an informative message during the Provider initialization
 // if there are multiple sources of configuration that could confuse the user
 //
@@ -217,6 +245,8 @@ type Config struct {
 	ForceOrganizationID string
 	ForceAccessKey      string
 	ForceSecretKey      string
+	// ForceDNSProtectRecords replaces the dns_protect_records provider attribute, which is only read from ProviderSchema
+	ForceDNSProtectRecords []string
 }
 
 func customizeUserAgent(providerVersion string, terraformVersion string) string {
@@ -230,14 +260,15 @@ func customizeUserAgent(providerVersion string, terraformVersion string) string
 }
 
 type FrameworkProviderConfig struct {
-	AccessKey      string
-	SecretKey      string
-	ProfileName    string
-	ProjectID      string
-	OrganizationID string
-	Region         string
-	Zone           string
-	APIURL         string
+	AccessKey         string
+	SecretKey         string
+	ProfileName       string
+	ProjectID         string
+	OrganizationID    string
+	Region            string
+	Zone              string
+	APIURL            string
+	DNSProtectRecords []string
 }
 
 func LoadProfileFromFrameworkConfig(ctx context.Context, config *FrameworkProviderConfig) (*scw.Profile, *CredentialsSource, error) {
@@ -0,0 +1,42 @@
+// Package mutexkv serializes the operations of the resources sharing a remote object during a provider run,
+// e.g. the rules of an ACL which is replaced as a whole by the API.
+package mutexkv
+
+import (
+	"sync"
+)
+
+// MutexKV is a set of mutexes identified by a key
+type MutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func NewMutexKV() *MutexKV {
+	return &MutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
+
+// Lock locks the mutex of the given key, creating it on first use
+func (m *MutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+// Unlock unlocks the mutex of the given key
+func (m *MutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *MutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+
+	return mutex
+}
@@ -0,0 +1,395 @@
+package pgsql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Object types supported by grants
+const (
+	ObjectTypeDatabase = "database"
+	ObjectTypeSchema   = "schema"
+	ObjectTypeTable    = "table"
+	ObjectTypeSequence = "sequence"
+	ObjectTypeFunction = "function"
+)
+
+// ObjectTypes lists the object types supported by grants
+var ObjectTypes = []string{
+	ObjectTypeDatabase,
+	ObjectTypeSchema,
+	ObjectTypeTable,
+	ObjectTypeSequence,
+	ObjectTypeFunction,
+}
+
+// Privileges lists the privileges that can be granted on each object type
+var Privileges = map[string][]string{
+	ObjectTypeDatabase: {"CONNECT", "CREATE", "TEMPORARY"},
+	ObjectTypeSchema:   {"CREATE", "USAGE"},
+	ObjectTypeTable:    {"DELETE", "INSERT", "REFERENCES", "SELECT", "TRIGGER", "TRUNCATE", "UPDATE"},
+	ObjectTypeSequence: {"SELECT", "UPDATE", "USAGE"},
+	ObjectTypeFunction: {"EXECUTE"},
+}
+
+const publicRole = "public"
+
+// QuoteIdentifier quotes an identifier such as a role, schema or table name
+func QuoteIdentifier(name string) string {
+	return pgx.Identifier{name}.Sanitize()
+}
+
+// QuoteLiteral quotes a string constant
+func QuoteLiteral(value string) string {
+	quoted := "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	if strings.Contains(value, `\`) {
+		return "E" + strings.ReplaceAll(quoted, `\`, `\\`)
+	}
+
+	return quoted
+}
+
+func quoteRole(role string) string {
+	if strings.EqualFold(role, publicRole) {
+		return "PUBLIC"
+	}
+
+	return QuoteIdentifier(role)
+}
+
+// NormalizePrivilege returns the privilege as reported by the catalog, "temp" is an alias of "TEMPORARY"
+func NormalizePrivilege(privilege string) string {
+	privilege = strings.ToUpper(strings.TrimSpace(privilege))
+	if privilege == "TEMP" {
+		return "TEMPORARY"
+	}
+
+	return privilege
+}
+
+func firstValue(rows Rows) *string {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil
+	}
+
+	return rows[0][0]
+}
+
+// Extension is an extension installed in a database
+type Extension struct {
+	Name    string
+	Schema  string
+	Version string
+}
+
+// CreateExtensionSQL returns the statement installing an extension, schema and version are optional
+func CreateExtensionSQL(extension *Extension, cascade bool) string {
+	query := "CREATE EXTENSION IF NOT EXISTS " + QuoteIdentifier(extension.Name)
+
+	if extension.Schema != "" {
+		query += " SCHEMA " + QuoteIdentifier(extension.Schema)
+	}
+
+	if extension.Version != "" {
+		query += " VERSION " + QuoteLiteral(extension.Version)
+	}
+
+	if cascade {
+		query += " CASCADE"
+	}
+
+	return query
+}
+
+// DropExtensionSQL returns the statement removing an extension
+func DropExtensionSQL(name string, cascade bool) string {
+	return "DROP EXTENSION IF EXISTS " + QuoteIdentifier(name) + dropBehavior(cascade)
+}
+
+// ReadExtension returns the extension installed with the given name, nil when it is not installed
+func ReadExtension(ctx context.Context, conn *Conn, name string) (*Extension, error) {
+	rows, err := conn.Query(ctx, "SELECT n.nspname, e.extversion FROM pg_extension e JOIN pg_namespace n ON n.oid = e.extnamespace WHERE e.extname = "+QuoteLiteral(name))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 || len(rows[0]) < 2 || rows[0][0] == nil || rows[0][1] == nil {
+		return nil, nil
+	}
+
+	return &Extension{
+		Name:    name,
+		Schema:  *rows[0][0],
+		Version: *rows[0][1],
+	}, nil
+}
+
+// UpdateExtensionSQL returns the statements moving an extension to another schema or version, empty values are left unchanged
+func UpdateExtensionSQL(extension *Extension) string {
+	var statements []string
+
+	if extension.Schema != "" {
+		statements = append(statements, "ALTER EXTENSION "+QuoteIdentifier(extension.Name)+" SET SCHEMA "+QuoteIdentifier(extension.Schema))
+	}
+
+	if extension.Version != "" {
+		statements = append(statements, "ALTER EXTENSION "+QuoteIdentifier(extension.Name)+" UPDATE TO "+QuoteLiteral(extension.Version))
+	}
+
+	return strings.Join(statements, "; ")
+}
+
+// CreateSchemaSQL returns the statement creating a schema, owner is optional
+func CreateSchemaSQL(name string, owner string) string {
+	query := "CREATE SCHEMA " + QuoteIdentifier(name)
+	if owner != "" {
+		query += " AUTHORIZATION " + QuoteIdentifier(owner)
+	}
+
+	return query
+}
+
+// AlterSchemaOwnerSQL returns the statement changing the owner of a schema
+func AlterSchemaOwnerSQL(name string, owner string) string {
+	return "ALTER SCHEMA " + QuoteIdentifier(name) + " OWNER TO " + QuoteIdentifier(owner)
+}
+
+// DropSchemaSQL returns the statement removing a schema
+func DropSchemaSQL(name string, cascade bool) string {
+	return "DROP SCHEMA IF EXISTS " + QuoteIdentifier(name) + dropBehavior(cascade)
+}
+
+// ReadSchemaOwner returns the owner of a schema, nil when the schema does not exist
+func ReadSchemaOwner(ctx context.Context, conn *Conn, name string) (*string, error) {
+	rows, err := conn.Query(ctx, "SELECT pg_get_userbyid(nspowner) FROM pg_namespace WHERE nspname = "+QuoteLiteral(name))
+	if err != nil {
+		return nil, err
+	}
+
+	return firstValue(rows), nil
+}
+
+func dropBehavior(cascade bool) string {
+	if cascade {
+		return " CASCADE"
+	}
+
+	return " RESTRICT"
+}
+
+// Grant is a set of privileges granted to a role on a database, a schema or objects of a schema.
+// Grants on tables, sequences or functions without Objects apply to all the objects of this type in the schema.
+type Grant struct {
+	Role            string
+	ObjectType      string
+	Database        string
+	Schema          string
+	Objects         []string
+	Privileges      []string
+	WithGrantOption bool
+	// DefaultPrivilegesFor also grants the privileges on the objects this role creates in the schema later on
+	DefaultPrivilegesFor string
+}
+
+// ValidateGrant checks that the privileges and scope of a grant match its object type
+func ValidateGrant(grant *Grant) error {
+	available, ok := Privileges[grant.ObjectType]
+	if !ok {
+		return fmt.Errorf("unsupported object type %q, expected one of %s", grant.ObjectType, strings.Join(ObjectTypes, ", "))
+	}
+
+	for _, privilege := range grant.Privileges {
+		if !slices.Contains(available, NormalizePrivilege(privilege)) {
+			return fmt.Errorf("privilege %q cannot be granted on a %s, expected one of %s", privilege, grant.ObjectType, strings.Join(available, ", "))
+		}
+	}
+
+	switch grant.ObjectType {
+	case ObjectTypeDatabase:
+		if grant.Schema != "" || len(grant.Objects) > 0 || grant.DefaultPrivilegesFor != "" {
+			return fmt.Errorf("schema, objects and default privileges cannot be set on a %s grant", grant.ObjectType)
+		}
+	case ObjectTypeSchema:
+		if grant.Schema == "" {
+			return fmt.Errorf("schema must be set on a %s grant", grant.ObjectType)
+		}
+
+		if len(grant.Objects) > 0 || grant.DefaultPrivilegesFor != "" {
+			return fmt.Errorf("objects and default privileges cannot be set on a %s grant", grant.ObjectType)
+		}
+	default:
+		if grant.Schema == "" {
+			return fmt.Errorf("schema must be set on a %s grant", grant.ObjectType)
+		}
+	}
+
+	return nil
+}
+
+// grantTarget is the ON clause of the GRANT and REVOKE statements
+func grantTarget(grant *Grant) string {
+	switch grant.ObjectType {
+	case ObjectTypeDatabase:
+		return "DATABASE " + QuoteIdentifier(grant.Database)
+	case ObjectTypeSchema:
+		return "SCHEMA " + QuoteIdentifier(grant.Schema)
+	}
+
+	keyword := strings.ToUpper(grant.ObjectType)
+
+	if len(grant.Objects) == 0 {
+		return "ALL " + keyword + "S IN SCHEMA " + QuoteIdentifier(grant.Schema)
+	}
+
+	objects := make([]string, 0, len(grant.Objects))
+	for _, object := range grant.Objects {
+		objects = append(objects, QuoteIdentifier(grant.Schema)+"."+QuoteIdentifier(object))
+	}
+
+	return keyword + " " + strings.Join(objects, ", ")
+}
+
+func grantPrivileges(grant *Grant) string {
+	privileges := make([]string, 0, len(grant.Privileges))
+	for _, privilege := range grant.Privileges {
+		privileges = append(privileges, NormalizePrivilege(privilege))
+	}
+
+	slices.Sort(privileges)
+
+	return strings.Join(slices.Compact(privileges), ", ")
+}
+
+func defaultPrivilegesPrefix(grant *Grant) string {
+	return "ALTER DEFAULT PRIVILEGES FOR ROLE " + QuoteIdentifier(grant.DefaultPrivilegesFor) + " IN SCHEMA " + QuoteIdentifier(grant.Schema) + " "
+}
+
+// GrantSQL returns the statements granting the privileges
+func GrantSQL(grant *Grant) string {
+	withGrantOption := ""
+	if grant.WithGrantOption {
+		withGrantOption = " WITH GRANT OPTION"
+	}
+
+	statements := []string{
+		"GRANT " + grantPrivileges(grant) + " ON " + grantTarget(grant) + " TO " + quoteRole(grant.Role) + withGrantOption,
+	}
+
+	if grant.DefaultPrivilegesFor != "" {
+		statements = append(statements, defaultPrivilegesPrefix(grant)+
+			"GRANT "+grantPrivileges(grant)+" ON "+strings.ToUpper(grant.ObjectType)+"S TO "+quoteRole(grant.Role)+withGrantOption)
+	}
+
+	return strings.Join(statements, "; ")
+}
+
+// RevokeSQL returns the statements revoking every privilege of the role on the objects of the grant
+func RevokeSQL(grant *Grant) string {
+	statements := []string{
+		"REVOKE ALL PRIVILEGES ON " + grantTarget(grant) + " FROM " + quoteRole(grant.Role),
+	}
+
+	if grant.DefaultPrivilegesFor != "" {
+		statements = append(statements, defaultPrivilegesPrefix(grant)+
+			"REVOKE ALL PRIVILEGES ON "+strings.ToUpper(grant.ObjectType)+"S FROM "+quoteRole(grant.Role))
+	}
+
+	return strings.Join(statements, "; ")
+}
+
+// grantObjectFilter restricts the objects of a grant to its Objects, when set
+func grantObjectFilter(grant *Grant, column string) string {
+	if len(grant.Objects) == 0 {
+		return ""
+	}
+
+	objects := make([]string, 0, len(grant.Objects))
+	for _, object := range grant.Objects {
+		objects = append(objects, QuoteLiteral(object))
+	}
+
+	return " AND " + column + " IN (" + strings.Join(objects, ", ") + ")"
+}
+
+// grantObjectsSQL selects the OID and the access privileges of the objects of a grant
+func grantObjectsSQL(grant *Grant) string {
+	switch grant.ObjectType {
+	case ObjectTypeDatabase:
+		return "SELECT oid, COALESCE(datacl, acldefault('d', datdba)) AS acl FROM pg_database WHERE datname = " + QuoteLiteral(grant.Database)
+	case ObjectTypeSchema:
+		return "SELECT oid, COALESCE(nspacl, acldefault('n', nspowner)) AS acl FROM pg_namespace WHERE nspname = " + QuoteLiteral(grant.Schema)
+	case ObjectTypeFunction:
+		return "SELECT p.oid, COALESCE(p.proacl, acldefault('f', p.proowner)) AS acl FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace" +
+			" WHERE n.nspname = " + QuoteLiteral(grant.Schema) + " AND p.prokind = 'f'" + grantObjectFilter(grant, "p.proname")
+	}
+
+	relkinds, aclType := "'r', 'p', 'v', 'm', 'f'", "r"
+	if grant.ObjectType == ObjectTypeSequence {
+		relkinds, aclType = "'S'", "s"
+	}
+
+	return "SELECT c.oid, COALESCE(c.relacl, acldefault('" + aclType + "', c.relowner)) AS acl FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace" +
+		" WHERE n.nspname = " + QuoteLiteral(grant.Schema) + " AND c.relkind IN (" + relkinds + ")" + grantObjectFilter(grant, "c.relname")
+}
+
+// ReadGrantSQL returns a query listing the privileges the role holds on every object of the grant.
+// Each row holds a privilege, whether the role can grant it on every object and the number of objects.
+// A single row with a NULL privilege is returned when the role holds no privilege on every object.
+func ReadGrantSQL(grant *Grant) string {
+	grantee := "(SELECT oid FROM pg_roles WHERE rolname = " + QuoteLiteral(grant.Role) + ")"
+	if strings.EqualFold(grant.Role, publicRole) {
+		grantee = "0"
+	}
+
+	return "WITH objects AS (" + grantObjectsSQL(grant) + "), total AS (SELECT count(*) AS n FROM objects)" +
+		" SELECT p.privilege_type, p.grantable, total.n FROM total LEFT JOIN (" +
+		"SELECT a.privilege_type, bool_and(a.is_grantable) AS grantable, count(DISTINCT o.oid) AS n" +
+		" FROM objects o, aclexplode(o.acl) a WHERE a.grantee = " + grantee + " GROUP BY a.privilege_type" +
+		") p ON p.n = total.n ORDER BY p.privilege_type"
+}
+
+// GrantState is the state of a grant in the database
+type GrantState struct {
+	Privileges      []string
+	WithGrantOption bool
+	ObjectCount     int
+}
+
+// ReadGrant returns the privileges the role holds on every object of the grant
+func ReadGrant(ctx context.Context, conn *Conn, grant *Grant) (*GrantState, error) {
+	rows, err := conn.Query(ctx, ReadGrantSQL(grant))
+	if err != nil {
+		return nil, err
+	}
+
+	state := &GrantState{WithGrantOption: true}
+
+	for _, row := range rows {
+		if len(row) < 3 || row[2] == nil {
+			return nil, fmt.Errorf("unexpected row %v", row)
+		}
+
+		state.ObjectCount, err = strconv.Atoi(*row[2])
+		if err != nil {
+			return nil, err
+		}
+
+		if row[0] == nil {
+			continue
+		}
+
+		state.Privileges = append(state.Privileges, *row[0])
+		state.WithGrantOption = state.WithGrantOption && row[1] != nil && *row[1] == "t"
+	}
+
+	if len(state.Privileges) == 0 {
+		state.WithGrantOption = false
+	}
+
+	return state, nil
+}
@@ -0,0 +1,170 @@
+// Package pgsql manages database objects of a PostgreSQL server, connections go through pgx.
+package pgsql
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	defaultPort     = 5432
+	defaultTimeout  = 30 * time.Second
+	applicationName = "terraform-provider-scaleway"
+)
+
+// Config holds the parameters of a connection
+type Config struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Database string
+	// TLS enables TLS when set, the connection fails if the server does not support it
+	TLS *tls.Config
+	// ConnectTimeout defaults to 30 seconds
+	ConnectTimeout time.Duration
+}
+
+// ParseURL parses a postgres:// URL. TLS is enabled unless sslmode is "disable".
+func ParseURL(rawURL string) (*Config, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return nil, fmt.Errorf("unsupported scheme %q, expected postgres", u.Scheme)
+	}
+
+	config := &Config{
+		Host:     u.Hostname(),
+		Port:     defaultPort,
+		Database: strings.TrimPrefix(u.Path, "/"),
+	}
+
+	if u.Port() != "" {
+		config.Port, err = strconv.Atoi(u.Port())
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: %w", u.Port(), err)
+		}
+	}
+
+	if u.User != nil {
+		config.User = u.User.Username()
+		config.Password, _ = u.User.Password()
+	}
+
+	if u.Query().Get("sslmode") != "disable" {
+		config.TLS = &tls.Config{
+			ServerName: config.Host,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return config, nil
+}
+
+// IsErrorCode returns true when err is a server error with the given SQLSTATE code
+func IsErrorCode(err error, code string) bool {
+	pgErr := &pgconn.PgError{}
+
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+// Conn is a connection to a PostgreSQL server, it must not be used concurrently
+type Conn struct {
+	conn *pgx.Conn
+}
+
+// Rows are the rows returned by a query in text format, NULL values are nil
+type Rows [][]*string
+
+// Connect opens and authenticates a connection
+func Connect(ctx context.Context, config *Config) (*Conn, error) {
+	// The empty connection string only sets the defaults, every parameter of the connection is overridden below
+	pgxConfig, err := pgx.ParseConfig("")
+	if err != nil {
+		return nil, err
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = defaultPort
+	}
+
+	pgxConfig.Host = config.Host
+	pgxConfig.Port = uint16(port) //nolint:gosec // ports are validated by the schema
+	pgxConfig.User = config.User
+	pgxConfig.Password = config.Password
+	pgxConfig.Database = config.Database
+	pgxConfig.TLSConfig = config.TLS
+	// Without fallbacks, the connection fails instead of retrying without TLS
+	pgxConfig.Fallbacks = nil
+	pgxConfig.RuntimeParams["application_name"] = applicationName
+
+	pgxConfig.ConnectTimeout = config.ConnectTimeout
+	if pgxConfig.ConnectTimeout == 0 {
+		pgxConfig.ConnectTimeout = defaultTimeout
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, pgxConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Conn{conn: conn}, nil
+}
+
+// Query runs one or more SQL statements with the simple query protocol and returns the rows of the last one.
+// Statements sent together run in a single implicit transaction.
+func (c *Conn) Query(ctx context.Context, query string) (Rows, error) {
+	results, err := c.conn.PgConn().Exec(ctx, query).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	last := results[len(results)-1]
+	rows := make(Rows, 0, len(last.Rows))
+
+	for _, row := range last.Rows {
+		values := make([]*string, len(row))
+
+		for i, value := range row {
+			if value != nil {
+				values[i] = new(string(value))
+			}
+		}
+
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+// Exec runs one or more SQL statements and discards their rows
+func (c *Conn) Exec(ctx context.Context, query string) error {
+	_, err := c.conn.Exec(ctx, query)
+
+	return err
+}
+
+// Close terminates the connection
+func (c *Conn) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	return c.conn.Close(ctx)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	datawarehouseapi "github.com/scaleway/scaleway-sdk-go/api/datawarehouse/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
@@ -214,6 +215,7 @@ func deploymentSchema() map[string]*schema.Schema {
 			},
 		},
 		// Computed
+		"connection": connection.Schema(),
 		"status": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -360,6 +362,8 @@ func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, meta an
 		_ = d.Set("private_network", privateBlock.([]map[string]any))
 	}
 
+	_ = d.Set("connection", flattenConnection(deployment.Endpoints))
+
 	return nil
 }
 
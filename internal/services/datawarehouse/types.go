@@ -3,6 +3,7 @@ package datawarehouse
 import (
 	datawarehouseapi "github.com/scaleway/scaleway-sdk-go/api/datawarehouse/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 )
 
@@ -68,3 +69,31 @@ func flattenPrivateNetwork(endpoints []*datawarehouseapi.Endpoint, region scw.Re
 
 	return privateFlat, len(privateFlat) != 0
 }
+
+// clickhouseNativeProtocol is the protocol of the ClickHouse native TLS service, used by the DSN template
+const clickhouseNativeProtocol = "tcp"
+
+// flattenConnection returns the connection block of a deployment using the port of its native ClickHouse service.
+// The certificates of the deployments are signed by a public CA, no CA certificate is exported.
+func flattenConnection(endpoints []*datawarehouseapi.Endpoint) []map[string]any {
+	connectionEndpoints := make([]connection.Endpoint, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		kind := connection.KindPublicNetwork
+		if endpoint.PrivateNetwork != nil {
+			kind = connection.KindPrivateNetwork
+		}
+
+		for _, svc := range endpoint.Services {
+			if string(svc.Protocol) == clickhouseNativeProtocol {
+				connectionEndpoints = append(connectionEndpoints, connection.Endpoint{
+					Kind: kind,
+					Host: endpoint.DNSRecord,
+					Port: int(svc.Port),
+				})
+			}
+		}
+	}
+
+	return connection.Flatten(connection.EngineClickHouse, connectionEndpoints, "", true)
+}
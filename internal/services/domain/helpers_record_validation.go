@@ -0,0 +1,364 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+const (
+	maxDomainNameLength  = 253
+	maxDomainLabelLength = 63
+	maxTXTStringLength   = 255
+)
+
+var (
+	domainLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?$`)
+	caaTagRegex      = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+)
+
+// ValidateRecord checks the name and the data of a record against the syntax of its type,
+// so that a typo fails at plan time instead of in the middle of an apply.
+func ValidateRecord(name string, recordType domain.RecordType, data string, dnsZone string) error {
+	name = normalizeRecordName(name, dnsZone)
+
+	if err := validateRecordName(name, dnsZone); err != nil {
+		return err
+	}
+
+	data = strings.TrimSpace(data)
+
+	switch recordType {
+	case domain.RecordTypeA:
+		if ip := net.ParseIP(data); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("A record data %q must be an IPv4 address", data)
+		}
+	case domain.RecordTypeAAAA:
+		if ip := net.ParseIP(data); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("AAAA record data %q must be an IPv6 address", data)
+		}
+	case domain.RecordTypeCNAME:
+		if name == "" {
+			return errors.New("a CNAME record cannot be created at the zone apex, where the SOA and NS records live, use an ALIAS record instead")
+		}
+
+		return validateRecordTarget(recordType, data)
+	case domain.RecordTypeNS, domain.RecordTypeALIAS, domain.RecordTypePTR:
+		return validateRecordTarget(recordType, data)
+	case domain.RecordTypeMX:
+		if fields := strings.Fields(data); len(fields) == 2 {
+			if _, err := strconv.ParseUint(fields[0], 10, 16); err == nil {
+				return fmt.Errorf("MX record data %q must only contain the mail server, set %s with the priority attribute", data, fields[0])
+			}
+		}
+
+		return validateRecordTarget(recordType, data)
+	case domain.RecordTypeSRV:
+		return validateSRVData(data)
+	case domain.RecordTypeCAA:
+		return validateCAAData(data)
+	case domain.RecordTypeTXT:
+		return validateTXTData(data)
+	}
+
+	return nil
+}
+
+// validateRecordName checks the labels of a record name relative to its zone, a wildcard is only allowed as the first label
+func validateRecordName(name string, dnsZone string) error {
+	if name == "" {
+		return nil
+	}
+
+	if fqdn := name + "." + strings.TrimSuffix(dnsZone, "."); len(fqdn) > maxDomainNameLength {
+		return fmt.Errorf("record name %q is too long, %s is more than %d characters", name, fqdn, maxDomainNameLength)
+	}
+
+	for i, label := range strings.Split(name, ".") {
+		if label == "*" && i == 0 {
+			continue
+		}
+
+		if err := validateDomainLabel(label); err != nil {
+			return fmt.Errorf("invalid record name %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateDomainLabel(label string) error {
+	switch {
+	case label == "":
+		return errors.New("empty label")
+	case label == "*":
+		return errors.New("a wildcard is only allowed as the first label")
+	case len(label) > maxDomainLabelLength:
+		return fmt.Errorf("label %q is longer than %d characters", label, maxDomainLabelLength)
+	case !domainLabelRegex.MatchString(label):
+		return fmt.Errorf("label %q must only contain letters, digits, hyphens and underscores, and cannot start or end with a hyphen", label)
+	}
+
+	return nil
+}
+
+// validateHostname checks a target host name, relative to the zone or absolute with a trailing dot
+func validateHostname(hostname string) error {
+	if hostname == "@" {
+		return nil
+	}
+
+	trimmed := strings.TrimSuffix(hostname, ".")
+	if trimmed == "" {
+		return errors.New("empty host name")
+	}
+
+	if len(trimmed) > maxDomainNameLength {
+		return fmt.Errorf("host name %q is longer than %d characters", hostname, maxDomainNameLength)
+	}
+
+	for label := range strings.SplitSeq(trimmed, ".") {
+		if err := validateDomainLabel(label); err != nil {
+			return fmt.Errorf("invalid host name %q: %w", hostname, err)
+		}
+	}
+
+	return nil
+}
+
+func validateRecordTarget(recordType domain.RecordType, data string) error {
+	if net.ParseIP(strings.TrimSuffix(data, ".")) != nil {
+		return fmt.Errorf("%s record data %q must be a host name, not an IP address", recordType, data)
+	}
+
+	if strings.ContainsAny(data, " \t") {
+		return fmt.Errorf("%s record data %q must be a single host name", recordType, data)
+	}
+
+	if err := validateHostname(data); err != nil {
+		return fmt.Errorf("%s record data: %w", recordType, err)
+	}
+
+	return nil
+}
+
+// validateSRVData checks the "[priority] weight port target" format of SRV data, the priority may be set with the priority attribute
+func validateSRVData(data string) error {
+	fields := strings.Fields(data)
+	if len(fields) != 3 && len(fields) != 4 {
+		return fmt.Errorf("SRV record data %q must be in the \"priority weight port target\" format", data)
+	}
+
+	numbers, target := fields[:len(fields)-1], fields[len(fields)-1]
+
+	// A numeric target is a forgotten target, e.g. "0 0 5060" read as "weight port target"
+	if _, err := strconv.ParseUint(target, 10, 64); err == nil {
+		return fmt.Errorf("SRV record data %q must be in the \"priority weight port target\" format", data)
+	}
+
+	for _, number := range numbers {
+		if _, err := strconv.ParseUint(number, 10, 16); err != nil {
+			return fmt.Errorf("SRV record data %q: %q must be a number between 0 and 65535", data, number)
+		}
+	}
+
+	if target == "." {
+		return nil
+	}
+
+	if err := validateHostname(target); err != nil {
+		return fmt.Errorf("SRV record data %q: %w", data, err)
+	}
+
+	return nil
+}
+
+// validateCAAData checks the "flags tag value" format of CAA data (RFC 8659)
+func validateCAAData(data string) error {
+	fields := strings.SplitN(data, " ", 3)
+	if len(fields) != 3 {
+		return fmt.Errorf("CAA record data %q must be in the \"flags tag value\" format, e.g. 0 issue \"letsencrypt.org\"", data)
+	}
+
+	flags, tag, value := fields[0], fields[1], strings.TrimSpace(fields[2])
+
+	if _, err := strconv.ParseUint(flags, 10, 8); err != nil {
+		return fmt.Errorf("CAA record data %q: flags %q must be a number between 0 and 255", data, flags)
+	}
+
+	if !caaTagRegex.MatchString(tag) {
+		return fmt.Errorf("CAA record data %q: tag %q must only contain letters and digits", data, tag)
+	}
+
+	if strings.HasPrefix(value, `"`) != strings.HasSuffix(value, `"`) || value == `"` {
+		return fmt.Errorf("CAA record data %q: value %s has unbalanced quotes", data, value)
+	}
+
+	if strings.EqualFold(tag, "iodef") {
+		u, err := url.Parse(strings.Trim(value, `"`))
+		if err != nil || (u.Scheme != "mailto" && u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("CAA record data %q: the iodef value must be a mailto:, http:// or https:// URL", data)
+		}
+	}
+
+	return nil
+}
+
+// validateTXTData checks the quoting of TXT data, each quoted string is limited to 255 characters
+func validateTXTData(data string) error {
+	if !strings.HasPrefix(data, `"`) {
+		return nil
+	}
+
+	inString := false
+	length := 0
+
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; {
+		case c == '\\' && inString:
+			i++
+			length++
+		case c == '"':
+			if inString && length > maxTXTStringLength {
+				return fmt.Errorf("TXT record data: a quoted string is longer than %d characters, split it in several quoted strings", maxTXTStringLength)
+			}
+
+			inString = !inString
+			length = 0
+		case inString:
+			length++
+		case c != ' ' && c != '\t':
+			return fmt.Errorf("TXT record data %q: text outside of the quoted strings", data)
+		}
+	}
+
+	if inString {
+		return fmt.Errorf("TXT record data %q has unbalanced quotes", data)
+	}
+
+	return nil
+}
+
+// checkCNAMEExclusivity rejects a CNAME sharing its name with other records, and a record added next to a CNAME
+func checkCNAMEExclusivity(name string, recordType domain.RecordType, existing []*domain.Record) error {
+	displayName := name
+	if displayName == "" {
+		displayName = "@"
+	}
+
+	for _, record := range existing {
+		if record.Name != name {
+			continue
+		}
+
+		switch {
+		case recordType == domain.RecordTypeCNAME && record.Type != domain.RecordTypeCNAME:
+			return fmt.Errorf("a CNAME record cannot be created for %s, a %s record already exists with this name", displayName, record.Type)
+		case recordType != domain.RecordTypeCNAME && record.Type == domain.RecordTypeCNAME:
+			return fmt.Errorf("a %s record cannot be created for %s, a CNAME record already exists with this name", recordType, displayName)
+		}
+	}
+
+	return nil
+}
+
+// ValidateProtectRecordPattern validates a "[name/]type" pattern of the dns_protect_records provider attribute
+func ValidateProtectRecordPattern() schema.SchemaValidateDiagFunc {
+	return func(i any, p cty.Path) diag.Diagnostics {
+		if _, _, err := parseProtectRecordPattern(i.(string)); err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       err.Error(),
+				AttributePath: p,
+			}}
+		}
+
+		return nil
+	}
+}
+
+func parseProtectRecordPattern(pattern string) (string, string, error) {
+	namePattern, typePattern, ok := strings.Cut(pattern, "/")
+	if !ok {
+		namePattern, typePattern = "*", pattern
+	}
+
+	if namePattern == "@" {
+		namePattern = ""
+	}
+
+	if _, err := path.Match(namePattern, ""); err != nil {
+		return "", "", fmt.Errorf("invalid name in DNS record pattern %q: %w", pattern, err)
+	}
+
+	typePattern = strings.ToUpper(typePattern)
+	if typePattern != "*" && !isKnownRecordType(typePattern) {
+		return "", "", fmt.Errorf("unknown record type in DNS record pattern %q, expected [name/]type, e.g. NS, @/A or */MX", pattern)
+	}
+
+	return namePattern, typePattern, nil
+}
+
+func isKnownRecordType(recordType string) bool {
+	for _, value := range domain.RecordType("").Values() {
+		if value.String() == recordType && value != domain.RecordTypeUnknown {
+			return true
+		}
+	}
+
+	return false
+}
+
+// protectedRecordPattern returns the dns_protect_records pattern matching a record, the name is relative to the zone
+func protectedRecordPattern(patterns []string, name string, recordType domain.RecordType) (string, bool) {
+	for _, pattern := range patterns {
+		namePattern, typePattern, err := parseProtectRecordPattern(pattern)
+		if err != nil {
+			continue
+		}
+
+		if typePattern != "*" && typePattern != recordType.String() {
+			continue
+		}
+
+		if matched, _ := path.Match(namePattern, name); matched {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+// checkProtectedRecordsDeletion refuses to delete the records matching the dns_protect_records provider attribute
+func checkProtectedRecordsDeletion(m any, dnsZone string, records []*domain.Record) error {
+	patterns := meta.ExtractDNSProtectRecords(m)
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	for _, record := range records {
+		name := normalizeRecordName(record.Name, dnsZone)
+
+		if pattern, ok := protectedRecordPattern(patterns, name, record.Type); ok {
+			displayName := name
+			if displayName == "" {
+				displayName = "@"
+			}
+
+			return fmt.Errorf("record %s %s of zone %s is protected by the %q pattern of the dns_protect_records provider attribute, set allow_protected_delete to true to delete it", displayName, record.Type, dnsZone, pattern)
+		}
+	}
+
+	return nil
+}
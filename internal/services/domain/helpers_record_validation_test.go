@@ -0,0 +1,128 @@
+//nolint:testpackage // Tests need access to unexported record validation helpers.
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+)
+
+func TestValidateRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		recordName string
+		recordType domain.RecordType
+		data       string
+		wantErr    string
+	}{
+		{name: "A", recordName: "www", recordType: domain.RecordTypeA, data: "192.0.2.1"},
+		{name: "A with IPv6", recordName: "www", recordType: domain.RecordTypeA, data: "2001:db8::1", wantErr: "must be an IPv4 address"},
+		{name: "AAAA with IPv4", recordName: "www", recordType: domain.RecordTypeAAAA, data: "192.0.2.1", wantErr: "must be an IPv6 address"},
+		{name: "wildcard", recordName: "*.dev", recordType: domain.RecordTypeA, data: "192.0.2.1"},
+		{name: "wildcard in the middle", recordName: "dev.*", recordType: domain.RecordTypeA, data: "192.0.2.1", wantErr: "a wildcard is only allowed as the first label"},
+		{name: "fqdn name", recordName: "_dmarc.example.com.", recordType: domain.RecordTypeTXT, data: "v=DMARC1; p=none"},
+		{name: "empty label", recordName: "a..b", recordType: domain.RecordTypeA, data: "192.0.2.1", wantErr: "empty label"},
+		{name: "hyphen", recordName: "-www", recordType: domain.RecordTypeA, data: "192.0.2.1", wantErr: "cannot start or end with a hyphen"},
+		{name: "long label", recordName: strings.Repeat("a", 64), recordType: domain.RecordTypeA, data: "192.0.2.1", wantErr: "longer than 63 characters"},
+		{name: "CNAME", recordName: "www", recordType: domain.RecordTypeCNAME, data: "example.com."},
+		{name: "CNAME at the apex", recordName: "@", recordType: domain.RecordTypeCNAME, data: "example.org.", wantErr: "zone apex"},
+		{name: "CNAME to an IP", recordName: "www", recordType: domain.RecordTypeCNAME, data: "192.0.2.1", wantErr: "not an IP address"},
+		{name: "MX", recordName: "", recordType: domain.RecordTypeMX, data: "mail.example.com."},
+		{name: "MX with priority", recordName: "", recordType: domain.RecordTypeMX, data: "10 mail.example.com.", wantErr: "set 10 with the priority attribute"},
+		{name: "SRV", recordName: "_sip._tcp", recordType: domain.RecordTypeSRV, data: "100 1 3128 bigbox.example.com."},
+		{name: "SRV without priority", recordName: "_sip._tcp", recordType: domain.RecordTypeSRV, data: "1 3128 sip"},
+		{name: "SRV with a large port", recordName: "_sip._tcp", recordType: domain.RecordTypeSRV, data: "0 0 70000 sip", wantErr: `"70000" must be a number`},
+		{name: "SRV without target", recordName: "_sip._tcp", recordType: domain.RecordTypeSRV, data: "0 0 5060", wantErr: "priority weight port target"},
+		{name: "CAA", recordName: "", recordType: domain.RecordTypeCAA, data: `0 issue "letsencrypt.org"`},
+		{name: "CAA iodef", recordName: "", recordType: domain.RecordTypeCAA, data: `0 iodef "mailto:security@example.com"`},
+		{name: "CAA bad iodef", recordName: "", recordType: domain.RecordTypeCAA, data: `0 iodef "security@example.com"`, wantErr: "iodef value"},
+		{name: "CAA bad flags", recordName: "", recordType: domain.RecordTypeCAA, data: `256 issue "letsencrypt.org"`, wantErr: "between 0 and 255"},
+		{name: "CAA unbalanced", recordName: "", recordType: domain.RecordTypeCAA, data: `0 issue "letsencrypt.org`, wantErr: "unbalanced quotes"},
+		{name: "TXT", recordName: "", recordType: domain.RecordTypeTXT, data: "v=spf1 -all"},
+		{name: "TXT quoted", recordName: "", recordType: domain.RecordTypeTXT, data: `"part 1" "part \"2\""`},
+		{name: "TXT unbalanced", recordName: "", recordType: domain.RecordTypeTXT, data: `"part 1" "part 2`, wantErr: "unbalanced quotes"},
+		{name: "TXT long string", recordName: "", recordType: domain.RecordTypeTXT, data: `"` + strings.Repeat("a", 256) + `"`, wantErr: "longer than 255 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateRecord(tt.recordName, tt.recordType, tt.data, "example.com")
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckCNAMEExclusivity(t *testing.T) {
+	t.Parallel()
+
+	existing := []*domain.Record{
+		{Name: "www", Type: domain.RecordTypeA},
+		{Name: "blog", Type: domain.RecordTypeCNAME},
+	}
+
+	if err := checkCNAMEExclusivity("www", domain.RecordTypeCNAME, existing); err == nil {
+		t.Fatal("expected an error for a CNAME next to an A record")
+	}
+
+	if err := checkCNAMEExclusivity("blog", domain.RecordTypeTXT, existing); err == nil {
+		t.Fatal("expected an error for a TXT record next to a CNAME")
+	}
+
+	if err := checkCNAMEExclusivity("blog", domain.RecordTypeCNAME, existing); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := checkCNAMEExclusivity("www", domain.RecordTypeAAAA, existing); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestProtectedRecordPattern(t *testing.T) {
+	t.Parallel()
+
+	patterns := []string{"NS", "@/A", "*/mx", "_dmarc*/TXT"}
+
+	tests := []struct {
+		name       string
+		recordType domain.RecordType
+		want       string
+	}{
+		{name: "", recordType: domain.RecordTypeNS, want: "NS"},
+		{name: "sub", recordType: domain.RecordTypeNS, want: "NS"},
+		{name: "", recordType: domain.RecordTypeA, want: "@/A"},
+		{name: "www", recordType: domain.RecordTypeA},
+		{name: "", recordType: domain.RecordTypeMX, want: "*/mx"},
+		{name: "_dmarc.sub", recordType: domain.RecordTypeTXT, want: "_dmarc*/TXT"},
+		{name: "", recordType: domain.RecordTypeTXT},
+	}
+
+	for _, tt := range tests {
+		pattern, ok := protectedRecordPattern(patterns, tt.name, tt.recordType)
+		if pattern != tt.want || ok != (tt.want != "") {
+			t.Errorf("%q %s: got %q, want %q", tt.name, tt.recordType, pattern, tt.want)
+		}
+	}
+
+	for _, pattern := range []string{"NS", "@/A", "*", "www/*"} {
+		if _, _, err := parseProtectRecordPattern(pattern); err != nil {
+			t.Errorf("%q: unexpected error: %s", pattern, err)
+		}
+	}
+
+	for _, pattern := range []string{"NOPE", "www/", "[/A"} {
+		if _, _, err := parseProtectRecordPattern(pattern); err == nil {
+			t.Errorf("%q: expected an error", pattern)
+		}
+	}
+}
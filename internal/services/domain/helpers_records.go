@@ -0,0 +1,505 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/zonefile"
+)
+
+// recordsScope selects the records a scaleway_domain_records resource owns
+type recordsScope struct {
+	dnsZone string
+	names   map[string]bool
+	types   map[domain.RecordType]bool
+	// skipDynamic leaves the geo_ip, http_service, view and weighted records alone, they cannot be written in a zone file
+	skipDynamic bool
+}
+
+func newRecordsScope(dnsZone string, names []any, types []any, skipDynamic bool) recordsScope {
+	scope := recordsScope{
+		dnsZone:     dnsZone,
+		names:       map[string]bool{},
+		types:       map[domain.RecordType]bool{},
+		skipDynamic: skipDynamic,
+	}
+
+	for _, name := range names {
+		scope.names[normalizeRecordName(name.(string), dnsZone)] = true
+	}
+
+	for _, recordType := range types {
+		scope.types[domain.RecordType(recordType.(string))] = true
+	}
+
+	return scope
+}
+
+// recordsID returns the ID of a scaleway_domain_records resource. A scoped resource gets the {dns_zone}/{names}/{types}
+// form with comma-separated sorted lists, so resources sharing a zone get distinct IDs and an import restores the scope.
+// The zone apex is written "@".
+func recordsID(dnsZone string, names []any, types []any) string {
+	if len(names) == 0 && len(types) == 0 {
+		return dnsZone
+	}
+
+	idNames := make([]string, 0, len(names))
+	for _, name := range names {
+		idName := name.(string)
+		if idName == "" {
+			idName = "@"
+		}
+
+		idNames = append(idNames, idName)
+	}
+
+	idTypes := make([]string, 0, len(types))
+	for _, recordType := range types {
+		idTypes = append(idTypes, recordType.(string))
+	}
+
+	slices.Sort(idNames)
+	slices.Sort(idTypes)
+
+	return strings.Join([]string{dnsZone, strings.Join(idNames, ","), strings.Join(idTypes, ",")}, "/")
+}
+
+// parseRecordsID returns the DNS zone, names and types encoded by recordsID
+func parseRecordsID(id string) (string, []string, []string, error) {
+	parts := strings.Split(id, "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return parts[0], nil, nil, nil
+	case len(parts) == 3 && parts[0] != "":
+		var names, types []string
+
+		if parts[1] != "" {
+			names = strings.Split(parts[1], ",")
+		}
+
+		if parts[2] != "" {
+			types = strings.Split(parts[2], ",")
+		}
+
+		return parts[0], names, types, nil
+	default:
+		return "", nil, nil, fmt.Errorf("invalid ID %q, expected {dns_zone} or {dns_zone}/{names}/{types} with comma-separated names and types", id)
+	}
+}
+
+// contains returns true when the record is owned. The NS records of the zone apex and the SOA are never owned,
+// they are managed by Scaleway.
+func (s recordsScope) contains(record *domain.Record) bool {
+	name := normalizeRecordName(record.Name, s.dnsZone)
+
+	switch {
+	case name == "" && record.Type == domain.RecordTypeNS:
+		return false
+	case record.Type == domain.RecordType("SOA"):
+		return false
+	case len(s.names) > 0 && !s.names[name]:
+		return false
+	case len(s.types) > 0 && !s.types[record.Type]:
+		return false
+	case s.skipDynamic && isDynamicRecord(record):
+		return false
+	}
+
+	return true
+}
+
+func (s recordsScope) filter(records []*domain.Record) []*domain.Record {
+	filtered := []*domain.Record(nil)
+
+	for _, record := range records {
+		if s.contains(record) {
+			filtered = append(filtered, record)
+		}
+	}
+
+	return filtered
+}
+
+func isDynamicRecord(record *domain.Record) bool {
+	return record.GeoIPConfig != nil || record.HTTPServiceConfig != nil || record.ViewConfig != nil || record.WeightedConfig != nil
+}
+
+// dynamicRecordKind returns the name of the dynamic configuration of a record, as in the scaleway_domain_record schema
+func dynamicRecordKind(record *domain.Record) string {
+	switch {
+	case record.GeoIPConfig != nil:
+		return "geo_ip"
+	case record.HTTPServiceConfig != nil:
+		return "http_service"
+	case record.ViewConfig != nil:
+		return "view"
+	case record.WeightedConfig != nil:
+		return "weighted"
+	default:
+		return ""
+	}
+}
+
+func canonicalRecordData(data string, recordType domain.RecordType, dnsZone string) string {
+	return NormalizeRecordData(FlattenDomainData(data, recordType, dnsZone).(string), recordType, dnsZone)
+}
+
+// recordGroupKey identifies the records the API can set or delete at once
+func recordGroupKey(dnsZone string, record *domain.Record) string {
+	return normalizeRecordName(record.Name, dnsZone) + "/" + record.Type.String()
+}
+
+// recordContentKey identifies a record by the name, type and data that ValidateRecord checks
+func recordContentKey(dnsZone string, record *domain.Record) string {
+	return recordGroupKey(dnsZone, record) + "|" + canonicalRecordData(record.Data, record.Type, dnsZone)
+}
+
+// recordFingerprint identifies a record by its content, two records with the same fingerprint are equivalent
+func recordFingerprint(dnsZone string, record *domain.Record) string {
+	priority := uint32(0)
+	if record.Type == domain.RecordTypeMX {
+		priority = record.Priority
+	}
+
+	var geoIPMatches []*domain.RecordGeoIPConfigMatch
+	if record.GeoIPConfig != nil {
+		geoIPMatches = record.GeoIPConfig.Matches
+	}
+
+	dynamic, _ := json.Marshal([]any{geoIPMatches, record.HTTPServiceConfig, record.ViewConfig, record.WeightedConfig})
+
+	return strings.Join([]string{
+		recordGroupKey(dnsZone, record),
+		canonicalRecordData(record.Data, record.Type, dnsZone),
+		strconv.FormatUint(uint64(record.TTL), 10),
+		strconv.FormatUint(uint64(priority), 10),
+		string(dynamic),
+	}, "|")
+}
+
+// expandRecordsSet returns the records of the record blocks in the form the API expects
+func expandRecordsSet(dnsZone string, rawRecords []any) []*domain.Record {
+	records := make([]*domain.Record, 0, len(rawRecords))
+
+	for _, rawRecord := range rawRecords {
+		records = append(records, expandRecordsElem(dnsZone, rawRecord.(map[string]any)))
+	}
+
+	return records
+}
+
+func expandRecordsElem(dnsZone string, rawRecord map[string]any) *domain.Record {
+	recordType := domain.RecordType(rawRecord["type"].(string))
+	recordData := NormalizeRecordData(rawRecord["data"].(string), recordType, dnsZone)
+	geoIP := rawRecord["geo_ip"].([]any)
+	httpService := rawRecord["http_service"].([]any)
+	view := rawRecord["view"].([]any)
+	weighted := rawRecord["weighted"].([]any)
+
+	return &domain.Record{
+		Name:              normalizeRecordName(rawRecord["name"].(string), dnsZone),
+		Type:              recordType,
+		Data:              recordData,
+		TTL:               uint32(rawRecord["ttl"].(int)),
+		Priority:          uint32(rawRecord["priority"].(int)),
+		GeoIPConfig:       expandDomainGeoIPConfig(recordData, geoIP, len(geoIP) > 0),
+		HTTPServiceConfig: expandDomainHTTPService(httpService, len(httpService) > 0),
+		WeightedConfig:    expandDomainWeighted(weighted, len(weighted) > 0),
+		ViewConfig:        expandDomainView(view, len(view) > 0),
+	}
+}
+
+// flattenRecordsSet returns the record blocks of records. A record equivalent to a previous block keeps the form
+// of that block, e.g. "www" instead of "www.example.com." so that the set does not change when the API normalizes the data.
+func flattenRecordsSet(dnsZone string, records []*domain.Record, previous []any) []any {
+	previousByFingerprint := make(map[string]map[string]any, len(previous))
+	for _, rawRecord := range previous {
+		rawMap := rawRecord.(map[string]any)
+		previousByFingerprint[recordFingerprint(dnsZone, expandRecordsElem(dnsZone, rawMap))] = rawMap
+	}
+
+	flattened := make([]any, 0, len(records))
+
+	for _, record := range records {
+		if rawRecord, ok := previousByFingerprint[recordFingerprint(dnsZone, record)]; ok {
+			flattened = append(flattened, rawRecord)
+
+			continue
+		}
+
+		priority := 0
+		if record.Type == domain.RecordTypeMX {
+			priority = int(record.Priority)
+		}
+
+		flattened = append(flattened, map[string]any{
+			"name":         record.Name,
+			"type":         record.Type.String(),
+			"data":         FlattenDomainData(record.Data, record.Type, dnsZone).(string),
+			"ttl":          int(record.TTL),
+			"priority":     priority,
+			"geo_ip":       flattenDomainGeoIP(record.GeoIPConfig),
+			"http_service": flattenDomainHTTPService(record.HTTPServiceConfig),
+			"view":         flattenDomainView(record.ViewConfig),
+			"weighted":     flattenDomainWeighted(record.WeightedConfig),
+		})
+	}
+
+	return flattened
+}
+
+// zoneFileRecords returns the record blocks of a zone file
+func zoneFileRecords(dnsZone string, zoneFile string) ([]any, error) {
+	parsed, err := zonefile.Parse(strings.NewReader(zoneFile), dnsZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zone_file: %w", err)
+	}
+
+	records := make([]any, 0, len(parsed))
+
+	for _, record := range parsed {
+		recordType := domain.RecordType(record.Type)
+		data := record.Data
+		priority := 0
+
+		switch recordType {
+		case domain.RecordTypeMX:
+			rawPriority, target, ok := strings.Cut(data, " ")
+			if !ok {
+				return nil, fmt.Errorf("invalid zone_file: MX record %q must have a priority and a target", record.Name)
+			}
+
+			priority, err = strconv.Atoi(rawPriority)
+			if err != nil {
+				return nil, fmt.Errorf("invalid zone_file: MX record %q has an invalid priority %q", record.Name, rawPriority)
+			}
+
+			data = target
+		case domain.RecordTypeTXT:
+			data = zonefile.Unquote(data)
+		}
+
+		records = append(records, map[string]any{
+			"name":         record.Name,
+			"type":         recordType.String(),
+			"data":         data,
+			"ttl":          int(record.TTL),
+			"priority":     priority,
+			"geo_ip":       []any{},
+			"http_service": []any{},
+			"view":         []any{},
+			"weighted":     []any{},
+		})
+	}
+
+	return records, nil
+}
+
+// exportZoneFile writes the static records of a zone as a zone file. Dynamic records are written as comments
+// since the zone file format cannot express them, only their default data is shown.
+func exportZoneFile(dnsZone string, records []*domain.Record) string {
+	static := []zonefile.Record(nil)
+	dynamic := []string(nil)
+
+	for _, record := range records {
+		data := FlattenDomainData(record.Data, record.Type, dnsZone).(string)
+
+		switch record.Type {
+		case domain.RecordTypeMX:
+			data = strconv.FormatUint(uint64(record.Priority), 10) + " " + NormalizeTargetFQDN(data, dnsZone)
+		case domain.RecordTypeCNAME, domain.RecordTypeNS:
+			data = NormalizeTargetFQDN(data, dnsZone)
+		case domain.RecordTypeTXT:
+			data = zonefile.Quote(data)
+		}
+
+		zoneRecord := zonefile.Record{
+			Name: normalizeRecordName(record.Name, dnsZone),
+			TTL:  record.TTL,
+			Type: record.Type.String(),
+			Data: data,
+		}
+
+		if isDynamicRecord(record) {
+			name := zoneRecord.Name
+			if name == "" {
+				name = "@"
+			}
+
+			dynamic = append(dynamic, fmt.Sprintf("; %s\t%d\tIN\t%s\t%s ; %s record, see the records attribute", name, zoneRecord.TTL, zoneRecord.Type, zoneRecord.Data, dynamicRecordKind(record)))
+
+			continue
+		}
+
+		static = append(static, zoneRecord)
+	}
+
+	output := zonefile.Format(dnsZone, static)
+
+	if len(dynamic) > 0 {
+		slices.Sort(dynamic)
+		output += "; dynamic records cannot be written in a zone file\n" + strings.Join(dynamic, "\n") + "\n"
+	}
+
+	return output
+}
+
+// planRecordChanges returns the changes that turn the owned records of a zone into the desired ones.
+// A group of records with the same name and type is added, set or deleted at once when the resource owns all of it,
+// otherwise its records are added and deleted one by one so that the records it does not own are kept.
+func planRecordChanges(scope recordsScope, zoneRecords []*domain.Record, desired []*domain.Record) []*domain.RecordChange {
+	dnsZone := scope.dnsZone
+	groupSizes := map[string]int{}
+	current := map[string][]*domain.Record{}
+	wanted := map[string][]*domain.Record{}
+
+	for _, record := range zoneRecords {
+		key := recordGroupKey(dnsZone, record)
+		groupSizes[key]++
+
+		if scope.contains(record) {
+			current[key] = append(current[key], record)
+		}
+	}
+
+	for _, record := range desired {
+		key := recordGroupKey(dnsZone, record)
+		wanted[key] = append(wanted[key], record)
+	}
+
+	keys := make([]string, 0, len(current)+len(wanted))
+	for key := range current {
+		keys = append(keys, key)
+	}
+
+	for key := range wanted {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	changes := []*domain.RecordChange(nil)
+
+	for _, key := range keys {
+		currentRecords, wantedRecords := current[key], wanted[key]
+		added, removed := diffRecords(dnsZone, currentRecords, wantedRecords)
+
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		owned := groupSizes[key] == len(currentRecords)
+		name, recordType, _ := strings.Cut(key, "/")
+		idFields := &domain.RecordIdentifier{Name: name, Type: domain.RecordType(recordType)}
+
+		switch {
+		case len(currentRecords) == 0:
+			changes = append(changes, &domain.RecordChange{Add: &domain.RecordChangeAdd{Records: wantedRecords}})
+		case owned && len(wantedRecords) == 0:
+			changes = append(changes, &domain.RecordChange{Delete: &domain.RecordChangeDelete{IDFields: idFields}})
+		case owned:
+			changes = append(changes, &domain.RecordChange{Set: &domain.RecordChangeSet{IDFields: idFields, Records: wantedRecords}})
+		default:
+			for _, record := range removed {
+				changes = append(changes, &domain.RecordChange{Delete: &domain.RecordChangeDelete{ID: new(record.ID)}})
+			}
+
+			if len(added) > 0 {
+				changes = append(changes, &domain.RecordChange{Add: &domain.RecordChangeAdd{Records: added}})
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffRecords matches records by fingerprint, duplicates are matched one to one
+func diffRecords(dnsZone string, current, wanted []*domain.Record) (added, removed []*domain.Record) {
+	remaining := map[string]int{}
+	for _, record := range current {
+		remaining[recordFingerprint(dnsZone, record)]++
+	}
+
+	for _, record := range wanted {
+		fingerprint := recordFingerprint(dnsZone, record)
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+
+			continue
+		}
+
+		added = append(added, record)
+	}
+
+	for _, record := range current {
+		fingerprint := recordFingerprint(dnsZone, record)
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+
+			removed = append(removed, record)
+		}
+	}
+
+	return added, removed
+}
+
+// removedRecordGroups returns the current records whose name and type are not in the desired records anymore,
+// the records of a group that is only changed are updated in place
+func removedRecordGroups(dnsZone string, current, desired []*domain.Record) []*domain.Record {
+	kept := map[string]bool{}
+	for _, record := range desired {
+		kept[recordGroupKey(dnsZone, record)] = true
+	}
+
+	removed := []*domain.Record(nil)
+
+	for _, record := range current {
+		if !kept[recordGroupKey(dnsZone, record)] {
+			removed = append(removed, record)
+		}
+	}
+
+	return removed
+}
+
+func listZoneRecords(ctx context.Context, domainAPI *domain.API, dnsZone string) ([]*domain.Record, error) {
+	res, err := domainAPI.ListDNSZoneRecords(&domain.ListDNSZoneRecordsRequest{
+		DNSZone: dnsZone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Records, nil
+}
+
+// recordsElemSchema returns the schema of a record block, based on the scaleway_domain_record arguments
+func recordsElemSchema() map[string]*schema.Schema {
+	base := recordSchema()
+	elem := make(map[string]*schema.Schema)
+
+	for _, key := range []string{"name", "type", "data", "ttl", "priority", "geo_ip", "http_service", "view", "weighted"} {
+		s := *base[key]
+		s.ForceNew = false
+		s.ConflictsWith = nil
+		s.StateFunc = nil
+		s.DiffSuppressFunc = nil
+		elem[key] = &s
+	}
+
+	elem["priority"].Computed = false
+	elem["priority"].Default = 0
+	elem["priority"].Description = "The priority of the record, only used by MX records"
+
+	return elem
+}
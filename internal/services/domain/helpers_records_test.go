@@ -0,0 +1,159 @@
+//nolint:testpackage // Tests need access to unexported record set helpers.
+package domain
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+)
+
+const testRecordsZone = "example.com"
+
+func TestPlanRecordChanges(t *testing.T) {
+	t.Parallel()
+
+	zoneRecords := []*domain.Record{
+		{ID: "ns", Name: "", Type: domain.RecordTypeNS, Data: "ns0.dom.scw.cloud.", TTL: 1800},
+		{ID: "apex", Name: "", Type: domain.RecordTypeA, Data: "192.0.2.1", TTL: 3600},
+		{ID: "www", Name: "www", Type: domain.RecordTypeCNAME, Data: "example.com.", TTL: 3600},
+		{ID: "old", Name: "old", Type: domain.RecordTypeA, Data: "192.0.2.9", TTL: 3600},
+		{ID: "mx", Name: "", Type: domain.RecordTypeMX, Data: "10 mail.example.com.", Priority: 10, TTL: 3600},
+		{ID: "view", Name: "api", Type: domain.RecordTypeA, Data: "192.0.2.2", TTL: 3600, ViewConfig: &domain.RecordViewConfig{
+			Views: []*domain.RecordViewConfigView{{Subnet: "10.0.0.0/8", Data: "10.0.0.2"}},
+		}},
+		{ID: "api", Name: "api", Type: domain.RecordTypeA, Data: "192.0.2.3", TTL: 3600},
+	}
+
+	desired := []*domain.Record{
+		{Name: "", Type: domain.RecordTypeA, Data: "192.0.2.1", TTL: 3600},
+		{Name: "www", Type: domain.RecordTypeCNAME, Data: NormalizeRecordData("@", domain.RecordTypeCNAME, testRecordsZone), TTL: 300},
+		{Name: "", Type: domain.RecordTypeMX, Data: NormalizeRecordData("mail", domain.RecordTypeMX, testRecordsZone), Priority: 10, TTL: 3600},
+		{Name: "api", Type: domain.RecordTypeA, Data: "192.0.2.4", TTL: 3600},
+		{Name: "new", Type: domain.RecordTypeTXT, Data: "hello", TTL: 3600},
+	}
+
+	// The zone file mode does not own the dynamic records, the api group must be changed record by record
+	scope := newRecordsScope(testRecordsZone, nil, nil, true)
+	changes := planRecordChanges(scope, zoneRecords, desired)
+
+	summary := []string(nil)
+
+	for _, change := range changes {
+		switch {
+		case change.Add != nil:
+			for _, record := range change.Add.Records {
+				summary = append(summary, "add "+record.Name+" "+record.Type.String()+" "+record.Data)
+			}
+		case change.Set != nil:
+			summary = append(summary, "set "+change.Set.IDFields.Name+" "+change.Set.IDFields.Type.String())
+		case change.Delete != nil && change.Delete.ID != nil:
+			summary = append(summary, "delete "+*change.Delete.ID)
+		case change.Delete != nil:
+			summary = append(summary, "delete "+change.Delete.IDFields.Name+" "+change.Delete.IDFields.Type.String())
+		}
+	}
+
+	expected := []string{
+		"delete api",
+		"add api A 192.0.2.4",
+		"add new TXT hello",
+		"delete old A",
+		"set www CNAME",
+	}
+
+	if strings.Join(summary, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected changes:\n%s\nwant:\n%s", strings.Join(summary, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestFlattenRecordsSetKeepsConfiguredForm(t *testing.T) {
+	t.Parallel()
+
+	previous := []any{
+		map[string]any{
+			"name": "www", "type": "CNAME", "data": "example.com", "ttl": 3600, "priority": 0,
+			"geo_ip": []any{}, "http_service": []any{}, "view": []any{}, "weighted": []any{},
+		},
+	}
+	records := []*domain.Record{
+		{Name: "www", Type: domain.RecordTypeCNAME, Data: "example.com.", TTL: 3600},
+		{Name: "lb", Type: domain.RecordTypeA, Data: "192.0.2.1", TTL: 60, WeightedConfig: &domain.RecordWeightedConfig{
+			WeightedIPs: []*domain.RecordWeightedConfigWeightedIP{{IP: net.ParseIP("192.0.2.1"), Weight: 1}},
+		}},
+	}
+
+	flattened := flattenRecordsSet(testRecordsZone, records, previous)
+
+	if data := flattened[0].(map[string]any)["data"]; data != "example.com" {
+		t.Fatalf("data = %v, want the configured example.com", data)
+	}
+
+	if weighted := flattened[1].(map[string]any)["weighted"].([]map[string]any); len(weighted) != 1 {
+		t.Fatalf("weighted = %v", weighted)
+	}
+}
+
+func TestZoneFileRecordsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	records := []*domain.Record{
+		{Name: "", Type: domain.RecordTypeMX, Data: "10 mail.example.com.", Priority: 10, TTL: 3600},
+		{Name: "", Type: domain.RecordTypeTXT, Data: `"v=spf1 -all"`, TTL: 3600},
+		{Name: "www", Type: domain.RecordTypeCNAME, Data: "example.com.", TTL: 300},
+		{Name: "api", Type: domain.RecordTypeA, Data: "192.0.2.2", TTL: 3600, ViewConfig: &domain.RecordViewConfig{
+			Views: []*domain.RecordViewConfigView{{Subnet: "10.0.0.0/8", Data: "10.0.0.2"}},
+		}},
+	}
+
+	exported := exportZoneFile(testRecordsZone, records)
+	if !strings.Contains(exported, "; api\t3600\tIN\tA\t192.0.2.2 ; view record") {
+		t.Fatalf("the dynamic record is not commented out:\n%s", exported)
+	}
+
+	parsed, err := zoneFileRecords(testRecordsZone, exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed) != 3 {
+		t.Fatalf("expected the 3 static records, got %v", parsed)
+	}
+
+	added, removed := diffRecords(testRecordsZone, records[:3], expandRecordsSet(testRecordsZone, parsed))
+	if len(added) != 0 || len(removed) != 0 {
+		t.Fatalf("the records changed after a round trip: added %v, removed %v", added, removed)
+	}
+}
+
+func TestRecordsID(t *testing.T) {
+	t.Parallel()
+
+	if id := recordsID(testRecordsZone, nil, nil); id != testRecordsZone {
+		t.Fatalf("unexpected ID %q", id)
+	}
+
+	id := recordsID(testRecordsZone, []any{"www", ""}, []any{"TXT", "A"})
+	if id != "example.com/@,www/A,TXT" {
+		t.Fatalf("unexpected ID %q", id)
+	}
+
+	dnsZone, names, types, err := parseRecordsID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dnsZone != testRecordsZone || strings.Join(names, ",") != "@,www" || strings.Join(types, ",") != "A,TXT" {
+		t.Fatalf("unexpected scope %q %q %q", dnsZone, names, types)
+	}
+
+	dnsZone, names, types, err = parseRecordsID("example.com//MX")
+	if err != nil || dnsZone != testRecordsZone || names != nil || strings.Join(types, ",") != "MX" {
+		t.Fatalf("unexpected scope %q %q %q: %v", dnsZone, names, types, err)
+	}
+
+	if _, _, _, err := parseRecordsID("example.com/www"); err == nil {
+		t.Fatal("expected an error for an ID with two parts")
+	}
+}
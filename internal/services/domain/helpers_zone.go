@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+)
+
+// tsigAlgorithms are the TSIG algorithms accepted for zone transfers, as named in RFC 8945
+var tsigAlgorithms = []string{
+	"hmac-md5",
+	"hmac-sha1",
+	"hmac-sha224",
+	"hmac-sha256",
+	"hmac-sha384",
+	"hmac-sha512",
+}
+
+func expandZoneAXFRSource(raw []any) *domain.ImportRawDNSZoneRequestAXFRSource {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+
+	rawSecondary := raw[0].(map[string]any)
+	source := &domain.ImportRawDNSZoneRequestAXFRSource{
+		NameServer: rawSecondary["ns_master"].(string),
+	}
+
+	if rawKeys := rawSecondary["tsig_key"].([]any); len(rawKeys) > 0 && rawKeys[0] != nil {
+		rawKey := rawKeys[0].(map[string]any)
+		source.TsigKey = &domain.ImportRawDNSZoneRequestTsigKey{
+			Name:      rawKey["name"].(string),
+			Key:       rawKey["key"].(string),
+			Algorithm: rawKey["algorithm"].(string),
+		}
+	}
+
+	return source
+}
+
+// transferZone replaces the records of the zone with the ones pulled from the primary name server of the secondary block
+func transferZone(ctx context.Context, d *schema.ResourceData, domainAPI *domain.API, projectID string, timeout time.Duration) error {
+	source := expandZoneAXFRSource(d.Get("secondary").([]any))
+	if source == nil {
+		return nil
+	}
+
+	_, err := domainAPI.ImportRawDNSZone(&domain.ImportRawDNSZoneRequest{
+		DNSZone:    d.Id(),
+		ProjectID:  projectID,
+		AxfrSource: source,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("zone transfer from %s: %w", source.NameServer, err)
+	}
+
+	zone, err := waitForDNSZone(ctx, domainAPI, d.Id(), timeout)
+	if err != nil {
+		return err
+	}
+
+	if zone.Status == domain.DNSZoneStatusError {
+		return fmt.Errorf("zone transfer from %s failed: %s", source.NameServer, zoneMessage(zone))
+	}
+
+	return nil
+}
+
+// zoneFollowsPrimary tells whether the zone still pulls its records from the primary name server of the secondary block.
+// The zones for which the API does not report their primary name servers are assumed to follow it.
+func zoneFollowsPrimary(zone *domain.DNSZone, nsMaster string) bool {
+	if len(zone.NsMaster) == 0 {
+		return true
+	}
+
+	for _, ns := range zone.NsMaster {
+		if strings.EqualFold(strings.TrimSuffix(ns, "."), strings.TrimSuffix(nsMaster, ".")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func zoneMessage(zone *domain.DNSZone) string {
+	if zone.Message == nil {
+		return ""
+	}
+
+	return *zone.Message
+}
+
+// readZoneDNSSEC returns the DNSSEC status of a zone apex from the registrar API, which also knows the external domains
+func readZoneDNSSEC(ctx context.Context, registrarAPI *domain.RegistrarAPI, domainName string) (bool, []any, error) {
+	res, err := registrarAPI.GetDomain(&domain.RegistrarAPIGetDomainRequest{
+		Domain: domainName,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) || httperrors.Is403(err) {
+			return false, []any{}, nil
+		}
+
+		return false, nil, err
+	}
+
+	if res.Dnssec == nil {
+		return false, []any{}, nil
+	}
+
+	return res.Dnssec.Status == domain.DomainFeatureStatusEnabled, FlattenDSRecord(res.Dnssec.DsRecords), nil
+}
+
+// updateZoneDNSSEC enables or disables the signing of a zone apex and waits for the DS records
+func updateZoneDNSSEC(ctx context.Context, registrarAPI *domain.RegistrarAPI, domainName string, enable bool, timeout time.Duration) error {
+	res, err := registrarAPI.GetDomain(&domain.RegistrarAPIGetDomainRequest{
+		Domain: domainName,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return fmt.Errorf("domain %s is unknown to the registrar, register it or add it as an external domain to enable dnssec: %w", domainName, err)
+		}
+
+		return err
+	}
+
+	enabled := res.Dnssec != nil && res.Dnssec.Status == domain.DomainFeatureStatusEnabled
+
+	switch {
+	case enable && !enabled:
+		_, err = registrarAPI.EnableDomainDNSSEC(&domain.RegistrarAPIEnableDomainDNSSECRequest{
+			Domain: domainName,
+		}, scw.WithContext(ctx))
+	case !enable && enabled:
+		_, err = registrarAPI.DisableDomainDNSSEC(&domain.RegistrarAPIDisableDomainDNSSECRequest{
+			Domain: domainName,
+		}, scw.WithContext(ctx))
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForDNSSECStatus(ctx, registrarAPI, domainName, timeout)
+
+	return err
+}
@@ -0,0 +1,53 @@
+//nolint:testpackage // Tests need access to the unexported zone transfer helpers.
+package domain
+
+import (
+	"testing"
+
+	domainSDK "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+)
+
+func TestExpandZoneAXFRSource(t *testing.T) {
+	t.Parallel()
+
+	if source := expandZoneAXFRSource(nil); source != nil {
+		t.Fatalf("source = %v, want nil without secondary block", source)
+	}
+
+	source := expandZoneAXFRSource([]any{map[string]any{
+		"ns_master": "192.0.2.53",
+		"tsig_key":  []any{},
+	}})
+	if source.NameServer != "192.0.2.53" || source.TsigKey != nil {
+		t.Fatalf("source = %+v, want the name server without TSIG key", source)
+	}
+
+	source = expandZoneAXFRSource([]any{map[string]any{
+		"ns_master": "ns1.example.com",
+		"tsig_key": []any{map[string]any{
+			"name":      "transfer-key",
+			"algorithm": "hmac-sha256",
+			"key":       "c2VjcmV0",
+		}},
+	}})
+	if source.TsigKey == nil || source.TsigKey.Name != "transfer-key" || source.TsigKey.Algorithm != "hmac-sha256" || source.TsigKey.Key != "c2VjcmV0" {
+		t.Fatalf("tsig key = %+v, want the configured key", source.TsigKey)
+	}
+}
+
+func TestZoneFollowsPrimary(t *testing.T) {
+	t.Parallel()
+
+	if !zoneFollowsPrimary(&domainSDK.DNSZone{}, "192.0.2.53") {
+		t.Fatal("a zone without primary name servers should be assumed to follow the primary")
+	}
+
+	zone := &domainSDK.DNSZone{NsMaster: []string{"NS1.example.com."}}
+	if !zoneFollowsPrimary(zone, "ns1.example.com") {
+		t.Fatal("the primary name server should match regardless of case and trailing dot")
+	}
+
+	if zoneFollowsPrimary(zone, "192.0.2.53") {
+		t.Fatal("a zone listing another primary name server should not follow 192.0.2.53")
+	}
+}
@@ -50,6 +50,7 @@ func ResourceRecord() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    recordSchema,
+		CustomizeDiff: resourceDomainRecordCustomizeDiff,
 		Identity:      identity.WrapSchemaMap(recordIdentitySchema()),
 	}
 }
@@ -281,6 +282,12 @@ func recordSchema() map[string]*schema.Schema {
 			Description: "The FQDN of the record",
 			Computed:    true,
 		},
+		"allow_protected_delete": {
+			Type:        schema.TypeBool,
+			Description: "Allow the deletion of the record when it matches the dns_protect_records provider attribute",
+			Optional:    true,
+			Default:     false,
+		},
 		"project_id": account.ProjectIDSchema(),
 	}
 }
@@ -533,9 +540,21 @@ func resourceDomainRecordUpdate(ctx context.Context, d *schema.ResourceData, m a
 
 func resourceDomainRecordDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	domainAPI := NewDomainAPI(m)
+	dnsZone := d.Get("dns_zone").(string)
+
+	// CustomizeDiff does not run when the resource is destroyed, the protected records are checked here
+	if !d.Get("allow_protected_delete").(bool) {
+		err := checkProtectedRecordsDeletion(m, dnsZone, []*domain.Record{{
+			Name: d.Get("name").(string),
+			Type: domain.RecordType(d.Get("type").(string)),
+		}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	_, err := domainAPI.UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
-		DNSZone: d.Get("dns_zone").(string),
+		DNSZone: dnsZone,
 		Changes: []*domain.RecordChange{
 			{
 				Delete: &domain.RecordChangeDelete{
@@ -553,3 +572,65 @@ func resourceDomainRecordDelete(ctx context.Context, d *schema.ResourceData, m a
 
 	return nil
 }
+
+// resourceDomainRecordCustomizeDiff refuses to replace a protected record, validates a new or changed record against
+// the syntax of its type and checks that a CNAME does not share its name with other records. The latter is best effort,
+// the plan goes on when the zone cannot be listed.
+func resourceDomainRecordCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	// dns_zone, name and type force a replacement, which deletes the current record
+	if diff.Id() != "" && diff.HasChanges("dns_zone", "name", "type") && !diff.Get("allow_protected_delete").(bool) {
+		oldZone, _ := diff.GetChange("dns_zone")
+		oldName, _ := diff.GetChange("name")
+		oldType, _ := diff.GetChange("type")
+
+		err := checkProtectedRecordsDeletion(m, oldZone.(string), []*domain.Record{{
+			Name: oldName.(string),
+			Type: domain.RecordType(oldType.(string)),
+		}})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range []string{"dns_zone", "name", "type", "data"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	if diff.Id() != "" && !diff.HasChanges("dns_zone", "name", "type", "data") {
+		return nil
+	}
+
+	dnsZone := diff.Get("dns_zone").(string)
+	recordName := normalizeRecordName(diff.Get("name").(string), dnsZone)
+	recordType := domain.RecordType(diff.Get("type").(string))
+
+	if err := ValidateRecord(recordName, recordType, diff.Get("data").(string), dnsZone); err != nil {
+		return err
+	}
+
+	if diff.Id() != "" && !diff.HasChanges("dns_zone", "name", "type") {
+		return nil
+	}
+
+	res, err := NewDomainAPI(m).ListDNSZoneRecords(&domain.ListDNSZoneRecordsRequest{
+		DNSZone: dnsZone,
+		Name:    recordName,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("could not list the %q records of zone %s to check CNAME exclusivity: %s", recordName, dnsZone, err))
+
+		return nil
+	}
+
+	others := []*domain.Record(nil)
+
+	for _, record := range res.Records {
+		if record.ID != locality.ExpandID(diff.Id()) {
+			others = append(others, record)
+		}
+	}
+
+	return checkCNAMEExclusivity(recordName, recordType, others)
+}
@@ -22,6 +22,8 @@ func DataSourceRecord() *schema.Resource {
 	// Set 'Optional' schema elements
 	datasource.AddOptionalFieldsToSchema(dsSchema, "dns_zone", "name", "type", "data", "project_id")
 
+	delete(dsSchema, "allow_protected_delete")
+
 	dsSchema["name"].ConflictsWith = []string{"record_id"}
 	dsSchema["type"].ConflictsWith = []string{"record_id"}
 	dsSchema["data"].ConflictsWith = []string{"record_id"}
@@ -2,9 +2,12 @@ package domain_test
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	domainSDK "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
@@ -12,7 +15,10 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/logging"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/domain"
+	"github.com/scaleway/terraform-provider-scaleway/v2/provider"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccDomainRecord_Basic(t *testing.T) {
@@ -914,6 +920,135 @@ func TestAccDomainRecord_NameDiffSuppress(t *testing.T) {
 	})
 }
 
+func TestAccDomainRecord_ProtectRecords(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	testDNSZone := "test-protect-records." + acctest.TestDomain
+	logging.L.Debugf("TestAccDomainRecord_ProtectRecords: test dns zone: %s", testDNSZone)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protectRecordsProviderFactories(tt, "tf-protected/TXT"),
+		CheckDestroy:             testAccCheckDomainRecordDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_domain_record" "protected" {
+						dns_zone = "%[1]s"
+						name     = "tf-protected"
+						type     = "TXT"
+						data     = "protected"
+					}
+
+					resource "scaleway_domain_record" "unprotected" {
+						dns_zone = "%[1]s"
+						name     = "tf-unprotected"
+						type     = "TXT"
+						data     = "unprotected"
+					}
+				`, testDNSZone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDomainRecordExists(tt, "scaleway_domain_record.protected"),
+					testAccCheckDomainRecordExists(tt, "scaleway_domain_record.unprotected"),
+					resource.TestCheckResourceAttr("scaleway_domain_record.protected", "allow_protected_delete", "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_domain_record" "protected" {
+						dns_zone = "%[1]s"
+						name     = "tf-renamed"
+						type     = "TXT"
+						data     = "protected"
+					}
+
+					resource "scaleway_domain_record" "unprotected" {
+						dns_zone = "%[1]s"
+						name     = "tf-unprotected"
+						type     = "TXT"
+						data     = "unprotected"
+					}
+				`, testDNSZone),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`record tf-protected TXT of zone ` + regexp.QuoteMeta(testDNSZone) + ` is protected by the "tf-protected/TXT" pattern`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_domain_record" "unprotected" {
+						dns_zone = "%[1]s"
+						name     = "tf-unprotected"
+						type     = "TXT"
+						data     = "unprotected"
+					}
+				`, testDNSZone),
+				ExpectError: regexp.MustCompile(`is protected by the "tf-protected/TXT" pattern of the dns_protect_records provider attribute`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_domain_record" "protected" {
+						dns_zone               = "%[1]s"
+						name                   = "tf-protected"
+						type                   = "TXT"
+						data                   = "protected"
+						allow_protected_delete = true
+					}
+
+					resource "scaleway_domain_record" "unprotected" {
+						dns_zone = "%[1]s"
+						name     = "tf-unprotected"
+						type     = "TXT"
+						data     = "unprotected"
+					}
+				`, testDNSZone),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDomainRecordExists(tt, "scaleway_domain_record.protected"),
+					resource.TestCheckResourceAttr("scaleway_domain_record.protected", "allow_protected_delete", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_domain_record" "unprotected" {
+						dns_zone = "%[1]s"
+						name     = "tf-unprotected"
+						type     = "TXT"
+						data     = "unprotected"
+					}
+				`, testDNSZone),
+				Check: testAccCheckDomainRecordExists(tt, "scaleway_domain_record.unprotected"),
+			},
+		},
+	})
+}
+
+// protectRecordsProviderFactories returns the provider factories of the tests with the dns_protect_records provider
+// attribute set, acctest builds the meta of the provider itself so the attribute cannot be set in the configuration
+func protectRecordsProviderFactories(tt *acctest.TestTools, patterns ...string) map[string]func() (tfprotov6.ProviderServer, error) {
+	ctx := tt.T.Context()
+
+	m, err := meta.NewMeta(ctx, &meta.Config{
+		TerraformVersion:       "terraform-tests",
+		HTTPClient:             tt.Meta.HTTPClient(),
+		ForceDNSProtectRecords: patterns,
+	})
+	require.NoError(tt.T, err)
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"scaleway": func() (tfprotov6.ProviderServer, error) {
+			providers, errProvider := provider.NewProviderList(ctx, &provider.Config{Meta: m})
+			if errProvider != nil {
+				return nil, errProvider
+			}
+
+			muxServer, errMux := tf6muxserver.NewMuxServer(ctx, providers...)
+			if errMux != nil {
+				return nil, errMux
+			}
+
+			return muxServer.ProviderServer(), nil
+		},
+	}
+}
+
 func TestAccDomainRecord_TEMIntegration(t *testing.T) {
 	tt := acctest.NewTestTools(t)
 	defer tt.Cleanup()
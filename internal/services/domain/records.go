@@ -0,0 +1,360 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceRecords() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainRecordsCreate,
+		ReadContext:   resourceDomainRecordsRead,
+		UpdateContext: resourceDomainRecordsUpdate,
+		DeleteContext: resourceDomainRecordsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultDomainRecordTimeout),
+			Read:    schema.DefaultTimeout(defaultDomainRecordTimeout),
+			Update:  schema.DefaultTimeout(defaultDomainRecordTimeout),
+			Delete:  schema.DefaultTimeout(defaultDomainRecordTimeout),
+			Default: schema.DefaultTimeout(defaultDomainRecordTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDomainRecordsImport,
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    recordsSchema,
+		CustomizeDiff: resourceDomainRecordsCustomizeDiff,
+		Identity:      identity.DefaultGlobal(),
+		// The ID encodes names and types, it changes with them
+		ResourceBehavior: schema.ResourceBehavior{MutableIdentity: true},
+	}
+}
+
+func recordsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"dns_zone": {
+			Type:        schema.TypeString,
+			Description: "The zone the records belong to",
+			Required:    true,
+			ForceNew:    true,
+		},
+		"names": {
+			Type:        schema.TypeSet,
+			Description: "Only own the records with these names, all the names of the zone are owned when empty",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"types": {
+			Type:        schema.TypeSet,
+			Description: "Only own the records with these types, all the types are owned when empty",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: verify.ValidateEnum[domain.RecordType](),
+			},
+		},
+		"record": {
+			Type:          schema.TypeSet,
+			Description:   "The records of the zone, the owned records that are not listed are deleted",
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"zone_file"},
+			Elem: &schema.Resource{
+				Schema: recordsElemSchema(),
+			},
+		},
+		"zone_file": {
+			Type:          schema.TypeString,
+			Description:   "The records of the zone in the RFC 1035 zone file format, dynamic records are not owned in this mode",
+			Optional:      true,
+			ConflictsWith: []string{"record"},
+		},
+		"allow_protected_delete": {
+			Type:        schema.TypeBool,
+			Description: "Allow the deletion of the records matching the dns_protect_records provider attribute",
+			Optional:    true,
+			Default:     false,
+		},
+		"project_id": {
+			Type:        schema.TypeString,
+			Description: "The project ID of the zone",
+			Computed:    true,
+		},
+	}
+}
+
+// resourceDomainRecordsCustomizeDiff plans the records of the zone file, rejects the records outside of the owned names and types
+// and refuses to plan the deletion of protected records
+func resourceDomainRecordsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, m any) error {
+	// Changing dns_zone replaces the resource, which deletes all the owned records of the current zone
+	if diff.Id() != "" && diff.HasChange("dns_zone") && !diff.Get("allow_protected_delete").(bool) {
+		oldZone, _ := diff.GetChange("dns_zone")
+		oldRecords, _ := diff.GetChange("record")
+
+		err := checkProtectedRecordsDeletion(m, oldZone.(string), expandRecordsSet(oldZone.(string), oldRecords.(*schema.Set).List()))
+		if err != nil {
+			return err
+		}
+	}
+
+	if !diff.NewValueKnown("dns_zone") || !diff.NewValueKnown("zone_file") || !diff.NewValueKnown("names") || !diff.NewValueKnown("types") {
+		return nil
+	}
+
+	dnsZone := diff.Get("dns_zone").(string)
+
+	if zoneFile := diff.Get("zone_file").(string); zoneFile != "" {
+		records, err := zoneFileRecords(dnsZone, zoneFile)
+		if err != nil {
+			return err
+		}
+
+		if err := diff.SetNew("record", records); err != nil {
+			return err
+		}
+	}
+
+	if !diff.NewValueKnown("record") {
+		return nil
+	}
+
+	scope := newRecordsScope(dnsZone, diff.Get("names").(*schema.Set).List(), diff.Get("types").(*schema.Set).List(), false)
+	records := expandRecordsSet(dnsZone, diff.Get("record").(*schema.Set).List())
+
+	// Only the new and changed records are validated, the ones already in the zone are left alone
+	oldRawRecords, _ := diff.GetChange("record")
+	oldRecords := []*domain.Record(nil)
+
+	if !diff.HasChange("dns_zone") {
+		oldRecords = expandRecordsSet(dnsZone, oldRawRecords.(*schema.Set).List())
+
+		if !diff.Get("allow_protected_delete").(bool) {
+			if err := checkProtectedRecordsDeletion(m, dnsZone, removedRecordGroups(dnsZone, scope.filter(oldRecords), records)); err != nil {
+				return err
+			}
+		}
+	}
+
+	validated := map[string]bool{}
+	for _, record := range oldRecords {
+		validated[recordContentKey(dnsZone, record)] = true
+	}
+
+	for _, record := range records {
+		if !scope.contains(record) {
+			return fmt.Errorf("record %q of type %s is not owned by the resource, check names and types (the NS records of the zone apex are managed by Scaleway)", record.Name, record.Type)
+		}
+
+		if !validated[recordContentKey(dnsZone, record)] {
+			if err := ValidateRecord(record.Name, record.Type, FlattenDomainData(record.Data, record.Type, dnsZone).(string), dnsZone); err != nil {
+				return err
+			}
+		}
+
+		if err := checkCNAMEExclusivity(record.Name, record.Type, records); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceDomainRecordsImport restores the DNS zone, names and types encoded in the ID
+func resourceDomainRecordsImport(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	dnsZone, names, types, err := parseRecordsID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range names {
+		if name == "@" {
+			names[i] = ""
+		}
+	}
+
+	_ = d.Set("dns_zone", dnsZone)
+	_ = d.Set("names", names)
+	_ = d.Set("types", types)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceDomainRecordsCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	diags := applyDomainRecords(ctx, d, m, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := identity.SetGlobalIdentity(d, recordsIDFromState(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(diags, resourceDomainRecordsRead(ctx, d, m)...)
+}
+
+func resourceDomainRecordsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	domainAPI := NewDomainAPI(m)
+
+	dnsZone, _, _, err := parseRecordsID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dnsZones, err := domainAPI.ListDNSZones(&domain.ListDNSZonesRequest{DNSZones: []string{dnsZone}}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) || httperrors.Is403(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if len(dnsZones.DNSZones) == 0 {
+		d.SetId("")
+
+		return nil
+	}
+
+	zoneRecords, err := listZoneRecords(ctx, domainAPI, dnsZone)
+	if err != nil {
+		if httperrors.Is404(err) || httperrors.Is403(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("dns_zone", dnsZone)
+
+	if err := identity.SetGlobalIdentity(d, recordsIDFromState(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	scope := recordsScopeFromState(d)
+	previous := d.Get("record").(*schema.Set).List()
+
+	_ = d.Set("record", flattenRecordsSet(dnsZone, scope.filter(zoneRecords), previous))
+	_ = d.Set("project_id", dnsZones.DNSZones[0].ProjectID)
+
+	return nil
+}
+
+func resourceDomainRecordsUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	diags := applyDomainRecords(ctx, d, m, d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceDomainRecordsRead(ctx, d, m)...)
+}
+
+func resourceDomainRecordsDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	domainAPI := NewDomainAPI(m)
+	dnsZone := d.Get("dns_zone").(string)
+
+	zoneRecords, err := listZoneRecords(ctx, domainAPI, dnsZone)
+	if err != nil {
+		if httperrors.Is404(err) || httperrors.Is403(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	scope := recordsScopeFromState(d)
+
+	// CustomizeDiff does not run when the resource is destroyed, the protected records are checked here
+	if !d.Get("allow_protected_delete").(bool) {
+		if err := checkProtectedRecordsDeletion(m, dnsZone, scope.filter(zoneRecords)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	changes := planRecordChanges(scope, zoneRecords, nil)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	_, err = domainAPI.UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
+		DNSZone:          dnsZone,
+		Changes:          changes,
+		ReturnAllRecords: new(false),
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// applyDomainRecords turns the owned records of the zone into the configured ones with a single batch of changes
+func applyDomainRecords(ctx context.Context, d *schema.ResourceData, m any, timeout time.Duration) diag.Diagnostics {
+	domainAPI := NewDomainAPI(m)
+	dnsZone := d.Get("dns_zone").(string)
+
+	zoneRecords, err := listZoneRecords(ctx, domainAPI, dnsZone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	desired := expandRecordsSet(dnsZone, d.Get("record").(*schema.Set).List())
+	scope := recordsScopeFromState(d)
+
+	if !d.Get("allow_protected_delete").(bool) {
+		if err := checkProtectedRecordsDeletion(m, dnsZone, removedRecordGroups(dnsZone, scope.filter(zoneRecords), desired)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	changes := planRecordChanges(scope, zoneRecords, desired)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("DNS ZONE %s: applying %d record changes", dnsZone, len(changes)))
+
+	_, err = domainAPI.UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
+		DNSZone:          dnsZone,
+		Changes:          changes,
+		ReturnAllRecords: new(false),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForDNSZone(ctx, domainAPI, dnsZone, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func recordsIDFromState(d *schema.ResourceData) string {
+	return recordsID(d.Get("dns_zone").(string), d.Get("names").(*schema.Set).List(), d.Get("types").(*schema.Set).List())
+}
+
+func recordsScopeFromState(d *schema.ResourceData) recordsScope {
+	return newRecordsScope(
+		d.Get("dns_zone").(string),
+		d.Get("names").(*schema.Set).List(),
+		d.Get("types").(*schema.Set).List(),
+		d.Get("zone_file").(string) != "",
+	)
+}
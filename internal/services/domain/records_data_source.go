@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/datasource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+)
+
+func DataSourceRecords() *schema.Resource {
+	// Generate datasource schema from resource
+	dsSchema := datasource.SchemaFromResourceSchema(ResourceRecords().SchemaFunc())
+
+	datasource.AddOptionalFieldsToSchema(dsSchema, "names", "types")
+	datasource.FixDatasourceSchemaFlags(dsSchema, true, "dns_zone")
+
+	delete(dsSchema, "allow_protected_delete")
+
+	dsSchema["record"].Description = "The records of the zone, including the dynamic ones"
+	dsSchema["zone_file"].Description = "The records of the zone in the RFC 1035 zone file format, the dynamic records are written as comments"
+
+	return &schema.Resource{
+		ReadContext: DataSourceRecordsRead,
+		Schema:      dsSchema,
+	}
+}
+
+func DataSourceRecordsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	domainAPI := NewDomainAPI(m)
+	dnsZone := d.Get("dns_zone").(string)
+
+	dnsZones, err := domainAPI.ListDNSZones(&domain.ListDNSZonesRequest{DNSZones: []string{dnsZone}}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(dnsZones.DNSZones) == 0 {
+		return diag.Errorf("no zone found with the name %s", dnsZone)
+	}
+
+	zoneRecords, err := listZoneRecords(ctx, domainAPI, dnsZone)
+	if err != nil {
+		if httperrors.Is404(err) {
+			return diag.Errorf("no zone found with the name %s", dnsZone)
+		}
+
+		return diag.FromErr(err)
+	}
+
+	records := newRecordsScope(dnsZone, d.Get("names").(*schema.Set).List(), d.Get("types").(*schema.Set).List(), false).filter(zoneRecords)
+
+	d.SetId(dnsZone)
+
+	_ = d.Set("record", flattenRecordsSet(dnsZone, records, nil))
+	_ = d.Set("zone_file", exportZoneFile(dnsZone, records))
+	_ = d.Set("project_id", dnsZones.DNSZones[0].ProjectID)
+
+	return nil
+}
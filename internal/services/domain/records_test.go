@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccDomainRecords_Scoped(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	testDNSZone := "test-records." + acctest.TestDomain
+
+	config := fmt.Sprintf(`
+		resource "scaleway_domain_zone" "main" {
+			domain     = "%[1]s"
+			subdomain  = "test-records"
+			project_id = "%[2]s"
+		}
+
+		resource "scaleway_domain_records" "web" {
+			dns_zone = scaleway_domain_zone.main.id
+			names    = ["", "www"]
+			types    = ["A"]
+
+			record {
+				name = ""
+				type = "A"
+				data = "192.0.2.1"
+				ttl  = 3600
+			}
+
+			record {
+				name = "www"
+				type = "A"
+				data = "192.0.2.2"
+				ttl  = 3600
+			}
+		}
+
+		resource "scaleway_domain_records" "mail" {
+			dns_zone = scaleway_domain_zone.main.id
+			types    = ["MX", "TXT"]
+
+			record {
+				name     = ""
+				type     = "MX"
+				data     = "mail.%[3]s."
+				priority = 10
+				ttl      = 3600
+			}
+
+			record {
+				name = ""
+				type = "TXT"
+				data = "v=spf1 -all"
+				ttl  = 3600
+			}
+		}
+	`, acctest.TestDomain, testAccDomainZoneProjectID(tt), testDNSZone)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckDomainZoneDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_domain_records.web", "id", testDNSZone+"/@,www/A"),
+					resource.TestCheckResourceAttr("scaleway_domain_records.web", "record.#", "2"),
+					resource.TestCheckResourceAttr("scaleway_domain_records.mail", "id", testDNSZone+"//MX,TXT"),
+					resource.TestCheckResourceAttr("scaleway_domain_records.mail", "record.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "scaleway_domain_records.web",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allow_protected_delete"},
+			},
+			{
+				ResourceName:            "scaleway_domain_records.mail",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allow_protected_delete"},
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
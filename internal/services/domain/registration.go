@@ -115,79 +115,84 @@ func registrationSchema() map[string]*schema.Schema {
 			Default:     false,
 			Description: "Enable or disable dnssec for the domain.",
 		},
-		"ds_record": {
-			Type:     schema.TypeList,
-			Computed: true,
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"key_id": {
-						Type:        schema.TypeInt,
-						Computed:    true,
-						Description: "The identifier for the dnssec key.",
-					},
-					"algorithm": {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "The algorithm used for dnssec (e.g., rsasha256, ecdsap256sha256).",
-					},
-					"digest": {
-						Type:     schema.TypeList,
-						Computed: true,
-						Elem: &schema.Resource{
-							Schema: map[string]*schema.Schema{
-								"type": {
-									Type:        schema.TypeString,
-									Computed:    true,
-									Description: "The digest type for the DS record (e.g., sha_1, sha_256, gost_r_34_11_94, sha_384).",
-								},
-								"digest": {
-									Type:        schema.TypeString,
-									Computed:    true,
-									Description: "The digest value.",
-								},
-								"public_key": {
-									Type:     schema.TypeList,
-									Computed: true,
-									Elem: &schema.Resource{
-										Schema: map[string]*schema.Schema{
-											"key": {
-												Type:        schema.TypeString,
-												Required:    true,
-												Description: "The public key value.",
-											},
+		"ds_record":  dsRecordSchema(),
+		"project_id": account.ProjectIDSchema(),
+
+		"task_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the task that created the domain.",
+		},
+	}
+}
+
+// dsRecordSchema returns the computed DS records of a domain with DNSSEC enabled
+func dsRecordSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key_id": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The identifier for the dnssec key.",
+				},
+				"algorithm": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The algorithm used for dnssec (e.g., rsasha256, ecdsap256sha256).",
+				},
+				"digest": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "The digest type for the DS record (e.g., sha_1, sha_256, gost_r_34_11_94, sha_384).",
+							},
+							"digest": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "The digest value.",
+							},
+							"public_key": {
+								Type:     schema.TypeList,
+								Computed: true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"key": {
+											Type:        schema.TypeString,
+											Required:    true,
+											Description: "The public key value.",
 										},
 									},
-									Description: "The public key associated with the digest.",
 								},
+								Description: "The public key associated with the digest.",
 							},
 						},
-						Description: "Details about the digest.",
 					},
-					"public_key": {
-						Type:     schema.TypeList,
-						Computed: true,
-						Elem: &schema.Resource{
-							Schema: map[string]*schema.Schema{
-								"key": {
-									Type:        schema.TypeString,
-									Required:    true,
-									Description: "The public key value.",
-								},
+					Description: "Details about the digest.",
+				},
+				"public_key": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"key": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "The public key value.",
 							},
 						},
-						Description: "Public key associated with the dnssec record.",
 					},
+					Description: "Public key associated with the dnssec record.",
 				},
 			},
-			Description: "dnssec DS record configuration.",
-		},
-		"project_id": account.ProjectIDSchema(),
-
-		"task_id": {
-			Type:        schema.TypeString,
-			Computed:    true,
-			Description: "ID of the task that created the domain.",
 		},
+		Description: "dnssec DS record configuration.",
 	}
 }
 
@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
@@ -30,8 +32,11 @@ func ResourceZone() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    zoneSchema,
-		CustomizeDiff: resourceZoneCustomizeDiff,
-		Identity:      identity.DefaultGlobal(),
+		CustomizeDiff: customdiff.All(
+			resourceZoneCustomizeDiff,
+			resourceZoneDNSSECCustomizeDiff,
+		),
+		Identity: identity.DefaultGlobal(),
 	}
 }
 
@@ -87,6 +92,55 @@ func zoneSchema() map[string]*schema.Schema {
 			Description: "The date and time of the last update of the DNS zone.",
 			Computed:    true,
 		},
+		"dnssec": {
+			Type:        schema.TypeBool,
+			Description: "Sign the zone with DNSSEC, only available for the zone apex of a domain.",
+			Optional:    true,
+			Default:     false,
+		},
+		"ds_record": dsRecordSchema(),
+		"secondary": {
+			Type:        schema.TypeList,
+			Description: "Pull the records of the zone from a primary name server with a zone transfer (AXFR).",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"ns_master": {
+						Type:        schema.TypeString,
+						Description: "The IP address or hostname of the primary name server.",
+						Required:    true,
+					},
+					"tsig_key": {
+						Type:        schema.TypeList,
+						Description: "The TSIG key used to authenticate the zone transfer.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:        schema.TypeString,
+									Description: "The name of the TSIG key.",
+									Required:    true,
+								},
+								"algorithm": {
+									Type:         schema.TypeString,
+									Description:  "The algorithm of the TSIG key.",
+									Required:     true,
+									ValidateFunc: validation.StringInSlice(tsigAlgorithms, false),
+								},
+								"key": {
+									Type:        schema.TypeString,
+									Description: "The base64 encoded secret of the TSIG key.",
+									Required:    true,
+									Sensitive:   true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"project_id": account.ProjectIDSchema(),
 	}
 }
@@ -115,7 +169,7 @@ func resourceDomainZoneCreate(ctx context.Context, d *schema.ResourceData, m any
 					return diag.FromErr(err)
 				}
 
-				return resourceDomainZoneRead(ctx, d, m)
+				return resourceDomainZoneConfigure(ctx, d, m)
 			}
 
 			// Zone already exists - throw error instead of managing existing resource
@@ -142,7 +196,7 @@ func resourceDomainZoneCreate(ctx context.Context, d *schema.ResourceData, m any
 			if subdomainName == "" {
 				d.SetId(BuildZoneName(subdomainName, domainName))
 
-				return resourceDomainZoneRead(ctx, d, m)
+				return resourceDomainZoneConfigure(ctx, d, m)
 			}
 
 			// Zone was created by another process - throw error instead of managing it
@@ -157,6 +211,30 @@ func resourceDomainZoneCreate(ctx context.Context, d *schema.ResourceData, m any
 		return diag.FromErr(err)
 	}
 
+	return resourceDomainZoneConfigure(ctx, d, m)
+}
+
+// resourceDomainZoneConfigure starts the zone transfer and enables DNSSEC of a new zone, the zone apex may already exist
+func resourceDomainZoneConfigure(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	if _, ok := d.GetOk("secondary.0"); ok {
+		projectID, _, err := meta.ExtractProjectID(d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = transferZone(ctx, d, NewDomainAPI(m), projectID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.Get("dnssec").(bool) {
+		err := updateZoneDNSSEC(ctx, NewRegistrarDomainAPI(m), d.Id(), true, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDomainZoneRead(ctx, d, m)
 }
 
@@ -204,6 +282,25 @@ func resourceDomainZoneRead(ctx context.Context, d *schema.ResourceData, m any)
 	_ = d.Set("updated_at", zone.UpdatedAt.String())
 	_ = d.Set("project_id", zone.ProjectID)
 
+	// A zone no longer following its primary name server is planned for a new transfer
+	if nsMaster, ok := d.GetOk("secondary.0.ns_master"); ok && !zoneFollowsPrimary(zone, nsMaster.(string)) {
+		_ = d.Set("secondary", []any{})
+	}
+
+	// The DNSSEC status is read from the registrar API, only when it is enabled to avoid a call for every zone
+	if d.Get("dnssec").(bool) {
+		dnssec, dsRecords, err := readZoneDNSSEC(ctx, NewRegistrarDomainAPI(m), zoneName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("dnssec", dnssec)
+		_ = d.Set("ds_record", dsRecords)
+	} else {
+		_ = d.Set("dnssec", false)
+		_ = d.Set("ds_record", []any{})
+	}
+
 	return nil
 }
 
@@ -266,6 +363,27 @@ func resourceZoneUpdate(ctx context.Context, d *schema.ResourceData, m any) diag
 		}
 	}
 
+	if d.HasChange("secondary") {
+		if _, ok := d.GetOk("secondary.0"); ok {
+			projectID, _, err := meta.ExtractProjectID(d, m)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			err = transferZone(ctx, d, domainAPI, projectID, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.HasChange("dnssec") {
+		err := updateZoneDNSSEC(ctx, NewRegistrarDomainAPI(m), d.Id(), d.Get("dnssec").(bool), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDomainZoneRead(ctx, d, m)
 }
 
@@ -337,3 +455,16 @@ func resourceZoneCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m
 
 	return nil
 }
+
+// resourceZoneDNSSECCustomizeDiff rejects DNSSEC on a subdomain zone, the DS records are published in the parent zone
+func resourceZoneDNSSECCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if !diff.Get("dnssec").(bool) || !diff.NewValueKnown("subdomain") {
+		return nil
+	}
+
+	if subdomain := diff.Get("subdomain").(string); subdomain != "" {
+		return fmt.Errorf("dnssec can only be enabled on the zone apex of a domain, not on the %q subdomain zone", subdomain)
+	}
+
+	return nil
+}
@@ -14,6 +14,11 @@ func DataSourceZone() *schema.Resource {
 
 	datasource.AddOptionalFieldsToSchema(dsSchema, "domain", "subdomain", "project_id")
 
+	// The DS records are exposed by scaleway_domain_registration, the zone transfers by scaleway_domain_zone_transfer
+	delete(dsSchema, "dnssec")
+	delete(dsSchema, "ds_record")
+	delete(dsSchema, "secondary")
+
 	return &schema.Resource{
 		ReadContext: DataSourceZoneRead,
 		Schema:      dsSchema,
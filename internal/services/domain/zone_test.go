@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -87,6 +88,59 @@ func TestAccDomainZone_RootZone(t *testing.T) {
 	})
 }
 
+func TestAccDomainZone_SecondaryInvalidTSIG(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_domain_zone" "test" {
+						domain    = "%s"
+						subdomain = "test-secondary"
+
+						secondary {
+							ns_master = "192.0.2.53"
+
+							tsig_key {
+								name      = "transfer-key"
+								algorithm = "hmac-sha3"
+								key       = "c2VjcmV0"
+							}
+						}
+					}
+				`, acctest.TestDomain),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expected secondary\.0\.tsig_key\.0\.algorithm to be one of`),
+			},
+		},
+	})
+}
+
+func TestAccDomainZone_DNSSECOnSubdomain(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_domain_zone" "test" {
+						domain    = "%s"
+						subdomain = "test-dnssec"
+						dnssec    = true
+					}
+				`, acctest.TestDomain),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`dnssec can only be enabled on the zone apex of a domain`),
+			},
+		},
+	})
+}
+
 func testAccCheckDomainZoneExists(tt *acctest.TestTools, n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+func DataSourceZoneTransfer() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceZoneTransferRead,
+		SchemaFunc:  zoneTransferSchema,
+	}
+}
+
+func zoneTransferSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"dns_zone": {
+			Type:        schema.TypeString,
+			Description: "The name of the DNS zone",
+			Required:    true,
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Description: "The status of the zone, error when the last transfer failed",
+			Computed:    true,
+		},
+		"message": {
+			Type:        schema.TypeString,
+			Description: "The message of the last transfer, set when it failed",
+			Computed:    true,
+		},
+		"updated_at": {
+			Type:        schema.TypeString,
+			Description: "The date and time of the last update of the zone",
+			Computed:    true,
+		},
+		"ns_master": {
+			Type:        schema.TypeList,
+			Description: "The primary name servers of the zone",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"version_id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the last version of the zone, each transfer creates a version",
+			Computed:    true,
+		},
+		"version_created_at": {
+			Type:        schema.TypeString,
+			Description: "The date and time of the creation of the last version of the zone",
+			Computed:    true,
+		},
+		"tsig_key": {
+			Type:        schema.TypeList,
+			Description: "The TSIG key to authenticate the transfers of the zone from the Scaleway name servers",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "The name of the TSIG key",
+						Computed:    true,
+					},
+					"algorithm": {
+						Type:        schema.TypeString,
+						Description: "The algorithm of the TSIG key",
+						Computed:    true,
+					},
+					"key": {
+						Type:        schema.TypeString,
+						Description: "The base64 encoded secret of the TSIG key",
+						Computed:    true,
+						Sensitive:   true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceZoneTransferRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	domainAPI := NewDomainAPI(m)
+	dnsZone := d.Get("dns_zone").(string)
+
+	zones, err := domainAPI.ListDNSZones(&domain.ListDNSZonesRequest{
+		DNSZones: []string{dnsZone},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(zones.DNSZones) == 0 {
+		return diag.FromErr(fmt.Errorf("no zone found with the name %s", dnsZone))
+	}
+
+	zone := zones.DNSZones[0]
+
+	versions, err := domainAPI.ListDNSZoneVersions(&domain.ListDNSZoneVersionsRequest{
+		DNSZone: dnsZone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var lastVersion *domain.DNSZoneVersion
+
+	for _, version := range versions.Versions {
+		if lastVersion == nil || (version.CreatedAt != nil && lastVersion.CreatedAt != nil && version.CreatedAt.After(*lastVersion.CreatedAt)) {
+			lastVersion = version
+		}
+	}
+
+	tsigKey := []map[string]any(nil)
+
+	key, err := domainAPI.GetDNSZoneTsigKey(&domain.GetDNSZoneTsigKeyRequest{
+		DNSZone: dnsZone,
+	}, scw.WithContext(ctx))
+
+	switch {
+	case err == nil:
+		tsigKey = []map[string]any{{
+			"name":      key.Name,
+			"algorithm": key.Algorithm,
+			"key":       key.Key,
+		}}
+	case !httperrors.Is404(err):
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dnsZone)
+
+	_ = d.Set("status", zone.Status.String())
+	_ = d.Set("message", zoneMessage(zone))
+	_ = d.Set("updated_at", types.FlattenTime(zone.UpdatedAt))
+	_ = d.Set("ns_master", zone.NsMaster)
+	_ = d.Set("tsig_key", tsigKey)
+
+	if lastVersion != nil {
+		_ = d.Set("version_id", lastVersion.ID)
+		_ = d.Set("version_created_at", types.FlattenTime(lastVersion.CreatedAt))
+	}
+
+	return nil
+}
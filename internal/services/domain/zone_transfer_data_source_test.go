@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccDataSourceDomainZoneTransfer_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	testDNSZone := "test-zone-transfer"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckDomainZoneDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource scaleway_domain_zone main {
+						domain     = "%s"
+						subdomain  = "%s"
+						project_id = "%s"
+					}
+
+					data scaleway_domain_zone_transfer test {
+						dns_zone = scaleway_domain_zone.main.id
+					}
+				`, acctest.TestDomain, testDNSZone, testAccDomainZoneProjectID(tt)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.scaleway_domain_zone_transfer.test", "id", "scaleway_domain_zone.main", "id"),
+					resource.TestCheckResourceAttr("data.scaleway_domain_zone_transfer.test", "status", "active"),
+					resource.TestCheckResourceAttr("data.scaleway_domain_zone_transfer.test", "message", ""),
+					resource.TestCheckResourceAttrSet("data.scaleway_domain_zone_transfer.test", "updated_at"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,644 @@
+package iam
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+var (
+	_ resource.Resource                   = (*APIKeyRotationResource)(nil)
+	_ resource.ResourceWithConfigure      = (*APIKeyRotationResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*APIKeyRotationResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*APIKeyRotationResource)(nil)
+)
+
+func NewAPIKeyRotationResource() resource.Resource {
+	return &APIKeyRotationResource{}
+}
+
+type APIKeyRotationResource struct {
+	iamAPI    *iam.API
+	secretAPI *secret.API
+	meta      *meta.Meta
+}
+
+type apiKeyRotationResourceModel struct {
+	ApplicationID    types.String `tfsdk:"application_id"`
+	Description      types.String `tfsdk:"description"`
+	DefaultProjectID types.String `tfsdk:"default_project_id"`
+	RotationPeriod   types.String `tfsdk:"rotation_period"`
+	Overlap          types.String `tfsdk:"overlap"`
+	ExpireKeys       types.Bool   `tfsdk:"expire_keys"`
+	SecretID         types.String `tfsdk:"secret_id"`
+	// Output
+	ID             types.String `tfsdk:"id"`
+	Current        types.Object `tfsdk:"current"`
+	Previous       types.Object `tfsdk:"previous"`
+	NextRotationAt types.String `tfsdk:"next_rotation_at"`
+}
+
+// apiKeyGenerationModel is a generation of keys of the rotation, the current or the previous one.
+// Its secret key is only written to a version of the Secret Manager secret, it is not kept in the state.
+type apiKeyGenerationModel struct {
+	AccessKey      types.String `tfsdk:"access_key"`
+	SecretRevision types.Int64  `tfsdk:"secret_revision"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	ExpiresAt      types.String `tfsdk:"expires_at"`
+}
+
+func apiKeyGenerationAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"access_key":      types.StringType,
+		"secret_revision": types.Int64Type,
+		"created_at":      types.StringType,
+		"expires_at":      types.StringType,
+	}
+}
+
+// apiKeyRotationSecretData is the content of the secret versions written by the rotation
+type apiKeyRotationSecretData struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+func (r *APIKeyRotationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_api_key_rotation"
+}
+
+//go:embed descriptions/api_key_rotation_resource.md
+var apiKeyRotationResourceDescription string
+
+func apiKeyGenerationSchema(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.UseStateForUnknown(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"access_key": schema.StringAttribute{
+				MarkdownDescription: "The access key of the API key",
+				Computed:            true,
+			},
+			"secret_revision": schema.Int64Attribute{
+				MarkdownDescription: "The revision of the version of the secret holding the secret key of the API key",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time of the creation of the API key",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time of the expiration of the API key, rotation_period and overlap after its creation, empty when expire_keys is false",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *APIKeyRotationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: apiKeyRotationResourceDescription,
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the application owning the API keys",
+				Required:            true,
+				Validators: []validator.String{
+					verify.IsStringUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the API keys",
+				Optional:            true,
+			},
+			"default_project_id": schema.StringAttribute{
+				MarkdownDescription: "Default Project ID of the API keys, used with Object Storage",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					verify.IsStringUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_period": schema.StringAttribute{
+				MarkdownDescription: "The duration after which a new API key is created, e.g. `720h`",
+				Required:            true,
+			},
+			"overlap": schema.StringAttribute{
+				MarkdownDescription: "The duration during which the previous API key is kept after a rotation, at most the rotation period",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("24h"),
+			},
+			"expire_keys": schema.BoolAttribute{
+				MarkdownDescription: "Whether the API keys expire rotation_period and overlap after their creation, even if Terraform does not run. Only applies to the keys created afterwards",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"secret_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the Secret Manager secret to which a version holding the access key and the secret key is added for each new API key",
+				Required:            true,
+				Validators: []validator.String{
+					verify.IsStringUUIDOrUUIDWithLocality(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the rotation, the ID of the application",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"current":  apiKeyGenerationSchema("The current API key, to be used by the consumers"),
+			"previous": apiKeyGenerationSchema("The previous API key, kept during the overlap after a rotation"),
+			"next_rotation_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time from which the next apply rotates the API key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *APIKeyRotationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.meta = m
+	r.iamAPI = iam.NewAPI(r.meta.ScwClient())
+	r.secretAPI = secret.NewAPI(r.meta.ScwClient())
+}
+
+// secretRegionAndID returns the region and the ID of the secret receiving the secret keys
+func (r *APIKeyRotationResource) secretRegionAndID(data apiKeyRotationResourceModel) (scw.Region, string, error) {
+	secretID := regional.ExpandID(data.SecretID.ValueString())
+	if secretID.Region != "" {
+		return secretID.Region, secretID.ID, nil
+	}
+
+	defaultRegion, exists := r.meta.ScwClient().GetDefaultRegion()
+	if !exists {
+		return "", "", errors.New("secret_id has no region and no default region is configured in the provider")
+	}
+
+	return defaultRegion, secretID.ID, nil
+}
+
+func (r *APIKeyRotationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data apiKeyRotationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.RotationPeriod.IsUnknown() || data.Overlap.IsUnknown() {
+		return
+	}
+
+	overlap := "24h"
+	if !data.Overlap.IsNull() {
+		overlap = data.Overlap.ValueString()
+	}
+
+	_, _, err := parseAPIKeyRotationDurations(data.RotationPeriod.ValueString(), overlap)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rotation_period"), "Invalid API key rotation", err.Error())
+	}
+}
+
+// ModifyPlan plans the rotation of the current key once the rotation period has elapsed,
+// and the deletion of the previous key once the overlap has elapsed
+func (r *APIKeyRotationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan apiKeyRotationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.RotationPeriod.IsUnknown() || plan.Overlap.IsUnknown() {
+		return
+	}
+
+	rotationPeriod, overlap, err := parseAPIKeyRotationDurations(plan.RotationPeriod.ValueString(), plan.Overlap.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid API key rotation", err.Error())
+
+		return
+	}
+
+	current, diags := expandAPIKeyGeneration(ctx, state.Current)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || current == nil {
+		return
+	}
+
+	currentCreatedAt, err := time.Parse(time.RFC3339, current.CreatedAt.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid creation date of the current API key", err.Error())
+
+		return
+	}
+
+	rotate, deletePrevious := planAPIKeyRotation(time.Now(), currentCreatedAt, !state.Previous.IsNull(), rotationPeriod, overlap)
+
+	switch {
+	case rotate:
+		plan.Current = types.ObjectUnknown(apiKeyGenerationAttrTypes())
+		plan.Previous = types.ObjectUnknown(apiKeyGenerationAttrTypes())
+		plan.NextRotationAt = types.StringUnknown()
+	case deletePrevious:
+		plan.Previous = types.ObjectNull(apiKeyGenerationAttrTypes())
+		plan.NextRotationAt = types.StringValue(currentCreatedAt.Add(rotationPeriod).Format(time.RFC3339))
+	default:
+		plan.NextRotationAt = types.StringValue(currentCreatedAt.Add(rotationPeriod).Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// createAPIKey creates an API key and writes its secret key to a new version of the secret,
+// the key is deleted if it cannot be written as nobody could use it
+func (r *APIKeyRotationResource) createAPIKey(ctx context.Context, data apiKeyRotationResourceModel, rotationPeriod, overlap time.Duration) (*apiKeyGenerationModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	secretRegion, secretID, err := r.secretRegionAndID(data)
+	if err != nil {
+		diags.AddAttributeError(path.Root("secret_id"), "Invalid secret_id", err.Error())
+
+		return nil, diags
+	}
+
+	createReq := &iam.CreateAPIKeyRequest{
+		ApplicationID:    data.ApplicationID.ValueStringPointer(),
+		DefaultProjectID: data.DefaultProjectID.ValueStringPointer(),
+		Description:      data.Description.ValueString(),
+	}
+
+	if data.ExpireKeys.ValueBool() {
+		expiresAt := apiKeyRotationExpiresAt(time.Now(), rotationPeriod, overlap)
+		createReq.ExpiresAt = &expiresAt
+	}
+
+	res, err := r.iamAPI.CreateAPIKey(createReq, scw.WithContext(ctx))
+	if err != nil {
+		diags.AddError("Failed to create API key", err.Error())
+
+		return nil, diags
+	}
+
+	version, err := r.writeAPIKeySecret(ctx, secretRegion, secretID, res)
+	if err != nil {
+		diags.AddError("Failed to write API key "+res.AccessKey+" to secret "+secretID, err.Error())
+
+		err = r.iamAPI.DeleteAPIKey(&iam.DeleteAPIKeyRequest{
+			AccessKey: res.AccessKey,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			diags.AddError("Failed to delete API key "+res.AccessKey, err.Error())
+		}
+
+		return nil, diags
+	}
+
+	return &apiKeyGenerationModel{
+		AccessKey:      types.StringValue(res.AccessKey),
+		SecretRevision: types.Int64Value(int64(version.Revision)),
+		CreatedAt:      types.StringValue(res.CreatedAt.Format(time.RFC3339)),
+		ExpiresAt:      flattenAPIKeyRotationExpiresAt(res.ExpiresAt),
+	}, diags
+}
+
+// writeAPIKeySecret adds a version holding the access key and the secret key of an API key to the secret
+func (r *APIKeyRotationResource) writeAPIKeySecret(ctx context.Context, region scw.Region, secretID string, apiKey *iam.APIKey) (*secret.SecretVersion, error) {
+	payload, err := json.Marshal(apiKeyRotationSecretData{
+		AccessKey: apiKey.AccessKey,
+		SecretKey: *apiKey.SecretKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.secretAPI.CreateSecretVersion(&secret.CreateSecretVersionRequest{
+		Region:      region,
+		SecretID:    secretID,
+		Data:        payload,
+		Description: new("API key " + apiKey.AccessKey),
+	}, scw.WithContext(ctx))
+}
+
+// deleteAPIKey deletes an API key and disables the version of the secret holding its secret key
+func (r *APIKeyRotationResource) deleteAPIKey(ctx context.Context, data apiKeyRotationResourceModel, generation *apiKeyGenerationModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if generation == nil {
+		return diags
+	}
+
+	err := r.iamAPI.DeleteAPIKey(&iam.DeleteAPIKeyRequest{
+		AccessKey: generation.AccessKey.ValueString(),
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		diags.AddError("Failed to delete API key "+generation.AccessKey.ValueString(), err.Error())
+
+		return diags
+	}
+
+	if generation.SecretRevision.IsNull() || generation.SecretRevision.IsUnknown() {
+		return diags
+	}
+
+	secretRegion, secretID, err := r.secretRegionAndID(data)
+	if err != nil {
+		diags.AddAttributeError(path.Root("secret_id"), "Invalid secret_id", err.Error())
+
+		return diags
+	}
+
+	// A version which is already disabled or deleted is left as is
+	_, err = r.secretAPI.DisableSecretVersion(&secret.DisableSecretVersionRequest{
+		Region:   secretRegion,
+		SecretID: secretID,
+		Revision: strconv.FormatInt(generation.SecretRevision.ValueInt64(), 10),
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) && !httperrors.Is412(err) {
+		diags.AddError("Failed to disable the secret version of API key "+generation.AccessKey.ValueString(), err.Error())
+	}
+
+	return diags
+}
+
+func (r *APIKeyRotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data apiKeyRotationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotationPeriod, overlap, err := parseAPIKeyRotationDurations(data.RotationPeriod.ValueString(), data.Overlap.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid API key rotation", err.Error())
+
+		return
+	}
+
+	current, diags := r.createAPIKey(ctx, data, rotationPeriod, overlap)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ApplicationID
+	data.Previous = types.ObjectNull(apiKeyGenerationAttrTypes())
+
+	resp.Diagnostics.Append(setAPIKeyRotationCurrent(ctx, &data, current, rotationPeriod)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIKeyRotationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state apiKeyRotationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := expandAPIKeyGeneration(ctx, state.Current)
+	resp.Diagnostics.Append(diags...)
+
+	previous, diags := expandAPIKeyGeneration(ctx, state.Previous)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if current == nil {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	apiKey, err := r.iamAPI.GetAPIKey(&iam.GetAPIKeyRequest{
+		AccessKey: current.AccessKey.ValueString(),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError("Failed to read API key", err.Error())
+
+		return
+	}
+
+	current.ExpiresAt = flattenAPIKeyRotationExpiresAt(apiKey.ExpiresAt)
+	state.Description = types.StringValue(apiKey.Description)
+	state.DefaultProjectID = types.StringValue(apiKey.DefaultProjectID)
+
+	if state.Description.ValueString() == "" {
+		state.Description = types.StringNull()
+	}
+
+	state.Current, diags = types.ObjectValueFrom(ctx, apiKeyGenerationAttrTypes(), current)
+	resp.Diagnostics.Append(diags...)
+
+	if previous != nil {
+		_, err = r.iamAPI.GetAPIKey(&iam.GetAPIKeyRequest{
+			AccessKey: previous.AccessKey.ValueString(),
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			resp.Diagnostics.AddError("Failed to read API key", err.Error())
+
+			return
+		}
+
+		if httperrors.Is404(err) {
+			state.Previous = types.ObjectNull(apiKeyGenerationAttrTypes())
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *APIKeyRotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state apiKeyRotationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotationPeriod, overlap, err := parseAPIKeyRotationDurations(plan.RotationPeriod.ValueString(), plan.Overlap.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid API key rotation", err.Error())
+
+		return
+	}
+
+	current, diags := expandAPIKeyGeneration(ctx, state.Current)
+	resp.Diagnostics.Append(diags...)
+
+	previous, diags := expandAPIKeyGeneration(ctx, state.Previous)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case plan.Current.IsUnknown():
+		// The current key becomes the previous one, the key of the generation before is deleted
+		newCurrent, diags := r.createAPIKey(ctx, plan, rotationPeriod, overlap)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(r.deleteAPIKey(ctx, state, previous)...)
+
+		previous, current = current, newCurrent
+	case plan.Previous.IsNull() && previous != nil:
+		resp.Diagnostics.Append(r.deleteAPIKey(ctx, state, previous)...)
+
+		previous = nil
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Description.Equal(state.Description) || !plan.DefaultProjectID.Equal(state.DefaultProjectID) {
+		for _, generation := range []*apiKeyGenerationModel{current, previous} {
+			if generation == nil {
+				continue
+			}
+
+			_, err := r.iamAPI.UpdateAPIKey(&iam.UpdateAPIKeyRequest{
+				AccessKey:        generation.AccessKey.ValueString(),
+				Description:      new(plan.Description.ValueString()),
+				DefaultProjectID: plan.DefaultProjectID.ValueStringPointer(),
+			}, scw.WithContext(ctx))
+			if err != nil && !httperrors.Is404(err) {
+				resp.Diagnostics.AddError("Failed to update API key "+generation.AccessKey.ValueString(), err.Error())
+
+				return
+			}
+		}
+	}
+
+	plan.Previous = types.ObjectNull(apiKeyGenerationAttrTypes())
+
+	if previous != nil {
+		plan.Previous, diags = types.ObjectValueFrom(ctx, apiKeyGenerationAttrTypes(), previous)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(setAPIKeyRotationCurrent(ctx, &plan, current, rotationPeriod)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *APIKeyRotationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state apiKeyRotationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, generation := range []types.Object{state.Current, state.Previous} {
+		apiKey, diags := expandAPIKeyGeneration(ctx, generation)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(r.deleteAPIKey(ctx, state, apiKey)...)
+	}
+}
+
+func expandAPIKeyGeneration(ctx context.Context, object types.Object) (*apiKeyGenerationModel, diag.Diagnostics) {
+	if object.IsNull() || object.IsUnknown() {
+		return nil, nil
+	}
+
+	generation := &apiKeyGenerationModel{}
+	diags := object.As(ctx, generation, basetypes.ObjectAsOptions{})
+
+	return generation, diags
+}
+
+// flattenAPIKeyRotationExpiresAt returns the expiration date of a key, null when the key does not expire
+func flattenAPIKeyRotationExpiresAt(expiresAt *time.Time) types.String {
+	if expiresAt == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(expiresAt.Format(time.RFC3339))
+}
+
+// setAPIKeyRotationCurrent sets the current key and the date of the next rotation
+func setAPIKeyRotationCurrent(ctx context.Context, data *apiKeyRotationResourceModel, current *apiKeyGenerationModel, rotationPeriod time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	createdAt, err := time.Parse(time.RFC3339, current.CreatedAt.ValueString())
+	if err != nil {
+		diags.AddError("Invalid creation date of the current API key", err.Error())
+
+		return diags
+	}
+
+	data.Current, diags = types.ObjectValueFrom(ctx, apiKeyGenerationAttrTypes(), current)
+	data.NextRotationAt = types.StringValue(createdAt.Add(rotationPeriod).Format(time.RFC3339))
+
+	return diags
+}
@@ -0,0 +1,202 @@
+package iam_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	iamSDK "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/iam"
+	secrettestfuncs "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/secret/testfuncs"
+)
+
+func TestAccAPIKeyRotation_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	config := func(description string, expireKeys bool) string {
+		return fmt.Sprintf(`
+			resource "scaleway_iam_application" "main" {
+			  name = "tf_test_api_key_rotation"
+			}
+
+			resource "scaleway_secret" "main" {
+			  name = "tf_test_api_key_rotation"
+			}
+
+			resource "scaleway_iam_api_key_rotation" "main" {
+			  application_id  = scaleway_iam_application.main.id
+			  secret_id       = scaleway_secret.main.id
+			  description     = %q
+			  rotation_period = "720h"
+			  overlap         = "48h"
+			  expire_keys     = %t
+			}
+
+			data "scaleway_secret_version" "current" {
+			  secret_id = scaleway_secret.main.id
+			  revision  = scaleway_iam_api_key_rotation.main.current.secret_revision
+			}
+		`, description, expireKeys)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckIamApplicationDestroy(tt),
+			testAccCheckIamAPIKeyRotationDestroy(tt),
+			secrettestfuncs.CheckSecretDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config("tf_test_api_key_rotation", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_iam_api_key_rotation.main", "id", "scaleway_iam_application.main", "id"),
+					resource.TestCheckResourceAttrSet("scaleway_iam_api_key_rotation.main", "current.access_key"),
+					resource.TestCheckResourceAttr("scaleway_iam_api_key_rotation.main", "current.secret_revision", "1"),
+					resource.TestCheckNoResourceAttr("scaleway_iam_api_key_rotation.main", "current.secret_key"),
+					resource.TestCheckResourceAttrSet("scaleway_iam_api_key_rotation.main", "current.expires_at"),
+					resource.TestCheckNoResourceAttr("scaleway_iam_api_key_rotation.main", "previous.access_key"),
+					resource.TestCheckResourceAttrSet("scaleway_iam_api_key_rotation.main", "next_rotation_at"),
+					testAccCheckAPIKeyRotationSecret(tt, "scaleway_iam_api_key_rotation.main", "data.scaleway_secret_version.current"),
+				),
+			},
+			{
+				// Only the keys created afterwards are affected by expire_keys, the current key is kept
+				Config: config("tf_test_api_key_rotation_updated", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_iam_api_key_rotation.main", "description", "tf_test_api_key_rotation_updated"),
+					resource.TestCheckResourceAttr("scaleway_iam_api_key_rotation.main", "expire_keys", "false"),
+					resource.TestCheckResourceAttr("scaleway_iam_api_key_rotation.main", "current.secret_revision", "1"),
+					resource.TestCheckNoResourceAttr("scaleway_iam_api_key_rotation.main", "previous.access_key"),
+				),
+			},
+			{
+				Config:   config("tf_test_api_key_rotation_updated", false),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAPIKeyRotation_WithoutExpiration(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckIamApplicationDestroy(tt),
+			testAccCheckIamAPIKeyRotationDestroy(tt),
+			secrettestfuncs.CheckSecretDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_iam_application" "main" {
+					  name = "tf_test_api_key_rotation_no_expiration"
+					}
+
+					resource "scaleway_secret" "main" {
+					  name = "tf_test_api_key_rotation_no_expiration"
+					}
+
+					resource "scaleway_iam_api_key_rotation" "main" {
+					  application_id  = scaleway_iam_application.main.id
+					  secret_id       = scaleway_secret.main.id
+					  description     = "tf_test_api_key_rotation_no_expiration"
+					  rotation_period = "720h"
+					  expire_keys     = false
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("scaleway_iam_api_key_rotation.main", "current.access_key"),
+					resource.TestCheckNoResourceAttr("scaleway_iam_api_key_rotation.main", "current.expires_at"),
+					resource.TestCheckResourceAttr("scaleway_iam_api_key_rotation.main", "overlap", "24h"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAPIKeyRotationSecret checks that the secret version holds the access key of the current key and its secret key
+func testAccCheckAPIKeyRotationSecret(tt *acctest.TestTools, rotation string, secretVersion string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rotationState, ok := s.RootModule().Resources[rotation]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rotation)
+		}
+
+		versionState, ok := s.RootModule().Resources[secretVersion]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", secretVersion)
+		}
+
+		rawData, err := base64.StdEncoding.DecodeString(versionState.Primary.Attributes["data"])
+		if err != nil {
+			return err
+		}
+
+		data := struct {
+			AccessKey string `json:"access_key"`
+			SecretKey string `json:"secret_key"`
+		}{}
+
+		err = json.Unmarshal(rawData, &data)
+		if err != nil {
+			return err
+		}
+
+		if data.AccessKey != rotationState.Primary.Attributes["current.access_key"] {
+			return fmt.Errorf("secret holds access key %q, expected %q", data.AccessKey, rotationState.Primary.Attributes["current.access_key"])
+		}
+
+		if data.SecretKey == "" {
+			return errors.New("secret holds no secret key")
+		}
+
+		_, err = iam.NewAPI(tt.Meta).GetAPIKey(&iamSDK.GetAPIKeyRequest{
+			AccessKey: data.AccessKey,
+		})
+
+		return err
+	}
+}
+
+func testAccCheckIamAPIKeyRotationDestroy(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		api := iam.NewAPI(tt.Meta)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "scaleway_iam_api_key_rotation" {
+				continue
+			}
+
+			for _, attribute := range []string{"current.access_key", "previous.access_key"} {
+				accessKey := rs.Primary.Attributes[attribute]
+				if accessKey == "" {
+					continue
+				}
+
+				_, err := api.GetAPIKey(&iamSDK.GetAPIKeyRequest{
+					AccessKey: accessKey,
+				})
+				if err == nil {
+					return fmt.Errorf("API key %s of rotation %s still exists", accessKey, rs.Primary.ID)
+				}
+
+				if !httperrors.Is404(err) {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,293 @@
+package iam
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/effective_permissions_data_source.md
+var effectivePermissionsDataSourceDescription string
+
+func DataSourceEffectivePermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceIamEffectivePermissionsRead,
+		Description: effectivePermissionsDataSourceDescription,
+		SchemaFunc:  effectivePermissionsSchema,
+	}
+}
+
+func effectivePermissionsSchema() map[string]*schema.Schema {
+	principals := []string{"user_id", "group_id", "application_id", "access_key"}
+
+	return map[string]*schema.Schema{
+		"user_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			Description:      "The ID of the user whose permissions are evaluated",
+			ValidateDiagFunc: verify.IsUUID(),
+			ExactlyOneOf:     principals,
+		},
+		"group_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "The ID of the group whose permissions are evaluated",
+			ValidateDiagFunc: verify.IsUUID(),
+			ExactlyOneOf:     principals,
+		},
+		"application_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			Description:      "The ID of the application whose permissions are evaluated",
+			ValidateDiagFunc: verify.IsUUID(),
+			ExactlyOneOf:     principals,
+		},
+		"access_key": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The access key of the API key whose permissions are evaluated, they are the permissions of its bearer",
+			ExactlyOneOf: principals,
+		},
+		"organization_id": account.OrganizationIDOptionalSchema(),
+		"assert": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Permission sets which must be granted to the principal, the read fails when one of them is missing",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"permission_set_names": {
+						Type:        schema.TypeSet,
+						Required:    true,
+						Description: "Names of the permission sets which must be granted",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"project_id": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						Description:      "The project in which the permission sets must be granted, the organization when unset",
+						ValidateDiagFunc: verify.IsUUID(),
+					},
+				},
+			},
+		},
+		// Computed elements
+		"group_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IDs of the groups of the principal",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"policy_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IDs of the policies attached to the principal or to its groups",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"organization_permission_set_names": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The permission sets granted without condition at the organization level, they apply to every project",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"organization_conditional_permission_set_names": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The permission sets granted at the organization level only under a condition",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"project": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The permission sets granted per project",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"project_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the project",
+					},
+					"permission_set_names": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The permission sets granted without condition in the project",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"conditional_permission_set_names": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The permission sets granted in the project only under a condition",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceIamEffectivePermissionsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api := NewAPI(m)
+
+	orgID := account.GetOrganizationID(m, d)
+	if orgID == nil {
+		return diag.FromErr(errors.New("organization_id is required when no default organization is configured"))
+	}
+
+	userID := d.Get("user_id").(string)
+	applicationID := d.Get("application_id").(string)
+	groupID := d.Get("group_id").(string)
+	principalID := userID + applicationID + groupID
+
+	if accessKey := d.Get("access_key").(string); accessKey != "" {
+		apiKey, err := api.GetAPIKey(&iam.GetAPIKeyRequest{
+			AccessKey: accessKey,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		userID = types.FlattenStringPtr(apiKey.UserID).(string)
+		applicationID = types.FlattenStringPtr(apiKey.ApplicationID).(string)
+		principalID = accessKey
+	}
+
+	groupIDs := []string(nil)
+
+	if groupID != "" {
+		groupIDs = []string{groupID}
+	} else {
+		groupsReq := &iam.ListGroupsRequest{
+			OrganizationID: *orgID,
+		}
+
+		if userID != "" {
+			groupsReq.UserIDs = []string{userID}
+		} else {
+			groupsReq.ApplicationIDs = []string{applicationID}
+		}
+
+		groups, err := api.ListGroups(groupsReq, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to list the groups of the principal: %w", err))
+		}
+
+		for _, group := range groups.Groups {
+			groupIDs = append(groupIDs, group.ID)
+		}
+	}
+
+	policyRequests := []*iam.ListPoliciesRequest(nil)
+
+	switch {
+	case userID != "":
+		policyRequests = append(policyRequests, &iam.ListPoliciesRequest{OrganizationID: *orgID, UserIDs: []string{userID}})
+	case applicationID != "":
+		policyRequests = append(policyRequests, &iam.ListPoliciesRequest{OrganizationID: *orgID, ApplicationIDs: []string{applicationID}})
+	}
+
+	if len(groupIDs) > 0 {
+		policyRequests = append(policyRequests, &iam.ListPoliciesRequest{OrganizationID: *orgID, GroupIDs: groupIDs})
+	}
+
+	policyIDs := []string(nil)
+	permissions := newEffectivePermissions()
+
+	for _, req := range policyRequests {
+		policies, err := api.ListPolicies(req, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to list the policies of the principal: %w", err))
+		}
+
+		for _, policy := range policies.Policies {
+			if slices.Contains(policyIDs, policy.ID) {
+				continue
+			}
+
+			policyIDs = append(policyIDs, policy.ID)
+
+			rules, err := api.ListRules(&iam.ListRulesRequest{
+				PolicyID: policy.ID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to list the rules of policy %s: %w", policy.ID, err))
+			}
+
+			for _, rule := range rules.Rules {
+				permissions.addRule(rule)
+			}
+		}
+	}
+
+	if missing := permissions.missingPermissions(expandPermissionAssertions(d.Get("assert"))); len(missing) > 0 {
+		return diag.Errorf("principal %s is missing required permission sets: %s", principalID, strings.Join(missing, ", "))
+	}
+
+	d.SetId(principalID)
+
+	_ = d.Set("user_id", userID)
+	_ = d.Set("application_id", applicationID)
+	_ = d.Set("organization_id", *orgID)
+	_ = d.Set("group_ids", groupIDs)
+	_ = d.Set("policy_ids", policyIDs)
+	_ = d.Set("organization_permission_set_names", permissions.organization.names(false))
+	_ = d.Set("organization_conditional_permission_set_names", permissions.organization.names(true))
+	_ = d.Set("project", flattenEffectiveProjectPermissions(permissions))
+
+	return nil
+}
+
+func expandPermissionAssertions(raw any) []permissionAssertion {
+	rawAssertions := raw.([]any)
+	assertions := make([]permissionAssertion, 0, len(rawAssertions))
+
+	for _, rawAssertion := range rawAssertions {
+		assertion := rawAssertion.(map[string]any)
+
+		assertions = append(assertions, permissionAssertion{
+			ProjectID:          assertion["project_id"].(string),
+			PermissionSetNames: *expandPermissionSetNames(assertion["permission_set_names"]),
+		})
+	}
+
+	return assertions
+}
+
+func flattenEffectiveProjectPermissions(permissions *effectivePermissions) []map[string]any {
+	projects := make([]map[string]any, 0, len(permissions.projects))
+
+	for _, projectID := range permissions.projectIDs() {
+		projects = append(projects, map[string]any{
+			"project_id":                       projectID,
+			"permission_set_names":             permissions.projects[projectID].names(false),
+			"conditional_permission_set_names": permissions.projects[projectID].names(true),
+		})
+	}
+
+	return projects
+}
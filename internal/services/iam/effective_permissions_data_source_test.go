@@ -0,0 +1,119 @@
+package iam_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDataSourceEffectivePermissions_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	ctx := t.Context()
+	project, iamAPIKey, _, terminateFakeSideProject, err := acctest.CreateFakeIAMManager(tt)
+	require.NoError(t, err)
+
+	config := fmt.Sprintf(`
+		resource "scaleway_iam_application" "main" {
+		  name     = "tf_tests_effective_permissions"
+		  provider = side
+		}
+
+		resource "scaleway_iam_group" "main" {
+		  name            = "tf_tests_effective_permissions"
+		  application_ids = [scaleway_iam_application.main.id]
+		  provider        = side
+		}
+
+		resource "scaleway_iam_policy" "application" {
+		  name           = "tf_tests_effective_permissions_application"
+		  application_id = scaleway_iam_application.main.id
+		  rule {
+		    organization_id      = "%[1]s"
+		    permission_set_names = ["ContainerRegistryReadOnly"]
+		  }
+		  provider = side
+		}
+
+		resource "scaleway_iam_policy" "group" {
+		  name     = "tf_tests_effective_permissions_group"
+		  group_id = scaleway_iam_group.main.id
+		  rule {
+		    project_ids          = ["%[2]s"]
+		    permission_set_names = ["ObjectStorageReadOnly"]
+		  }
+		  provider = side
+		}
+	`, project.OrganizationID, project.ID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.FakeSideProjectProviders(ctx, tt, project, iamAPIKey),
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			func(_ *terraform.State) error {
+				return terminateFakeSideProject()
+			},
+			testAccCheckIamPolicyDestroy(tt),
+			testAccCheckIamGroupDestroy(tt),
+			testAccCheckIamApplicationDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				Config: config + fmt.Sprintf(`
+					data "scaleway_iam_effective_permissions" "main" {
+					  application_id  = scaleway_iam_application.main.id
+					  organization_id = "%[1]s"
+					  depends_on      = [scaleway_iam_policy.application, scaleway_iam_policy.group]
+					  provider        = side
+
+					  assert {
+					    permission_set_names = ["ContainerRegistryReadOnly"]
+					  }
+
+					  assert {
+					    project_id           = "%[2]s"
+					    permission_set_names = ["ContainerRegistryReadOnly", "ObjectStorageReadOnly"]
+					  }
+					}
+				`, project.OrganizationID, project.ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.scaleway_iam_effective_permissions.main", "id", "scaleway_iam_application.main", "id"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "user_id", ""),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "group_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.scaleway_iam_effective_permissions.main", "group_ids.0", "scaleway_iam_group.main", "id"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "policy_ids.#", "2"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "organization_permission_set_names.#", "1"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "organization_permission_set_names.0", "ContainerRegistryReadOnly"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "organization_conditional_permission_set_names.#", "0"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "project.#", "1"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "project.0.project_id", project.ID),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "project.0.permission_set_names.#", "1"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_effective_permissions.main", "project.0.permission_set_names.0", "ObjectStorageReadOnly"),
+				),
+			},
+			{
+				Config: config + fmt.Sprintf(`
+					data "scaleway_iam_effective_permissions" "main" {
+					  group_id        = scaleway_iam_group.main.id
+					  organization_id = "%[1]s"
+					  depends_on      = [scaleway_iam_policy.application, scaleway_iam_policy.group]
+					  provider        = side
+
+					  assert {
+					    permission_set_names = ["ObjectStorageReadOnly"]
+					  }
+					}
+				`, project.OrganizationID),
+				ExpectError: regexp.MustCompile(`is missing required permission sets: ObjectStorageReadOnly in the organization`),
+			},
+		},
+	})
+}
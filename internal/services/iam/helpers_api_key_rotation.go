@@ -0,0 +1,46 @@
+package iam
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// parseAPIKeyRotationDurations parses the rotation period and the overlap of an API key rotation,
+// the overlap cannot be longer than the rotation period as only two generations of keys are kept
+func parseAPIKeyRotationDurations(rawRotationPeriod, rawOverlap string) (time.Duration, time.Duration, error) {
+	rotationPeriod, err := time.ParseDuration(rawRotationPeriod)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rotation_period: %w", err)
+	}
+
+	overlap, err := time.ParseDuration(rawOverlap)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid overlap: %w", err)
+	}
+
+	if rotationPeriod <= 0 {
+		return 0, 0, errors.New("rotation_period must be positive")
+	}
+
+	if overlap < 0 || overlap > rotationPeriod {
+		return 0, 0, fmt.Errorf("overlap must be between 0 and the rotation period (%s)", rotationPeriod)
+	}
+
+	return rotationPeriod, overlap, nil
+}
+
+// planAPIKeyRotation decides whether the current key must be rotated, or else whether the previous key must be deleted.
+// The current key was created when the previous one was rotated, so the overlap starts at its creation.
+func planAPIKeyRotation(now time.Time, currentCreatedAt time.Time, hasPrevious bool, rotationPeriod, overlap time.Duration) (rotate bool, deletePrevious bool) {
+	if !now.Before(currentCreatedAt.Add(rotationPeriod)) {
+		return true, false
+	}
+
+	return false, hasPrevious && !now.Before(currentCreatedAt.Add(overlap))
+}
+
+// apiKeyRotationExpiresAt returns the expiration date of a key, after which the API refuses it even if Terraform did not run
+func apiKeyRotationExpiresAt(createdAt time.Time, rotationPeriod, overlap time.Duration) time.Time {
+	return createdAt.Add(rotationPeriod + overlap)
+}
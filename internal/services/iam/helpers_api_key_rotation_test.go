@@ -0,0 +1,57 @@
+//nolint:testpackage // Tests need access to unexported API key rotation helpers.
+package iam
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAPIKeyRotationDurations(t *testing.T) {
+	t.Parallel()
+
+	rotationPeriod, overlap, err := parseAPIKeyRotationDurations("720h", "24h")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rotationPeriod != 720*time.Hour || overlap != 24*time.Hour {
+		t.Fatalf("got %s and %s, want 720h and 24h", rotationPeriod, overlap)
+	}
+
+	for _, tt := range [][2]string{{"30d", "24h"}, {"720h", "1 day"}, {"0s", "0s"}, {"24h", "48h"}, {"24h", "-1h"}} {
+		if _, _, err := parseAPIKeyRotationDurations(tt[0], tt[1]); err == nil {
+			t.Errorf("rotation_period %q and overlap %q: expected an error", tt[0], tt[1])
+		}
+	}
+}
+
+func TestPlanAPIKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		now                time.Time
+		hasPrevious        bool
+		wantRotate         bool
+		wantDeletePrevious bool
+	}{
+		{name: "during overlap", now: createdAt.Add(12 * time.Hour), hasPrevious: true},
+		{name: "after overlap", now: createdAt.Add(36 * time.Hour), hasPrevious: true, wantDeletePrevious: true},
+		{name: "after overlap without previous key", now: createdAt.Add(36 * time.Hour)},
+		{name: "rotation due", now: createdAt.Add(7 * 24 * time.Hour), hasPrevious: true, wantRotate: true},
+		{name: "rotation late", now: createdAt.Add(30 * 24 * time.Hour), wantRotate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rotate, deletePrevious := planAPIKeyRotation(tt.now, createdAt, tt.hasPrevious, 7*24*time.Hour, 24*time.Hour)
+			if rotate != tt.wantRotate || deletePrevious != tt.wantDeletePrevious {
+				t.Fatalf("got rotate %t and delete previous %t, want %t and %t", rotate, deletePrevious, tt.wantRotate, tt.wantDeletePrevious)
+			}
+		})
+	}
+}
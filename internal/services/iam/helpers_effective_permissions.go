@@ -0,0 +1,126 @@
+package iam
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+)
+
+// permissionSetGrants maps the name of the granted permission sets to whether a rule grants them without condition
+type permissionSetGrants map[string]bool
+
+func (g permissionSetGrants) add(names []string, conditional bool) {
+	for _, name := range names {
+		g[name] = g[name] || !conditional
+	}
+}
+
+// names returns the sorted names of the permission sets granted without condition, or only under a condition
+func (g permissionSetGrants) names(conditional bool) []string {
+	names := []string{}
+
+	for name, unconditional := range g {
+		if unconditional != conditional {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// effectivePermissions aggregates the permission sets granted to a principal by the rules of its policies
+type effectivePermissions struct {
+	organization permissionSetGrants
+	projects     map[string]permissionSetGrants
+}
+
+func newEffectivePermissions() *effectivePermissions {
+	return &effectivePermissions{
+		organization: permissionSetGrants{},
+		projects:     map[string]permissionSetGrants{},
+	}
+}
+
+// addRule grants the permission sets of a policy rule, the rules scoped to the account root user are ignored
+func (p *effectivePermissions) addRule(rule *iam.Rule) {
+	if rule.PermissionSetNames == nil {
+		return
+	}
+
+	conditional := rule.Condition != ""
+
+	switch {
+	case rule.OrganizationID != nil:
+		p.organization.add(*rule.PermissionSetNames, conditional)
+	case rule.ProjectIDs != nil:
+		for _, projectID := range *rule.ProjectIDs {
+			if p.projects[projectID] == nil {
+				p.projects[projectID] = permissionSetGrants{}
+			}
+
+			p.projects[projectID].add(*rule.PermissionSetNames, conditional)
+		}
+	}
+}
+
+// granted reports whether the permission set is granted in the project, or at the organization level when projectID is empty.
+// A permission set granted at the organization level applies to every project.
+func (p *effectivePermissions) granted(projectID string, name string) (granted bool, conditional bool) {
+	unconditional, ok := p.organization[name]
+	if ok && unconditional {
+		return true, false
+	}
+
+	conditional = ok
+
+	if projectID != "" {
+		unconditional, ok = p.projects[projectID][name]
+		if ok && unconditional {
+			return true, false
+		}
+
+		conditional = conditional || ok
+	}
+
+	return false, conditional
+}
+
+func (p *effectivePermissions) projectIDs() []string {
+	return slices.Sorted(maps.Keys(p.projects))
+}
+
+type permissionAssertion struct {
+	ProjectID          string
+	PermissionSetNames []string
+}
+
+// missingPermissions returns a description of the asserted permission sets which are not granted
+func (p *effectivePermissions) missingPermissions(assertions []permissionAssertion) []string {
+	missing := []string(nil)
+
+	for _, assertion := range assertions {
+		scope := "the organization"
+		if assertion.ProjectID != "" {
+			scope = "project " + assertion.ProjectID
+		}
+
+		for _, name := range assertion.PermissionSetNames {
+			granted, conditional := p.granted(assertion.ProjectID, name)
+
+			switch {
+			case granted:
+				continue
+			case conditional:
+				missing = append(missing, fmt.Sprintf("%s in %s (only granted under a condition)", name, scope))
+			default:
+				missing = append(missing, fmt.Sprintf("%s in %s", name, scope))
+			}
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,84 @@
+//nolint:testpackage // Tests need access to unexported effective permissions helpers.
+package iam
+
+import (
+	"slices"
+	"testing"
+
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+)
+
+const (
+	testProjectA = "11111111-1111-1111-1111-111111111111"
+	testProjectB = "22222222-2222-2222-2222-222222222222"
+)
+
+func testEffectivePermissions() *effectivePermissions {
+	permissions := newEffectivePermissions()
+
+	for _, rule := range []*iam.Rule{
+		{OrganizationID: new("org"), PermissionSetNames: &[]string{"ProjectReadOnly"}},
+		{ProjectIDs: &[]string{testProjectA, testProjectB}, PermissionSetNames: &[]string{"InstancesFullAccess"}},
+		{ProjectIDs: &[]string{testProjectA}, PermissionSetNames: &[]string{"ObjectStorageFullAccess"}, Condition: `request.ip in ["10.0.0.0/8"]`},
+		{ProjectIDs: &[]string{testProjectB}, PermissionSetNames: &[]string{"ObjectStorageFullAccess"}, Condition: `request.ip in ["10.0.0.0/8"]`},
+		{ProjectIDs: &[]string{testProjectB}, PermissionSetNames: &[]string{"ObjectStorageFullAccess"}},
+		{AccountRootUserID: new("root"), PermissionSetNames: &[]string{"AllProductsFullAccess"}},
+	} {
+		permissions.addRule(rule)
+	}
+
+	return permissions
+}
+
+func TestEffectivePermissionsGranted(t *testing.T) {
+	t.Parallel()
+
+	permissions := testEffectivePermissions()
+
+	tests := []struct {
+		projectID       string
+		name            string
+		wantGranted     bool
+		wantConditional bool
+	}{
+		{projectID: "", name: "ProjectReadOnly", wantGranted: true},
+		{projectID: testProjectA, name: "ProjectReadOnly", wantGranted: true},
+		{projectID: testProjectA, name: "InstancesFullAccess", wantGranted: true},
+		{projectID: "", name: "InstancesFullAccess"},
+		{projectID: testProjectA, name: "ObjectStorageFullAccess", wantConditional: true},
+		{projectID: testProjectB, name: "ObjectStorageFullAccess", wantGranted: true},
+		{projectID: testProjectA, name: "AllProductsFullAccess"},
+	}
+
+	for _, tt := range tests {
+		granted, conditional := permissions.granted(tt.projectID, tt.name)
+		if granted != tt.wantGranted || conditional != tt.wantConditional {
+			t.Errorf("granted(%q, %q) = %t, %t, want %t, %t", tt.projectID, tt.name, granted, conditional, tt.wantGranted, tt.wantConditional)
+		}
+	}
+
+	if got, want := permissions.projects[testProjectA].names(true), []string{"ObjectStorageFullAccess"}; !slices.Equal(got, want) {
+		t.Errorf("conditional permission sets of project A = %v, want %v", got, want)
+	}
+
+	if got, want := permissions.projects[testProjectB].names(false), []string{"InstancesFullAccess", "ObjectStorageFullAccess"}; !slices.Equal(got, want) {
+		t.Errorf("permission sets of project B = %v, want %v", got, want)
+	}
+}
+
+func TestEffectivePermissionsMissing(t *testing.T) {
+	t.Parallel()
+
+	missing := testEffectivePermissions().missingPermissions([]permissionAssertion{
+		{PermissionSetNames: []string{"ProjectReadOnly", "IAMManager"}},
+		{ProjectID: testProjectA, PermissionSetNames: []string{"InstancesFullAccess", "ObjectStorageFullAccess"}},
+	})
+
+	want := []string{
+		"IAMManager in the organization",
+		"ObjectStorageFullAccess in project " + testProjectA + " (only granted under a condition)",
+	}
+	if !slices.Equal(missing, want) {
+		t.Fatalf("missing permissions = %q, want %q", missing, want)
+	}
+}
@@ -0,0 +1,115 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-cty/cty"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// permissionSetCatalogue caches the permission sets of each organization, they only change with Scaleway releases
+var permissionSetCatalogue = struct {
+	sync.Mutex
+	permissionSets map[string][]*iam.PermissionSet
+}{
+	permissionSets: map[string][]*iam.PermissionSet{},
+}
+
+// listPermissionSets returns the permission sets of an organization, fetching them once per provider run
+func listPermissionSets(ctx context.Context, api *iam.API, organizationID string) ([]*iam.PermissionSet, error) {
+	permissionSetCatalogue.Lock()
+	defer permissionSetCatalogue.Unlock()
+
+	if permissionSets, ok := permissionSetCatalogue.permissionSets[organizationID]; ok {
+		return permissionSets, nil
+	}
+
+	res, err := api.ListPermissionSets(&iam.ListPermissionSetsRequest{
+		OrganizationID: organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	permissionSetCatalogue.permissionSets[organizationID] = res.PermissionSets
+
+	return res.PermissionSets, nil
+}
+
+// policyRulePermissions is the part of a policy rule validated against the permission set catalogue
+type policyRulePermissions struct {
+	PermissionSetNames []string
+	HasProjectIDs      bool
+}
+
+// expandPolicyRulePermissions reads the rules from the raw configuration, the permission set names which are not known yet are skipped
+func expandPolicyRulePermissions(rawRules cty.Value) []policyRulePermissions {
+	if !rawRules.IsKnown() || rawRules.IsNull() {
+		return nil
+	}
+
+	rules := []policyRulePermissions(nil)
+
+	for it := rawRules.ElementIterator(); it.Next(); {
+		_, rawRule := it.Element()
+		if !rawRule.IsKnown() || rawRule.IsNull() {
+			continue
+		}
+
+		rule := policyRulePermissions{}
+
+		if projectIDs := rawRule.GetAttr("project_ids"); !projectIDs.IsKnown() || (!projectIDs.IsNull() && projectIDs.LengthInt() > 0) {
+			rule.HasProjectIDs = true
+		}
+
+		if names := rawRule.GetAttr("permission_set_names"); names.IsKnown() && !names.IsNull() {
+			for nameIt := names.ElementIterator(); nameIt.Next(); {
+				_, name := nameIt.Element()
+				if name.IsKnown() && !name.IsNull() {
+					rule.PermissionSetNames = append(rule.PermissionSetNames, name.AsString())
+				}
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// validatePolicyRulePermissionSets checks the permission set names of policy rules against the catalogue.
+// Permission sets which are not scoped to projects, e.g. IAMManager, cannot be used in a rule with project_ids.
+func validatePolicyRulePermissionSets(rules []policyRulePermissions, permissionSets []*iam.PermissionSet) []error {
+	byName := make(map[string]*iam.PermissionSet, len(permissionSets))
+	names := make([]string, 0, len(permissionSets))
+
+	for _, permissionSet := range permissionSets {
+		byName[permissionSet.Name] = permissionSet
+		names = append(names, permissionSet.Name)
+	}
+
+	errs := []error(nil)
+
+	for i, rule := range rules {
+		for _, name := range rule.PermissionSetNames {
+			permissionSet, ok := byName[name]
+
+			switch {
+			case !ok:
+				if best := verify.ClosestMatch(name, names); best != "" {
+					errs = append(errs, fmt.Errorf("rule %d: unknown permission set %q, did you mean %q?", i, name, best))
+				} else {
+					errs = append(errs, fmt.Errorf("rule %d: unknown permission set %q, see the scaleway_iam_permission_sets data source for the available permission sets", i, name))
+				}
+			case rule.HasProjectIDs && permissionSet.ScopeType != iam.PermissionSetScopeTypeProjects:
+				errs = append(errs, fmt.Errorf("rule %d: permission set %q has the %s scope and cannot be used with project_ids, set organization_id instead", i, name, permissionSet.ScopeType))
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,73 @@
+//nolint:testpackage // Tests need access to unexported permission set helpers.
+package iam
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+)
+
+func TestExpandPolicyRulePermissions(t *testing.T) {
+	t.Parallel()
+
+	rawRules := cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"project_ids":          cty.ListVal([]cty.Value{cty.UnknownVal(cty.String)}),
+			"permission_set_names": cty.SetVal([]cty.Value{cty.StringVal("InstancesFullAccess"), cty.UnknownVal(cty.String)}),
+		}),
+		cty.ObjectVal(map[string]cty.Value{
+			"project_ids":          cty.NullVal(cty.List(cty.String)),
+			"permission_set_names": cty.SetVal([]cty.Value{cty.StringVal("IAMManager")}),
+		}),
+	})
+
+	rules := expandPolicyRulePermissions(rawRules)
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(rules))
+	}
+
+	if !rules[0].HasProjectIDs || strings.Join(rules[0].PermissionSetNames, ",") != "InstancesFullAccess" {
+		t.Errorf("unexpected first rule %+v", rules[0])
+	}
+
+	if rules[1].HasProjectIDs || strings.Join(rules[1].PermissionSetNames, ",") != "IAMManager" {
+		t.Errorf("unexpected second rule %+v", rules[1])
+	}
+
+	if rules := expandPolicyRulePermissions(cty.UnknownVal(rawRules.Type())); rules != nil {
+		t.Errorf("unknown rules: got %+v, want none", rules)
+	}
+}
+
+func TestValidatePolicyRulePermissionSets(t *testing.T) {
+	t.Parallel()
+
+	permissionSets := []*iam.PermissionSet{
+		{Name: "InstancesFullAccess", ScopeType: iam.PermissionSetScopeTypeProjects},
+		{Name: "ObjectStorageFullAccess", ScopeType: iam.PermissionSetScopeTypeProjects},
+		{Name: "IAMManager", ScopeType: iam.PermissionSetScopeTypeOrganization},
+	}
+
+	errs := validatePolicyRulePermissionSets([]policyRulePermissions{
+		{PermissionSetNames: []string{"InstancesFullAccess", "IAMManager"}},
+		{PermissionSetNames: []string{"InstanceFullAcess", "IAMManager", "DoesNotExist"}, HasProjectIDs: true},
+	}, permissionSets)
+
+	want := []string{
+		`rule 1: unknown permission set "InstanceFullAcess", did you mean "InstancesFullAccess"?`,
+		`rule 1: permission set "IAMManager" has the organization scope and cannot be used with project_ids, set organization_id instead`,
+		`rule 1: unknown permission set "DoesNotExist", see the scaleway_iam_permission_sets data source for the available permission sets`,
+	}
+
+	if len(errs) != len(want) {
+		t.Fatalf("got errors %v, want %d errors", errs, len(want))
+	}
+
+	for i, err := range errs {
+		if err.Error() != want[i] {
+			t.Errorf("error %d = %q, want %q", i, err, want[i])
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+// checkSCIMEndpoint only accepts HTTPS URLs as the SCIM token is sent with every request,
+// HTTP is only accepted on the loopback interface for the local stand-in servers used in tests
+func checkSCIMEndpoint(endpoint string) error {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid SCIM endpoint: %w", err)
+	}
+
+	if endpointURL.Host == "" {
+		return fmt.Errorf("SCIM endpoint %q must be an absolute URL", endpoint)
+	}
+
+	switch endpointURL.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if isLoopbackHost(endpointURL.Hostname()) {
+			return nil
+		}
+
+		return fmt.Errorf("SCIM endpoint %q must use https, http is only accepted on the loopback interface", endpoint)
+	default:
+		return fmt.Errorf("SCIM endpoint %q must be an HTTPS URL", endpoint)
+	}
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+var _ validator.String = scimEndpointValidator{}
+
+// scimEndpointValidator validates the endpoint attributes with checkSCIMEndpoint
+type scimEndpointValidator struct{}
+
+func (v scimEndpointValidator) Description(ctx context.Context) string {
+	return "value must be an HTTPS URL, or an HTTP URL on the loopback interface"
+}
+
+func (v scimEndpointValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v scimEndpointValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := checkSCIMEndpoint(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid SCIM endpoint", err.Error())
+	}
+}
+
+// scimOrganizationID returns the organization of the principals provisioned through SCIM, the default one of the provider when not set
+func scimOrganizationID(m *meta.Meta, organizationID types.String) string {
+	if organizationID.ValueString() != "" {
+		return organizationID.ValueString()
+	}
+
+	if m == nil {
+		return ""
+	}
+
+	defaultOrgID, _ := m.ScwClient().GetDefaultOrganizationID()
+
+	return defaultOrgID
+}
+
+// findIAMUserID returns the ID of the IAM user provisioned for a SCIM user, matched on its email address,
+// it is empty when IAM does not know the user yet
+func findIAMUserID(ctx context.Context, api *iam.API, organizationID string, email string) (string, error) {
+	if organizationID == "" || email == "" {
+		return "", nil
+	}
+
+	res, err := api.ListUsers(&iam.ListUsersRequest{
+		OrganizationID: &organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	userID := ""
+
+	for _, user := range res.Users {
+		if !strings.EqualFold(user.Email, email) {
+			continue
+		}
+
+		if userID != "" {
+			return "", fmt.Errorf("more than 1 IAM user found with the email %s", email)
+		}
+
+		userID = user.ID
+	}
+
+	return userID, nil
+}
+
+// findIAMGroupID returns the ID of the IAM group provisioned for a SCIM group, matched on its name,
+// it is empty when IAM does not know the group yet
+func findIAMGroupID(ctx context.Context, api *iam.API, organizationID string, name string) (string, error) {
+	if organizationID == "" || name == "" {
+		return "", nil
+	}
+
+	res, err := api.ListGroups(&iam.ListGroupsRequest{
+		OrganizationID: organizationID,
+		Name:           &name,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	groupID := ""
+
+	for _, group := range res.Groups {
+		if group.Name != name {
+			continue
+		}
+
+		if groupID != "" {
+			return "", fmt.Errorf("more than 1 IAM group found with the name %s", name)
+		}
+
+		groupID = group.ID
+	}
+
+	return groupID, nil
+}
@@ -0,0 +1,370 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+const (
+	scimContentType    = "application/scim+json"
+	scimUserSchema     = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema    = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimListPageSize   = 100
+	scimUsersEndpoint  = "Users"
+	scimGroupsEndpoint = "Groups"
+)
+
+// scimClient is a minimal SCIM 2.0 (RFC 7644) client used to manage the users and groups
+// provisioned in an organization through its SCIM endpoint, authenticated with a SCIM token
+type scimClient struct {
+	endpoint    string
+	bearerToken string
+	httpClient  *http.Client
+}
+
+func newSCIMClient(endpoint, bearerToken string, httpClient *http.Client) *scimClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &scimClient{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		bearerToken: bearerToken,
+		httpClient:  httpClient,
+	}
+}
+
+type scimMeta struct {
+	ResourceType string `json:"resourceType,omitempty"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+type scimName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type scimUser struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *scimName   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []scimEmail `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Meta        *scimMeta   `json:"meta,omitempty"`
+}
+
+// primaryEmail returns the primary email of the user, or its first email when none is flagged as primary
+func (u *scimUser) primaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+
+	return ""
+}
+
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+// memberIDs returns the IDs of the members of the group
+func (g *scimGroup) memberIDs() []string {
+	ids := make([]string, 0, len(g.Members))
+	for _, member := range g.Members {
+		ids = append(ids, member.Value)
+	}
+
+	return ids
+}
+
+type scimListResponse struct {
+	TotalResults int               `json:"totalResults"`
+	StartIndex   int               `json:"startIndex"`
+	ItemsPerPage int               `json:"itemsPerPage"`
+	Resources    []json.RawMessage `json:"Resources"`
+}
+
+// scimError is the error returned by a SCIM endpoint, as described in RFC 7644 section 3.12
+type scimError struct {
+	Status   int    `json:"-"`
+	ScimType string `json:"scimType,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+func (e *scimError) Error() string {
+	msg := fmt.Sprintf("scim error %d", e.Status)
+	if e.ScimType != "" {
+		msg += " (" + e.ScimType + ")"
+	}
+
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+
+	return msg
+}
+
+// isSCIMNotFound returns true when the SCIM resource does not exist
+func isSCIMNotFound(err error) bool {
+	scimErr := &scimError{}
+
+	return errors.As(err, &scimErr) && scimErr.Status == http.StatusNotFound
+}
+
+// scimFilterEq builds a SCIM filter matching an attribute equal to a value
+func scimFilterEq(attribute, value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+
+	return attribute + ` eq "` + value + `"`
+}
+
+func (c *scimClient) do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	// The endpoint may be unknown when the configuration is validated
+	if err := checkSCIMEndpoint(c.endpoint); err != nil {
+		return err
+	}
+
+	reqURL := c.endpoint + "/" + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode scim request: %w", err)
+		}
+
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to init scim request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	req.Header.Set("Accept", scimContentType)
+
+	if body != nil {
+		req.Header.Set("Content-Type", scimContentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send scim request: %w", err)
+	}
+	defer resp.Body.Close() //nolint: errcheck
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read scim response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		scimErr := &scimError{}
+		if json.Unmarshal(respBody, scimErr) != nil || scimErr.Detail == "" {
+			scimErr.Detail = strings.TrimSpace(string(respBody))
+		}
+
+		scimErr.Status = resp.StatusCode
+
+		return scimErr
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode scim response: %w", err)
+	}
+
+	return nil
+}
+
+// list returns all the resources of an endpoint matching the filter, following the pagination
+func (c *scimClient) list(ctx context.Context, endpoint, filter string) ([]json.RawMessage, error) {
+	resources := []json.RawMessage(nil)
+	startIndex := 1
+
+	for {
+		query := url.Values{
+			"startIndex": {strconv.Itoa(startIndex)},
+			"count":      {strconv.Itoa(scimListPageSize)},
+		}
+		if filter != "" {
+			query.Set("filter", filter)
+		}
+
+		page := &scimListResponse{}
+		if err := c.do(ctx, http.MethodGet, endpoint, query, nil, page); err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, page.Resources...)
+		startIndex += len(page.Resources)
+
+		if len(page.Resources) == 0 || len(resources) >= page.TotalResults {
+			return resources, nil
+		}
+	}
+}
+
+func (c *scimClient) CreateUser(ctx context.Context, user *scimUser) (*scimUser, error) {
+	user.Schemas = []string{scimUserSchema}
+	created := &scimUser{}
+
+	if err := c.do(ctx, http.MethodPost, scimUsersEndpoint, nil, user, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (c *scimClient) GetUser(ctx context.Context, id string) (*scimUser, error) {
+	user := &scimUser{}
+
+	if err := c.do(ctx, http.MethodGet, scimUsersEndpoint+"/"+url.PathEscape(id), nil, nil, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (c *scimClient) ReplaceUser(ctx context.Context, id string, user *scimUser) (*scimUser, error) {
+	user.Schemas = []string{scimUserSchema}
+	replaced := &scimUser{}
+
+	if err := c.do(ctx, http.MethodPut, scimUsersEndpoint+"/"+url.PathEscape(id), nil, user, replaced); err != nil {
+		return nil, err
+	}
+
+	return replaced, nil
+}
+
+func (c *scimClient) DeleteUser(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, scimUsersEndpoint+"/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *scimClient) ListUsers(ctx context.Context, filter string) ([]*scimUser, error) {
+	resources, err := c.list(ctx, scimUsersEndpoint, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*scimUser, 0, len(resources))
+	for _, raw := range resources {
+		user := &scimUser{}
+		if err := json.Unmarshal(raw, user); err != nil {
+			return nil, fmt.Errorf("failed to decode scim user: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (c *scimClient) CreateGroup(ctx context.Context, group *scimGroup) (*scimGroup, error) {
+	group.Schemas = []string{scimGroupSchema}
+	created := &scimGroup{}
+
+	if err := c.do(ctx, http.MethodPost, scimGroupsEndpoint, nil, group, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (c *scimClient) GetGroup(ctx context.Context, id string) (*scimGroup, error) {
+	group := &scimGroup{}
+
+	if err := c.do(ctx, http.MethodGet, scimGroupsEndpoint+"/"+url.PathEscape(id), nil, nil, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (c *scimClient) ReplaceGroup(ctx context.Context, id string, group *scimGroup) (*scimGroup, error) {
+	group.Schemas = []string{scimGroupSchema}
+	replaced := &scimGroup{}
+
+	if err := c.do(ctx, http.MethodPut, scimGroupsEndpoint+"/"+url.PathEscape(id), nil, group, replaced); err != nil {
+		return nil, err
+	}
+
+	return replaced, nil
+}
+
+func (c *scimClient) DeleteGroup(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, scimGroupsEndpoint+"/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *scimClient) ListGroups(ctx context.Context, filter string) ([]*scimGroup, error) {
+	resources, err := c.list(ctx, scimGroupsEndpoint, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*scimGroup, 0, len(resources))
+	for _, raw := range resources {
+		group := &scimGroup{}
+		if err := json.Unmarshal(raw, group); err != nil {
+			return nil, fmt.Errorf("failed to decode scim group: %w", err)
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// newSCIMClientFromMeta returns a SCIM client sharing the HTTP client of the provider, so that requests are recorded in cassettes
+func newSCIMClientFromMeta(m *meta.Meta, endpoint, bearerToken string) *scimClient {
+	var httpClient *http.Client
+	if m != nil {
+		httpClient = m.HTTPClient()
+	}
+
+	return newSCIMClient(endpoint, bearerToken, httpClient)
+}
@@ -0,0 +1,338 @@
+//nolint:testpackage // Tests need access to the unexported SCIM client.
+package iam
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const scimStandInToken = "stand-in-token"
+
+var scimStandInFilterRegexp = regexp.MustCompile(`^(\w+) eq "((?:[^"\\]|\\.)*)"$`)
+
+// scimStandIn is a local SCIM 2.0 server keeping the users and groups in memory,
+// its pages are capped to a few resources to exercise the pagination
+type scimStandIn struct {
+	sync.Mutex
+
+	nextID  int
+	users   map[string]map[string]any
+	groups  map[string]map[string]any
+	maxPage int
+}
+
+func newSCIMStandIn(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	standIn := &scimStandIn{
+		users:   map[string]map[string]any{},
+		groups:  map[string]map[string]any{},
+		maxPage: 2,
+	}
+
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *scimStandIn) writeError(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:Error"},
+		"status":  strconv.Itoa(status),
+		"detail":  detail,
+	})
+}
+
+func (s *scimStandIn) write(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *scimStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+scimStandInToken {
+		s.writeError(w, http.StatusUnauthorized, "invalid token")
+
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	resources, uniqueAttribute := s.users, "userName"
+	if parts[0] == scimGroupsEndpoint {
+		resources, uniqueAttribute = s.groups, "displayName"
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.list(w, r, resources)
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			s.writeError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+
+		for _, existing := range resources {
+			if existing[uniqueAttribute] == body[uniqueAttribute] {
+				s.writeError(w, http.StatusConflict, uniqueAttribute+" already exists")
+
+				return
+			}
+		}
+
+		s.nextID++
+		body["id"] = fmt.Sprintf("%08d-0000-0000-0000-000000000000", s.nextID)
+		body["meta"] = map[string]any{"created": "2026-01-01T00:00:00Z", "lastModified": "2026-01-01T00:00:00Z"}
+		resources[body["id"].(string)] = body
+		s.write(w, http.StatusCreated, body)
+	case len(parts) == 2:
+		existing, ok := resources[parts[1]]
+		if !ok {
+			s.writeError(w, http.StatusNotFound, "resource "+parts[1]+" not found")
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			s.write(w, http.StatusOK, existing)
+		case http.MethodPut:
+			body := map[string]any{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				s.writeError(w, http.StatusBadRequest, err.Error())
+
+				return
+			}
+
+			body["id"] = parts[1]
+			body["meta"] = map[string]any{"created": "2026-01-01T00:00:00Z", "lastModified": "2026-01-02T00:00:00Z"}
+			resources[parts[1]] = body
+			s.write(w, http.StatusOK, body)
+		case http.MethodDelete:
+			delete(resources, parts[1])
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		s.writeError(w, http.StatusMethodNotAllowed, "unsupported request")
+	}
+}
+
+func (s *scimStandIn) list(w http.ResponseWriter, r *http.Request, resources map[string]map[string]any) {
+	ids := make([]string, 0, len(resources))
+
+	for id, resource := range resources {
+		if filter := r.URL.Query().Get("filter"); filter != "" {
+			match := scimStandInFilterRegexp.FindStringSubmatch(filter)
+			if match == nil {
+				s.writeError(w, http.StatusBadRequest, "invalid filter")
+
+				return
+			}
+
+			value := strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(match[2])
+			if !strings.EqualFold(fmt.Sprint(resource[match[1]]), value) {
+				continue
+			}
+		}
+
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	startIndex, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+	count = min(count, s.maxPage)
+	start := min(max(startIndex, 1)-1, len(ids))
+	end := min(start+count, len(ids))
+
+	page := []map[string]any{}
+	for _, id := range ids[start:end] {
+		page = append(page, resources[id])
+	}
+
+	s.write(w, http.StatusOK, map[string]any{
+		"schemas":      []string{"urn:ietf:params:scim:api:messages:2.0:ListResponse"},
+		"totalResults": len(ids),
+		"startIndex":   start + 1,
+		"itemsPerPage": len(page),
+		"Resources":    page,
+	})
+}
+
+func TestSCIMClientUsers(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server := newSCIMStandIn(t)
+	client := newSCIMClient(server.URL+"/", scimStandInToken, server.Client())
+
+	created, err := client.CreateUser(ctx, expandScimUser(scimUserResourceModel{
+		UserName:   types.StringValue("jane@example.com"),
+		Email:      types.StringValue("jane@example.com"),
+		GivenName:  types.StringValue("Jane"),
+		FamilyName: types.StringValue("Doe"),
+		Active:     types.BoolValue(true),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateUser(ctx, &scimUser{UserName: "jane@example.com"}); err == nil || !strings.Contains(err.Error(), "scim error 409") {
+		t.Fatalf("creating a duplicated user: got %v, want a conflict", err)
+	}
+
+	for _, userName := range []string{"john@example.com", `quote"d@example.com`} {
+		if _, err := client.CreateUser(ctx, &scimUser{UserName: userName}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	users, err := client.ListUsers(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 3 {
+		t.Fatalf("got %d users across pages, want 3", len(users))
+	}
+
+	user, err := findScimUser(ctx, client, "JANE@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.ID != created.ID || user.primaryEmail() != "jane@example.com" {
+		t.Fatalf("found user %+v, want %+v", user, created)
+	}
+
+	if _, err := findScimUser(ctx, client, `quote"d@example.com`); err != nil {
+		t.Fatal(err)
+	}
+
+	data := scimUserResourceModel{UserName: types.StringValue("jane@example.com"), DisplayName: types.StringValue("Jane D."), Active: types.BoolValue(false)}
+
+	replaced, err := client.ReplaceUser(ctx, created.ID, expandScimUser(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flattenScimUser(&data, replaced)
+
+	if data.Active.ValueBool() || data.DisplayName.ValueString() != "Jane D." || !data.Email.IsNull() || data.UpdatedAt.ValueString() != "2026-01-02T00:00:00Z" {
+		t.Fatalf("unexpected state after replacing the user: %+v", data)
+	}
+
+	if err := client.DeleteUser(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetUser(ctx, created.ID); !isSCIMNotFound(err) {
+		t.Fatalf("getting a deleted user: got %v, want not found", err)
+	}
+}
+
+func TestSCIMClientGroups(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server := newSCIMStandIn(t)
+	client := newSCIMClient(server.URL, scimStandInToken, server.Client())
+
+	user, err := client.CreateUser(ctx, &scimUser{UserName: "jane@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := client.CreateGroup(ctx, &scimGroup{DisplayName: "developers", Members: []scimMember{{Value: user.ID}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := findScimGroup(ctx, client, "developers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if group.ID != created.ID || !slices.Equal(group.memberIDs(), []string{user.ID}) {
+		t.Fatalf("found group %+v, want %+v", group, created)
+	}
+
+	replaced, err := client.ReplaceGroup(ctx, created.ID, &scimGroup{DisplayName: "developers", Members: []scimMember{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(replaced.memberIDs()) != 0 {
+		t.Fatalf("got members %v after removing them", replaced.memberIDs())
+	}
+
+	if _, err := findScimGroup(ctx, client, "operators"); err == nil {
+		t.Fatal("expected an error when looking up a missing group")
+	}
+
+	if err := client.DeleteGroup(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeleteGroup(ctx, created.ID); !isSCIMNotFound(err) {
+		t.Fatalf("deleting a deleted group: got %v, want not found", err)
+	}
+}
+
+func TestSCIMClientUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := newSCIMStandIn(t)
+	client := newSCIMClient(server.URL, "revoked-token", server.Client())
+
+	_, err := client.ListUsers(t.Context(), "")
+	if err == nil || err.Error() != "scim error 401: invalid token" {
+		t.Fatalf("got %v, want an unauthorized error", err)
+	}
+}
+
+func TestCheckSCIMEndpoint(t *testing.T) {
+	t.Parallel()
+
+	for _, endpoint := range []string{"https://scim.example.com/v2", "http://127.0.0.1:8080/scim", "http://localhost/scim", "http://[::1]:8080"} {
+		if err := checkSCIMEndpoint(endpoint); err != nil {
+			t.Errorf("endpoint %q: unexpected error %s", endpoint, err)
+		}
+	}
+
+	for _, endpoint := range []string{"http://scim.example.com/v2", "http://10.0.0.1/scim", "ftp://scim.example.com", "scim.example.com/v2", "https://"} {
+		if err := checkSCIMEndpoint(endpoint); err == nil {
+			t.Errorf("endpoint %q: expected an error", endpoint)
+		}
+	}
+}
+
+func TestSCIMClientRefusesPlainHTTP(t *testing.T) {
+	t.Parallel()
+
+	client := newSCIMClient("http://scim.example.com/v2", scimStandInToken, nil)
+
+	_, err := client.ListUsers(t.Context(), "")
+	if err == nil || !strings.Contains(err.Error(), "must use https") {
+		t.Fatalf("got %v, want an https error", err)
+	}
+}
@@ -0,0 +1,127 @@
+package iam
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/permission_sets_data_source.md
+var permissionSetsDataSourceDescription string
+
+func DataSourcePermissionSets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceIamPermissionSetsRead,
+		Description: permissionSetsDataSourceDescription,
+		SchemaFunc:  permissionSetsSchema,
+	}
+}
+
+func permissionSetsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"organization_id": account.OrganizationIDOptionalSchema(),
+		"scope_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "Only list the permission sets of this scope type (projects, organization or account_root_user)",
+			ValidateDiagFunc: verify.ValidateEnum[iam.PermissionSetScopeType](),
+		},
+		// Computed elements
+		"names": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The names of the permission sets",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"permission_sets": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The permission sets",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the permission set",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the permission set",
+					},
+					"scope_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The scope type of the permission set, projects for the permission sets which can be used with project_ids",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The description of the permission set",
+					},
+					"categories": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The categories of the permission set",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceIamPermissionSetsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	orgID := account.GetOrganizationID(m, d)
+	if orgID == nil {
+		return diag.FromErr(errors.New("organization_id is required when no default organization is configured"))
+	}
+
+	permissionSets, err := listPermissionSets(ctx, NewAPI(m), *orgID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	scopeType := iam.PermissionSetScopeType(d.Get("scope_type").(string))
+	names := []string{}
+	flattened := []map[string]any{}
+
+	for _, permissionSet := range permissionSets {
+		if scopeType != "" && permissionSet.ScopeType != scopeType {
+			continue
+		}
+
+		categories := []string(nil)
+		if permissionSet.Categories != nil {
+			categories = *permissionSet.Categories
+		}
+
+		names = append(names, permissionSet.Name)
+		flattened = append(flattened, map[string]any{
+			"id":          permissionSet.ID,
+			"name":        permissionSet.Name,
+			"scope_type":  permissionSet.ScopeType.String(),
+			"description": permissionSet.Description,
+			"categories":  types.FlattenSliceString(categories),
+		})
+	}
+
+	d.SetId(*orgID)
+
+	_ = d.Set("organization_id", *orgID)
+	_ = d.Set("names", names)
+	_ = d.Set("permission_sets", flattened)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package iam_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccDataSourcePermissionSets_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "scaleway_iam_permission_sets" "all" {}
+
+					data "scaleway_iam_permission_sets" "projects" {
+					  scope_type = "projects"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.scaleway_iam_permission_sets.all", "organization_id"),
+					resource.TestCheckTypeSetElemAttr("data.scaleway_iam_permission_sets.all", "names.*", "AllProductsFullAccess"),
+					resource.TestCheckTypeSetElemAttr("data.scaleway_iam_permission_sets.all", "names.*", "ContainerRegistryReadOnly"),
+					resource.TestCheckResourceAttrSet("data.scaleway_iam_permission_sets.all", "permission_sets.0.id"),
+					resource.TestCheckResourceAttrSet("data.scaleway_iam_permission_sets.all", "permission_sets.0.name"),
+					resource.TestCheckResourceAttrPair("data.scaleway_iam_permission_sets.projects", "organization_id", "data.scaleway_iam_permission_sets.all", "organization_id"),
+					resource.TestCheckTypeSetElemAttr("data.scaleway_iam_permission_sets.projects", "names.*", "ContainerRegistryReadOnly"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_permission_sets.projects", "permission_sets.0.scope_type", "projects"),
+				),
+			},
+			{
+				Config: `
+					data "scaleway_iam_permission_sets" "all" {}
+
+					resource "scaleway_iam_policy" "main" {
+					  name         = "tf_tests_policy_permission_sets"
+					  no_principal = true
+					  rule {
+					    organization_id      = data.scaleway_iam_permission_sets.all.organization_id
+					    permission_set_names = ["ContainerRegistryReadOnli"]
+					  }
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`rule 0: unknown permission set "ContainerRegistryReadOnli"`),
+			},
+		},
+	})
+}
@@ -3,13 +3,16 @@ package iam
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
@@ -27,6 +30,7 @@ func ResourcePolicy() *schema.Resource {
 		},
 		SchemaVersion: 0,
 		SchemaFunc:    policySchema,
+		CustomizeDiff: resourceIamPolicyCustomizeDiff,
 	}
 }
 
@@ -134,6 +138,47 @@ func policySchema() map[string]*schema.Schema {
 	}
 }
 
+// resourceIamPolicyCustomizeDiff validates the permission sets of the rules against the catalogue of the organization.
+// The validation is best effort: it is skipped when the catalogue cannot be fetched.
+func resourceIamPolicyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if diff.Id() != "" && !diff.HasChange("rule") {
+		return nil
+	}
+
+	organizationID := diff.Get("organization_id").(string)
+	if organizationID == "" {
+		organizationID, _ = meta.ExtractScwClient(m).GetDefaultOrganizationID()
+	}
+
+	if organizationID == "" {
+		return nil
+	}
+
+	permissionSets, err := listPermissionSets(ctx, NewAPI(m), organizationID)
+	if err != nil {
+		tflog.Warn(ctx, "could not fetch the permission set catalogue, permission sets will be validated by the API", map[string]any{"error": err.Error()})
+
+		return nil
+	}
+
+	rawConfig := diff.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	errs := validatePolicyRulePermissionSets(expandPolicyRulePermissions(rawConfig.GetAttr("rule")), permissionSets)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Errorf("invalid permission sets:\n  - %s", strings.Join(messages, "\n  - "))
+}
+
 func resourceIamPolicyCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	api := NewAPI(m)
 
@@ -0,0 +1,344 @@
+package iam
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+var (
+	_ resource.Resource              = (*ScimGroupResource)(nil)
+	_ resource.ResourceWithConfigure = (*ScimGroupResource)(nil)
+)
+
+func NewScimGroupResource() resource.Resource {
+	return &ScimGroupResource{}
+}
+
+type ScimGroupResource struct {
+	meta *meta.Meta
+}
+
+type scimGroupResourceModel struct {
+	Endpoint    types.String `tfsdk:"endpoint"`
+	BearerToken types.String `tfsdk:"bearer_token"`
+	DisplayName types.String `tfsdk:"display_name"`
+	ExternalID  types.String `tfsdk:"external_id"`
+	MemberIDs   types.Set    `tfsdk:"member_ids"`
+	// Output
+	OrganizationID types.String `tfsdk:"organization_id"`
+	ID             types.String `tfsdk:"id"`
+	IAMGroupID     types.String `tfsdk:"iam_group_id"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+func (r *ScimGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_scim_group"
+}
+
+//go:embed descriptions/scim_group_resource.md
+var scimGroupResourceDescription string
+
+func (r *ScimGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: scimGroupResourceDescription,
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The HTTPS base URL of the SCIM 2.0 endpoint of the organization, as displayed in the console once SCIM is enabled. Plain HTTP is only accepted on the loopback interface.",
+				Required:            true,
+				Validators: []validator.String{
+					scimEndpointValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"bearer_token": schema.StringAttribute{
+				MarkdownDescription: "The SCIM token used to authenticate to the SCIM endpoint, usually the `bearer_token` of a `scaleway_iam_scim_token`.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the group.",
+				Required:            true,
+			},
+			"external_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the group in the identity provider.",
+				Optional:            true,
+			},
+			"member_ids": schema.SetAttribute{
+				MarkdownDescription: "The SCIM IDs of the users of the group. The membership is authoritative, members added outside of Terraform are removed.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization of the SCIM endpoint, in which the IAM group is looked up. If not provided, the default organization configured in the provider is used.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					verify.IsStringUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SCIM ID of the group",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"iam_group_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the IAM group provisioned for the group, to be used in IAM policies. Empty until IAM knows the group",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time of group creation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time of the last group update",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ScimGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.meta = m
+}
+
+func (r *ScimGroupResource) client(data scimGroupResourceModel) *scimClient {
+	return newSCIMClientFromMeta(r.meta, data.Endpoint.ValueString(), data.BearerToken.ValueString())
+}
+
+func (r *ScimGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data scimGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, diags := expandScimGroup(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client(data).CreateGroup(ctx, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create SCIM group",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(flattenScimGroup(ctx, &data, group)...)
+
+	err = r.setIAMGroupID(ctx, &data, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to find the IAM group of SCIM group",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScimGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state scimGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client(state).GetGroup(ctx, state.ID.ValueString())
+	if err != nil {
+		if isSCIMNotFound(err) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Failed to read SCIM group",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(flattenScimGroup(ctx, &state, group)...)
+
+	err = r.setIAMGroupID(ctx, &state, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to find the IAM group of SCIM group",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ScimGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan scimGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+
+	group, diags := expandScimGroup(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client(plan).ReplaceGroup(ctx, state.ID.ValueString(), group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update SCIM group",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(flattenScimGroup(ctx, &plan, group)...)
+
+	err = r.setIAMGroupID(ctx, &plan, group)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to find the IAM group of SCIM group",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ScimGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state scimGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client(state).DeleteGroup(ctx, state.ID.ValueString())
+	if err != nil && !isSCIMNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to delete SCIM group",
+			err.Error(),
+		)
+	}
+}
+
+// setIAMGroupID sets the organization of the group and the ID of its IAM group, matched on its name
+func (r *ScimGroupResource) setIAMGroupID(ctx context.Context, data *scimGroupResourceModel, group *scimGroup) error {
+	organizationID := scimOrganizationID(r.meta, data.OrganizationID)
+	data.OrganizationID = scimStringValue(organizationID)
+
+	groupID, err := findIAMGroupID(ctx, iam.NewAPI(r.meta.ScwClient()), organizationID, group.DisplayName)
+	if err != nil {
+		return err
+	}
+
+	data.IAMGroupID = scimStringValue(groupID)
+
+	return nil
+}
+
+func expandScimGroup(ctx context.Context, data scimGroupResourceModel) (*scimGroup, diag.Diagnostics) {
+	group := &scimGroup{
+		DisplayName: data.DisplayName.ValueString(),
+		ExternalID:  data.ExternalID.ValueString(),
+		Members:     []scimMember{},
+	}
+
+	memberIDs := []string(nil)
+
+	var diags diag.Diagnostics
+	if !data.MemberIDs.IsNull() && !data.MemberIDs.IsUnknown() {
+		diags = data.MemberIDs.ElementsAs(ctx, &memberIDs, false)
+	}
+
+	// Sort the members so that the request does not depend on the set ordering
+	slices.Sort(memberIDs)
+
+	for _, memberID := range memberIDs {
+		group.Members = append(group.Members, scimMember{Value: memberID})
+	}
+
+	return group, diags
+}
+
+// flattenScimGroup sets the attributes returned by the SCIM endpoint, member_ids stays null for a group without members unless it was set to an empty set
+func flattenScimGroup(ctx context.Context, data *scimGroupResourceModel, group *scimGroup) diag.Diagnostics {
+	data.ID = types.StringValue(group.ID)
+	data.DisplayName = types.StringValue(group.DisplayName)
+	data.ExternalID = scimStringValue(group.ExternalID)
+	data.CreatedAt, data.UpdatedAt = flattenScimMeta(group.Meta)
+
+	memberIDs := group.memberIDs()
+	if len(memberIDs) == 0 && data.MemberIDs.IsNull() {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+
+	data.MemberIDs, diags = types.SetValueFrom(ctx, types.StringType, memberIDs)
+
+	return diags
+}
@@ -0,0 +1,260 @@
+package iam
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+var (
+	_ datasource.DataSource              = (*ScimPrincipalDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*ScimPrincipalDataSource)(nil)
+)
+
+const (
+	scimPrincipalTypeUser  = "user"
+	scimPrincipalTypeGroup = "group"
+)
+
+func NewScimPrincipalDataSource() datasource.DataSource {
+	return &ScimPrincipalDataSource{}
+}
+
+type ScimPrincipalDataSource struct {
+	meta *meta.Meta
+}
+
+type scimPrincipalDataSourceModel struct {
+	Endpoint       types.String `tfsdk:"endpoint"`
+	BearerToken    types.String `tfsdk:"bearer_token"`
+	UserName       types.String `tfsdk:"user_name"`
+	GroupName      types.String `tfsdk:"group_name"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	// Output
+	ID          types.String `tfsdk:"id"`
+	IAMID       types.String `tfsdk:"iam_id"`
+	Type        types.String `tfsdk:"type"`
+	DisplayName types.String `tfsdk:"display_name"`
+	ExternalID  types.String `tfsdk:"external_id"`
+	Email       types.String `tfsdk:"email"`
+	Active      types.Bool   `tfsdk:"active"`
+	MemberIDs   types.List   `tfsdk:"member_ids"`
+}
+
+func (d *ScimPrincipalDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_scim_principal"
+}
+
+//go:embed descriptions/scim_principal_data_source.md
+var scimPrincipalDataSourceDescription string
+
+func (d *ScimPrincipalDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: scimPrincipalDataSourceDescription,
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The HTTPS base URL of the SCIM 2.0 endpoint of the organization, as displayed in the console once SCIM is enabled. Plain HTTP is only accepted on the loopback interface.",
+				Required:            true,
+				Validators: []validator.String{
+					scimEndpointValidator{},
+				},
+			},
+			"bearer_token": schema.StringAttribute{
+				MarkdownDescription: "The SCIM token used to authenticate to the SCIM endpoint, usually the `bearer_token` of a `scaleway_iam_scim_token`.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"user_name": schema.StringAttribute{
+				MarkdownDescription: "The user name of the user to look up. Only one of `user_name` and `group_name` should be specified.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("user_name"),
+						path.MatchRoot("group_name"),
+					),
+				},
+			},
+			"group_name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the group to look up. Only one of `user_name` and `group_name` should be specified.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("user_name"),
+						path.MatchRoot("group_name"),
+					),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization of the SCIM endpoint, in which the IAM user or group is looked up. If not provided, the default organization configured in the provider is used.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					verify.IsStringUUID(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The SCIM ID of the principal",
+				Computed:            true,
+			},
+			"iam_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the IAM user or group provisioned for the principal, to be used in IAM policies. Empty until IAM knows the principal",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the principal, `user` or `group`",
+				Computed:            true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the principal",
+				Computed:            true,
+			},
+			"external_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the principal in the identity provider",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The primary email address of the user, empty for a group",
+				Computed:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is active, always true for a group",
+				Computed:            true,
+			},
+			"member_ids": schema.ListAttribute{
+				MarkdownDescription: "The SCIM IDs of the members of the group, empty for a user",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ScimPrincipalDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.meta = m
+}
+
+func (d *ScimPrincipalDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state scimPrincipalDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := newSCIMClientFromMeta(d.meta, state.Endpoint.ValueString(), state.BearerToken.ValueString())
+	iamAPI := iam.NewAPI(d.meta.ScwClient())
+	organizationID := scimOrganizationID(d.meta, state.OrganizationID)
+	state.OrganizationID = types.StringValue(organizationID)
+
+	if !state.UserName.IsNull() {
+		user, err := findScimUser(ctx, client, state.UserName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("user_name"), "Failed to find SCIM user", err.Error())
+
+			return
+		}
+
+		state.ID = types.StringValue(user.ID)
+		state.Type = types.StringValue(scimPrincipalTypeUser)
+		state.DisplayName = types.StringValue(user.DisplayName)
+		state.ExternalID = types.StringValue(user.ExternalID)
+		state.Email = types.StringValue(user.primaryEmail())
+		state.Active = types.BoolValue(user.Active == nil || *user.Active)
+		state.MemberIDs = types.ListValueMust(types.StringType, nil)
+
+		email := user.primaryEmail()
+		if email == "" {
+			email = user.UserName
+		}
+
+		userID, err := findIAMUserID(ctx, iamAPI, organizationID, email)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to find the IAM user of SCIM user", err.Error())
+
+			return
+		}
+
+		state.IAMID = types.StringValue(userID)
+	} else {
+		group, err := findScimGroup(ctx, client, state.GroupName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("group_name"), "Failed to find SCIM group", err.Error())
+
+			return
+		}
+
+		memberIDs, diags := types.ListValueFrom(ctx, types.StringType, group.memberIDs())
+		resp.Diagnostics.Append(diags...)
+
+		state.ID = types.StringValue(group.ID)
+		state.Type = types.StringValue(scimPrincipalTypeGroup)
+		state.DisplayName = types.StringValue(group.DisplayName)
+		state.ExternalID = types.StringValue(group.ExternalID)
+		state.Email = types.StringValue("")
+		state.Active = types.BoolValue(true)
+		state.MemberIDs = memberIDs
+
+		groupID, err := findIAMGroupID(ctx, iamAPI, organizationID, group.DisplayName)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to find the IAM group of SCIM group", err.Error())
+
+			return
+		}
+
+		state.IAMID = types.StringValue(groupID)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// findScimUser returns the user with the given user name, SCIM user names are unique and case-insensitive
+func findScimUser(ctx context.Context, client *scimClient, userName string) (*scimUser, error) {
+	users, err := client.ListUsers(ctx, scimFilterEq("userName", userName))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) != 1 {
+		return nil, fmt.Errorf("expected exactly one user with user name %q, found %d", userName, len(users))
+	}
+
+	return users[0], nil
+}
+
+// findScimGroup returns the group with the given display name, which must be unique
+func findScimGroup(ctx context.Context, client *scimClient, displayName string) (*scimGroup, error) {
+	groups, err := client.ListGroups(ctx, scimFilterEq("displayName", displayName))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) != 1 {
+		return nil, fmt.Errorf("expected exactly one group named %q, found %d", displayName, len(groups))
+	}
+
+	return groups[0], nil
+}
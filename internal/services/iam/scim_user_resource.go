@@ -0,0 +1,371 @@
+package iam
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+var (
+	_ resource.Resource              = (*ScimUserResource)(nil)
+	_ resource.ResourceWithConfigure = (*ScimUserResource)(nil)
+)
+
+func NewScimUserResource() resource.Resource {
+	return &ScimUserResource{}
+}
+
+type ScimUserResource struct {
+	meta *meta.Meta
+}
+
+type scimUserResourceModel struct {
+	Endpoint    types.String `tfsdk:"endpoint"`
+	BearerToken types.String `tfsdk:"bearer_token"`
+	UserName    types.String `tfsdk:"user_name"`
+	Email       types.String `tfsdk:"email"`
+	GivenName   types.String `tfsdk:"given_name"`
+	FamilyName  types.String `tfsdk:"family_name"`
+	DisplayName types.String `tfsdk:"display_name"`
+	ExternalID  types.String `tfsdk:"external_id"`
+	Active      types.Bool   `tfsdk:"active"`
+	// Output
+	OrganizationID types.String `tfsdk:"organization_id"`
+	ID             types.String `tfsdk:"id"`
+	IAMUserID      types.String `tfsdk:"iam_user_id"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+func (r *ScimUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_scim_user"
+}
+
+//go:embed descriptions/scim_user_resource.md
+var scimUserResourceDescription string
+
+func (r *ScimUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: scimUserResourceDescription,
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The HTTPS base URL of the SCIM 2.0 endpoint of the organization, as displayed in the console once SCIM is enabled. Plain HTTP is only accepted on the loopback interface.",
+				Required:            true,
+				Validators: []validator.String{
+					scimEndpointValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"bearer_token": schema.StringAttribute{
+				MarkdownDescription: "The SCIM token used to authenticate to the SCIM endpoint, usually the `bearer_token` of a `scaleway_iam_scim_token`.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"user_name": schema.StringAttribute{
+				MarkdownDescription: "The unique user name of the user, usually its email address.",
+				Required:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The primary email address of the user.",
+				Optional:            true,
+			},
+			"given_name": schema.StringAttribute{
+				MarkdownDescription: "The given name of the user.",
+				Optional:            true,
+			},
+			"family_name": schema.StringAttribute{
+				MarkdownDescription: "The family name of the user.",
+				Optional:            true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The display name of the user.",
+				Optional:            true,
+			},
+			"external_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user in the identity provider.",
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is active. Inactive users cannot log in.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization of the SCIM endpoint, in which the IAM user is looked up. If not provided, the default organization configured in the provider is used.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					verify.IsStringUUID(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SCIM ID of the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"iam_user_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the IAM user provisioned for the user, to be used in IAM policies and groups. Empty until IAM knows the user",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time of user creation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time of the last user update",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ScimUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.meta = m
+}
+
+func (r *ScimUserResource) client(data scimUserResourceModel) *scimClient {
+	return newSCIMClientFromMeta(r.meta, data.Endpoint.ValueString(), data.BearerToken.ValueString())
+}
+
+func (r *ScimUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data scimUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client(data).CreateUser(ctx, expandScimUser(data))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create SCIM user",
+			err.Error(),
+		)
+
+		return
+	}
+
+	flattenScimUser(&data, user)
+
+	err = r.setIAMUserID(ctx, &data, user)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to find the IAM user of SCIM user",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScimUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state scimUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client(state).GetUser(ctx, state.ID.ValueString())
+	if err != nil {
+		if isSCIMNotFound(err) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Failed to read SCIM user",
+			err.Error(),
+		)
+
+		return
+	}
+
+	flattenScimUser(&state, user)
+
+	err = r.setIAMUserID(ctx, &state, user)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to find the IAM user of SCIM user",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ScimUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, plan scimUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+
+	user, err := r.client(plan).ReplaceUser(ctx, state.ID.ValueString(), expandScimUser(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to update SCIM user",
+			err.Error(),
+		)
+
+		return
+	}
+
+	flattenScimUser(&plan, user)
+
+	err = r.setIAMUserID(ctx, &plan, user)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to find the IAM user of SCIM user",
+			err.Error(),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ScimUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state scimUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client(state).DeleteUser(ctx, state.ID.ValueString())
+	if err != nil && !isSCIMNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Failed to delete SCIM user",
+			err.Error(),
+		)
+	}
+}
+
+// setIAMUserID sets the organization of the user and the ID of its IAM user, matched on its email address or else on its user name
+func (r *ScimUserResource) setIAMUserID(ctx context.Context, data *scimUserResourceModel, user *scimUser) error {
+	organizationID := scimOrganizationID(r.meta, data.OrganizationID)
+	data.OrganizationID = scimStringValue(organizationID)
+
+	email := user.primaryEmail()
+	if email == "" {
+		email = user.UserName
+	}
+
+	userID, err := findIAMUserID(ctx, iam.NewAPI(r.meta.ScwClient()), organizationID, email)
+	if err != nil {
+		return err
+	}
+
+	data.IAMUserID = scimStringValue(userID)
+
+	return nil
+}
+
+func expandScimUser(data scimUserResourceModel) *scimUser {
+	user := &scimUser{
+		UserName:    data.UserName.ValueString(),
+		DisplayName: data.DisplayName.ValueString(),
+		ExternalID:  data.ExternalID.ValueString(),
+		Active:      data.Active.ValueBoolPointer(),
+	}
+
+	if !data.GivenName.IsNull() || !data.FamilyName.IsNull() {
+		user.Name = &scimName{
+			GivenName:  data.GivenName.ValueString(),
+			FamilyName: data.FamilyName.ValueString(),
+		}
+	}
+
+	if email := data.Email.ValueString(); email != "" {
+		user.Emails = []scimEmail{{Value: email, Type: "work", Primary: true}}
+	}
+
+	return user
+}
+
+// flattenScimUser sets the attributes returned by the SCIM endpoint, optional attributes stay null when the endpoint does not return them
+func flattenScimUser(data *scimUserResourceModel, user *scimUser) {
+	data.ID = types.StringValue(user.ID)
+	data.UserName = types.StringValue(user.UserName)
+	data.Email = scimStringValue(user.primaryEmail())
+	data.DisplayName = scimStringValue(user.DisplayName)
+	data.ExternalID = scimStringValue(user.ExternalID)
+	data.GivenName = types.StringNull()
+	data.FamilyName = types.StringNull()
+
+	if user.Name != nil {
+		data.GivenName = scimStringValue(user.Name.GivenName)
+		data.FamilyName = scimStringValue(user.Name.FamilyName)
+	}
+
+	// A user is active unless stated otherwise
+	data.Active = types.BoolValue(user.Active == nil || *user.Active)
+
+	data.CreatedAt, data.UpdatedAt = flattenScimMeta(user.Meta)
+}
+
+func flattenScimMeta(scimMeta *scimMeta) (types.String, types.String) {
+	if scimMeta == nil {
+		return types.StringNull(), types.StringNull()
+	}
+
+	return scimStringValue(scimMeta.Created), scimStringValue(scimMeta.LastModified)
+}
+
+func scimStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
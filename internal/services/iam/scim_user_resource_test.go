@@ -0,0 +1,181 @@
+package iam_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	iamSDK "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/iam"
+)
+
+// scimEndpoint is the SCIM base URL of the organization of the tests, it is displayed in the console once SCIM is enabled
+var scimEndpoint = os.Getenv("IAM_SCIM_ENDPOINT")
+
+func scimPrincipalsConfig(familyName string, members string) string {
+	return fmt.Sprintf(`
+		resource "scaleway_iam_scim" "main" {}
+
+		resource "scaleway_iam_scim_token" "main" {
+		  scim_id = scaleway_iam_scim.main.id
+		}
+
+		resource "scaleway_iam_scim_user" "main" {
+		  endpoint     = %[1]q
+		  bearer_token = scaleway_iam_scim_token.main.bearer_token
+		  user_name    = "tf-test-scim-user@scaleway.test"
+		  email        = "tf-test-scim-user@scaleway.test"
+		  given_name   = "Terraform"
+		  family_name  = %[2]q
+		}
+
+		resource "scaleway_iam_scim_group" "main" {
+		  endpoint     = %[1]q
+		  bearer_token = scaleway_iam_scim_token.main.bearer_token
+		  display_name = "tf-test-scim-group"
+		  member_ids   = [%[3]s]
+		}
+
+		data "scaleway_iam_scim_principal" "user" {
+		  endpoint     = %[1]q
+		  bearer_token = scaleway_iam_scim_token.main.bearer_token
+		  user_name    = scaleway_iam_scim_user.main.user_name
+		}
+
+		data "scaleway_iam_scim_principal" "group" {
+		  endpoint     = %[1]q
+		  bearer_token = scaleway_iam_scim_token.main.bearer_token
+		  group_name   = scaleway_iam_scim_group.main.display_name
+		}
+	`, scimEndpoint, familyName, members)
+}
+
+func TestAccScimPrincipals_Basic(t *testing.T) {
+	if scimEndpoint == "" {
+		t.Skip("IAM_SCIM_ENDPOINT is not set, skipping test")
+	}
+
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			checkScimDestroyed(tt),
+			testAccCheckScimPrincipalsDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: scimPrincipalsConfig("User", "scaleway_iam_scim_user.main.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("scaleway_iam_scim_user.main", "id"),
+					resource.TestCheckResourceAttrSet("scaleway_iam_scim_user.main", "iam_user_id"),
+					resource.TestCheckResourceAttrSet("scaleway_iam_scim_user.main", "organization_id"),
+					resource.TestCheckResourceAttr("scaleway_iam_scim_user.main", "active", "true"),
+					resource.TestCheckResourceAttrSet("scaleway_iam_scim_group.main", "iam_group_id"),
+					resource.TestCheckResourceAttr("scaleway_iam_scim_group.main", "member_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.scaleway_iam_scim_principal.user", "id", "scaleway_iam_scim_user.main", "id"),
+					resource.TestCheckResourceAttrPair("data.scaleway_iam_scim_principal.user", "iam_id", "scaleway_iam_scim_user.main", "iam_user_id"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_scim_principal.user", "type", "user"),
+					resource.TestCheckResourceAttrPair("data.scaleway_iam_scim_principal.group", "iam_id", "scaleway_iam_scim_group.main", "iam_group_id"),
+					resource.TestCheckResourceAttr("data.scaleway_iam_scim_principal.group", "member_ids.#", "1"),
+					testAccCheckScimUserIAMUser(tt, "scaleway_iam_scim_user.main"),
+				),
+			},
+			{
+				Config: scimPrincipalsConfig("Updated", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_iam_scim_user.main", "family_name", "Updated"),
+					resource.TestCheckResourceAttr("scaleway_iam_scim_group.main", "member_ids.#", "0"),
+				),
+			},
+			{
+				Config:   scimPrincipalsConfig("Updated", ""),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccScimUser_PlainHTTPEndpoint(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_iam_scim_user" "main" {
+					  endpoint     = "http://scim.example.com/v2"
+					  bearer_token = "token"
+					  user_name    = "tf-test-scim-user@scaleway.test"
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must use https, http is only accepted on the loopback interface`),
+			},
+		},
+	})
+}
+
+// testAccCheckScimUserIAMUser checks that the IAM user of a SCIM user has its email address
+func testAccCheckScimUserIAMUser(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		user, err := iam.NewAPI(tt.Meta).GetUser(&iamSDK.GetUserRequest{
+			UserID: rs.Primary.Attributes["iam_user_id"],
+		})
+		if err != nil {
+			return err
+		}
+
+		if user.Email != rs.Primary.Attributes["email"] {
+			return fmt.Errorf("IAM user %s has email %s, expected %s", user.ID, user.Email, rs.Primary.Attributes["email"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckScimPrincipalsDestroy(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		api := iam.NewAPI(tt.Meta)
+
+		for _, rs := range state.RootModule().Resources {
+			var err error
+
+			switch rs.Type {
+			case "scaleway_iam_scim_user":
+				_, err = api.GetUser(&iamSDK.GetUserRequest{
+					UserID: rs.Primary.Attributes["iam_user_id"],
+				})
+			case "scaleway_iam_scim_group":
+				_, err = api.GetGroup(&iamSDK.GetGroupRequest{
+					GroupID: rs.Primary.Attributes["iam_group_id"],
+				})
+			default:
+				continue
+			}
+
+			if err == nil {
+				return fmt.Errorf("resource %s(%s) still exists", rs.Type, rs.Primary.ID)
+			}
+
+			if !httperrors.Is404(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -17,9 +17,15 @@ func AddTestSweepers() {
 		Name: "scaleway_iam_api_key",
 		F:    testSweepIamAPIKey,
 	})
+	resource.AddTestSweepers("scaleway_iam_api_key_rotation", &resource.Sweeper{
+		Name: "scaleway_iam_api_key_rotation",
+		// The keys of a rotation are API keys with the description of the rotation
+		F: testSweepIamAPIKey,
+	})
 	resource.AddTestSweepers("scaleway_iam_application", &resource.Sweeper{
-		Name: "scaleway_iam_application",
-		F:    testSweepIamApplication,
+		Name:         "scaleway_iam_application",
+		F:            testSweepIamApplication,
+		Dependencies: []string{"scaleway_iam_api_key_rotation"},
 	})
 	resource.AddTestSweepers("scaleway_iam_group", &resource.Sweeper{
 		Name: "scaleway_iam_group",
@@ -41,9 +47,19 @@ func AddTestSweepers() {
 		Name: "scaleway_iam_saml",
 		F:    testSweepSaml,
 	})
+	// The users and groups provisioned through SCIM are IAM users and groups
+	resource.AddTestSweepers("scaleway_iam_scim_user", &resource.Sweeper{
+		Name: "scaleway_iam_scim_user",
+		F:    testSweepUser,
+	})
+	resource.AddTestSweepers("scaleway_iam_scim_group", &resource.Sweeper{
+		Name: "scaleway_iam_scim_group",
+		F:    testSweepIamGroup,
+	})
 	resource.AddTestSweepers("scaleway_iam_scim", &resource.Sweeper{
-		Name: "scaleway_iam_scim",
-		F:    testSweepScim,
+		Name:         "scaleway_iam_scim",
+		F:            testSweepScim,
+		Dependencies: []string{"scaleway_iam_scim_user", "scaleway_iam_scim_group"},
 	})
 }
 
@@ -0,0 +1,61 @@
+package instance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/cloudinit"
+)
+
+func DataSourceCloudInit() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceInstanceCloudInitRead,
+		SchemaFunc:  cloudInitDataSourceSchema,
+	}
+}
+
+func cloudInitDataSourceSchema() map[string]*schema.Schema {
+	dsSchema := cloudInitPartsSchema()
+
+	dsSchema["base64_encode"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Base64 encode the rendered message, always true when gzip is enabled",
+	}
+	dsSchema["rendered"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The rendered MIME multipart message",
+	}
+
+	return dsSchema
+}
+
+func DataSourceInstanceCloudInitRead(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	parts, gzip := expandCloudInitParts(map[string]any{
+		"gzip": d.Get("gzip"),
+		"part": d.Get("part"),
+	})
+
+	diags := validateCloudInitParts(parts, cty.GetAttrPath("part"))
+	if diags.HasError() {
+		return diags
+	}
+
+	rendered, err := cloudinit.Render(parts, gzip, d.Get("base64_encode").(bool))
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	hash := sha256.Sum256([]byte(rendered))
+
+	d.SetId(hex.EncodeToString(hash[:]))
+	_ = d.Set("rendered", rendered)
+
+	return diags
+}
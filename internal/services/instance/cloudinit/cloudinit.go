@@ -0,0 +1,206 @@
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Boundary is the fixed MIME boundary used when rendering, it keeps the output stable between plans
+	Boundary = "MIMEBOUNDARY"
+
+	ContentTypeCloudConfig = "text/cloud-config"
+	ContentTypeShellScript = "text/x-shellscript"
+	ContentTypeJinja       = "text/jinja2"
+	ContentTypeBoothook    = "text/cloud-boothook"
+	ContentTypeIncludeURL  = "text/x-include-url"
+	ContentTypePartHandler = "text/part-handler"
+
+	// CloudConfigHeader must be the first line of a cloud-config document
+	CloudConfigHeader = "#cloud-config"
+	// JinjaHeader must be the first line of a jinja templated part
+	JinjaHeader = "## template: jinja"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Part is one part of a MIME multipart cloud-init message
+type Part struct {
+	ContentType string
+	Filename    string
+	Content     string
+	MergeType   string
+}
+
+// ContentTypes returns the content types accepted in a part
+func ContentTypes() []string {
+	return []string{
+		ContentTypeCloudConfig,
+		ContentTypeShellScript,
+		ContentTypeJinja,
+		ContentTypeBoothook,
+		ContentTypeIncludeURL,
+		ContentTypePartHandler,
+	}
+}
+
+// Render builds a MIME multipart message from parts. When gzipOutput is true the message is compressed,
+// the compressed message is always base64 encoded so it can be stored as a string.
+func Render(parts []Part, gzipOutput bool, base64Encode bool) (string, error) {
+	if len(parts) == 0 {
+		return "", errors.New("at least one part is required")
+	}
+
+	var message bytes.Buffer
+
+	message.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\n", Boundary))
+	message.WriteString("MIME-Version: 1.0\r\n\r\n")
+
+	writer := multipart.NewWriter(&message)
+
+	err := writer.SetBoundary(Boundary)
+	if err != nil {
+		return "", err
+	}
+
+	for i, part := range parts {
+		contentType := part.ContentType
+		if contentType == "" {
+			contentType = ContentTypeCloudConfig
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", contentType)
+		header.Set("Content-Transfer-Encoding", "7bit")
+		header.Set("Mime-Version", "1.0")
+
+		if part.Filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", part.Filename))
+		}
+
+		if part.MergeType != "" {
+			header.Set("X-Merge-Type", part.MergeType)
+		}
+
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return "", fmt.Errorf("failed to create part %d: %w", i, err)
+		}
+
+		content := part.Content
+		if contentType == ContentTypeJinja && !strings.HasPrefix(content, JinjaHeader) {
+			content = JinjaHeader + "\n" + content
+		}
+
+		_, err = partWriter.Write([]byte(content))
+		if err != nil {
+			return "", fmt.Errorf("failed to write part %d: %w", i, err)
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	if !gzipOutput {
+		if base64Encode {
+			return base64.StdEncoding.EncodeToString(message.Bytes()), nil
+		}
+
+		return message.String(), nil
+	}
+
+	var compressed bytes.Buffer
+
+	gzipWriter, err := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = gzipWriter.Write(message.Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(compressed.Bytes()), nil
+}
+
+// DecodeRendered returns the bytes to upload for a rendered message: base64 encoded gzip payloads are decoded,
+// anything else is sent as is.
+func DecodeRendered(rendered string) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(rendered)
+	if err == nil && bytes.HasPrefix(decoded, gzipMagic) {
+		return decoded
+	}
+
+	return []byte(rendered)
+}
+
+// EncodeForState returns the string stored in the state for raw user data: gzip payloads are base64 encoded.
+func EncodeForState(raw []byte) string {
+	if bytes.HasPrefix(raw, gzipMagic) {
+		return base64.StdEncoding.EncodeToString(raw)
+	}
+
+	return string(raw)
+}
+
+// IsCloudConfig returns true when the content is a cloud-config document
+func IsCloudConfig(content string) bool {
+	return strings.HasPrefix(strings.TrimLeft(content, "\r\n\t "), CloudConfigHeader)
+}
+
+// Severity of a validation issue
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+// Issue is a problem found in a cloud-config document
+type Issue struct {
+	Severity Severity
+	Line     int
+	Column   int
+	Message  string
+}
+
+func (i Issue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+var (
+	yamlErrorLine   = regexp.MustCompile(`line (\d+)`)
+	yamlErrorPrefix = regexp.MustCompile(`^yaml: (line \d+: )?`)
+)
+
+// lineFromYAMLError extracts the line number reported by the yaml parser
+func lineFromYAMLError(err error) int {
+	match := yamlErrorLine.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0
+	}
+
+	line, _ := strconv.Atoi(match[1])
+
+	return line
+}
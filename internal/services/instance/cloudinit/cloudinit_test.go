@@ -0,0 +1,133 @@
+package cloudinit_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/cloudinit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	parts := []cloudinit.Part{
+		{
+			ContentType: cloudinit.ContentTypeCloudConfig,
+			Filename:    "base.yml",
+			Content:     "#cloud-config\npackage_update: true\n",
+		},
+		{
+			ContentType: cloudinit.ContentTypeShellScript,
+			Content:     "#!/bin/sh\necho hello\n",
+			MergeType:   "list(append)+dict(recurse_array)",
+		},
+		{
+			ContentType: cloudinit.ContentTypeJinja,
+			Content:     "#cloud-config\nhostname: {{ v1.instance_id }}\n",
+		},
+	}
+
+	rendered, err := cloudinit.Render(parts, false, false)
+	require.NoError(t, err)
+
+	assert.Contains(t, rendered, "Content-Type: multipart/mixed; boundary=\"MIMEBOUNDARY\"")
+	assert.Contains(t, rendered, "Content-Disposition: attachment; filename=\"base.yml\"")
+	assert.Contains(t, rendered, "X-Merge-Type: list(append)+dict(recurse_array)")
+	assert.Contains(t, rendered, "## template: jinja\n#cloud-config\nhostname: {{ v1.instance_id }}")
+	assert.Contains(t, rendered, "--MIMEBOUNDARY--")
+
+	again, err := cloudinit.Render(parts, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, rendered, again, "rendering must be stable")
+
+	compressed, err := cloudinit.Render(parts, true, false)
+	require.NoError(t, err)
+
+	raw, err := base64.StdEncoding.DecodeString(compressed)
+	require.NoError(t, err)
+
+	reader, err := gzip.NewReader(bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	decompressed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, rendered, string(decompressed))
+
+	assert.Equal(t, raw, cloudinit.DecodeRendered(compressed))
+	assert.Equal(t, compressed, cloudinit.EncodeForState(raw))
+	assert.Equal(t, []byte(rendered), cloudinit.DecodeRendered(rendered))
+
+	_, err = cloudinit.Render(nil, false, false)
+	assert.Error(t, err)
+}
+
+func TestValidateCloudConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+		errors   int
+	}{
+		{
+			name:    "valid",
+			content: "#cloud-config\npackage_update: true\npackages:\n  - nginx\nwrite_files:\n  - path: /etc/motd\n    content: hello\n",
+		},
+		{
+			name:     "missingHeader",
+			content:  "package_update: true\n",
+			expected: []string{"line 1: cloud-config documents must start with \"#cloud-config\""},
+			errors:   1,
+		},
+		{
+			name:     "syntaxError",
+			content:  "#cloud-config\npackages:\n  - nginx\nhostname: a: b\n",
+			expected: []string{"line 4: mapping values are not allowed in this context"},
+			errors:   1,
+		},
+		{
+			name:     "wrongType",
+			content:  "#cloud-config\nruncmd: echo hello\npackage_upgrade: yes please\n",
+			expected: []string{"line 2: \"runcmd\" must be a list", "line 3: \"package_upgrade\" must be a boolean"},
+			errors:   2,
+		},
+		{
+			name:     "unknownKey",
+			content:  "#cloud-config\napt-update: true\nssh-authorized-keys: []\n",
+			expected: []string{"line 2: unknown key \"apt-update\", did you mean \"package_update\"?", "line 3: unknown key \"ssh-authorized-keys\", did you mean \"ssh_authorized_keys\"?"},
+		},
+		{
+			name:     "writeFilesWithoutPath",
+			content:  "#cloud-config\nwrite_files:\n  - content: hello\n    mode: 0644\n",
+			expected: []string{"line 3: write_files entry is missing the required key \"path\"", "line 4: unknown write_files key \"mode\""},
+			errors:   1,
+		},
+		{
+			name:     "duplicatedKey",
+			content:  "#cloud-config\nhostname: a\nhostname: b\n",
+			expected: []string{"line 3: key \"hostname\" is already defined at line 2"},
+			errors:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := cloudinit.ValidateCloudConfig(tt.content)
+
+			messages := []string(nil)
+			errors := 0
+
+			for _, issue := range issues {
+				messages = append(messages, issue.String())
+				if issue.Severity == cloudinit.SeverityError {
+					errors++
+				}
+			}
+
+			assert.Equal(t, tt.expected, messages)
+			assert.Equal(t, tt.errors, errors)
+		})
+	}
+}
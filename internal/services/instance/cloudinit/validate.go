@@ -0,0 +1,298 @@
+package cloudinit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kind is the YAML kind expected for a cloud-config key
+type kind int
+
+const (
+	kindAny kind = iota
+	kindScalar
+	kindBool
+	kindSequence
+	kindMapping
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindScalar:
+		return "a string"
+	case kindBool:
+		return "a boolean"
+	case kindSequence:
+		return "a list"
+	case kindMapping:
+		return "a mapping"
+	}
+
+	return "any value"
+}
+
+// cloudConfigSchema is the subset of the cloud-config schema checked at plan time.
+// Keys missing from this map are reported as warnings, not errors, as modules may add their own.
+var cloudConfigSchema = map[string]kind{
+	"apk_repos":                  kindMapping,
+	"apt":                        kindMapping,
+	"apt_pipelining":             kindAny,
+	"bootcmd":                    kindSequence,
+	"byobu_by_default":           kindScalar,
+	"ca_certs":                   kindMapping,
+	"chef":                       kindMapping,
+	"chpasswd":                   kindMapping,
+	"disable_ec2_metadata":       kindBool,
+	"disable_root":               kindBool,
+	"disk_setup":                 kindMapping,
+	"fqdn":                       kindScalar,
+	"fs_setup":                   kindSequence,
+	"final_message":              kindScalar,
+	"groups":                     kindAny,
+	"growpart":                   kindMapping,
+	"hostname":                   kindScalar,
+	"keyboard":                   kindMapping,
+	"locale":                     kindAny,
+	"manage_etc_hosts":           kindAny,
+	"manage_resolv_conf":         kindBool,
+	"merge_how":                  kindAny,
+	"merge_type":                 kindAny,
+	"mounts":                     kindSequence,
+	"mount_default_fields":       kindSequence,
+	"ntp":                        kindMapping,
+	"output":                     kindMapping,
+	"package_reboot_if_required": kindBool,
+	"package_update":             kindBool,
+	"package_upgrade":            kindBool,
+	"packages":                   kindSequence,
+	"phone_home":                 kindMapping,
+	"power_state":                kindMapping,
+	"prefer_fqdn_over_hostname":  kindBool,
+	"preserve_hostname":          kindBool,
+	"puppet":                     kindMapping,
+	"random_seed":                kindMapping,
+	"resize_rootfs":              kindAny,
+	"resolv_conf":                kindMapping,
+	"rsyslog":                    kindMapping,
+	"runcmd":                     kindSequence,
+	"salt_minion":                kindMapping,
+	"snap":                       kindMapping,
+	"ssh":                        kindMapping,
+	"ssh_authorized_keys":        kindSequence,
+	"ssh_deletekeys":             kindBool,
+	"ssh_genkeytypes":            kindSequence,
+	"ssh_keys":                   kindMapping,
+	"ssh_pwauth":                 kindAny,
+	"ssh_quiet_keygen":           kindBool,
+	"swap":                       kindMapping,
+	"timezone":                   kindScalar,
+	"ubuntu_advantage":           kindMapping,
+	"ubuntu_pro":                 kindMapping,
+	"updates":                    kindMapping,
+	"user":                       kindAny,
+	"users":                      kindAny,
+	"write_files":                kindSequence,
+	"yum_repo_dir":               kindScalar,
+	"yum_repos":                  kindMapping,
+	"zypper":                     kindMapping,
+}
+
+// writeFilesSchema is the schema of an entry of write_files
+var writeFilesSchema = map[string]kind{
+	"path":        kindScalar,
+	"content":     kindScalar,
+	"source":      kindMapping,
+	"owner":       kindScalar,
+	"permissions": kindScalar,
+	"encoding":    kindScalar,
+	"append":      kindBool,
+	"defer":       kindBool,
+}
+
+// ValidateCloudConfig parses a cloud-config document and checks it against the cloud-config schema.
+// Issues are sorted by line.
+func ValidateCloudConfig(content string) []Issue {
+	issues := []Issue(nil)
+
+	if !IsCloudConfig(content) {
+		return []Issue{{
+			Severity: SeverityError,
+			Line:     1,
+			Column:   1,
+			Message:  fmt.Sprintf("cloud-config documents must start with %q", CloudConfigHeader),
+		}}
+	}
+
+	document := yaml.Node{}
+
+	err := yaml.Unmarshal([]byte(content), &document)
+	if err != nil {
+		return []Issue{{
+			Severity: SeverityError,
+			Line:     lineFromYAMLError(err),
+			Message:  yamlErrorPrefix.ReplaceAllString(err.Error(), ""),
+		}}
+	}
+
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []Issue{{
+			Severity: SeverityError,
+			Line:     root.Line,
+			Column:   root.Column,
+			Message:  "cloud-config document must be a mapping",
+		}}
+	}
+
+	seen := map[string]int{}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		if firstLine, ok := seen[key.Value]; ok {
+			issues = append(issues, Issue{
+				Severity: SeverityError,
+				Line:     key.Line,
+				Column:   key.Column,
+				Message:  fmt.Sprintf("key %q is already defined at line %d", key.Value, firstLine),
+			})
+
+			continue
+		}
+
+		seen[key.Value] = key.Line
+
+		expected, known := cloudConfigSchema[key.Value]
+		if !known {
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				Line:     key.Line,
+				Column:   key.Column,
+				Message:  unknownKeyMessage(key.Value),
+			})
+
+			continue
+		}
+
+		if issue := checkKind(key.Value, value, expected); issue != nil {
+			issues = append(issues, *issue)
+
+			continue
+		}
+
+		if key.Value == "write_files" {
+			issues = append(issues, validateWriteFiles(value)...)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	return issues
+}
+
+func validateWriteFiles(node *yaml.Node) []Issue {
+	issues := []Issue(nil)
+
+	for _, entry := range node.Content {
+		if entry.Kind != yaml.MappingNode {
+			issues = append(issues, Issue{
+				Severity: SeverityError,
+				Line:     entry.Line,
+				Column:   entry.Column,
+				Message:  "write_files entries must be mappings",
+			})
+
+			continue
+		}
+
+		hasPath := false
+
+		for i := 0; i+1 < len(entry.Content); i += 2 {
+			key, value := entry.Content[i], entry.Content[i+1]
+
+			expected, known := writeFilesSchema[key.Value]
+			if !known {
+				issues = append(issues, Issue{
+					Severity: SeverityWarning,
+					Line:     key.Line,
+					Column:   key.Column,
+					Message:  fmt.Sprintf("unknown write_files key %q", key.Value),
+				})
+
+				continue
+			}
+
+			if key.Value == "path" {
+				hasPath = true
+			}
+
+			if issue := checkKind("write_files."+key.Value, value, expected); issue != nil {
+				issues = append(issues, *issue)
+			}
+		}
+
+		if !hasPath {
+			issues = append(issues, Issue{
+				Severity: SeverityError,
+				Line:     entry.Line,
+				Column:   entry.Column,
+				Message:  "write_files entry is missing the required key \"path\"",
+			})
+		}
+	}
+
+	return issues
+}
+
+func checkKind(name string, value *yaml.Node, expected kind) *Issue {
+	valid := true
+
+	switch expected {
+	case kindAny:
+	case kindScalar:
+		valid = value.Kind == yaml.ScalarNode
+	case kindBool:
+		valid = value.Kind == yaml.ScalarNode && value.ShortTag() == "!!bool"
+	case kindSequence:
+		valid = value.Kind == yaml.SequenceNode
+	case kindMapping:
+		valid = value.Kind == yaml.MappingNode
+	}
+
+	if valid {
+		return nil
+	}
+
+	return &Issue{
+		Severity: SeverityError,
+		Line:     value.Line,
+		Column:   value.Column,
+		Message:  fmt.Sprintf("%q must be %s", name, expected),
+	}
+}
+
+// unknownKeyMessage suggests the closest known key, "apt-update" is a common mistake for "package_update"
+func unknownKeyMessage(key string) string {
+	normalized := strings.ReplaceAll(key, "-", "_")
+	if _, ok := cloudConfigSchema[normalized]; ok {
+		return fmt.Sprintf("unknown key %q, did you mean %q?", key, normalized)
+	}
+
+	if strings.HasPrefix(normalized, "apt_") {
+		candidate := "package_" + strings.TrimPrefix(normalized, "apt_")
+		if _, ok := cloudConfigSchema[candidate]; ok {
+			return fmt.Sprintf("unknown key %q, did you mean %q?", key, candidate)
+		}
+	}
+
+	return fmt.Sprintf("unknown key %q, it will be ignored by cloud-init", key)
+}
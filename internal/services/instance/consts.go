@@ -13,6 +13,7 @@ const (
 	InstanceServerStateStandby = "standby"
 
 	DefaultInstanceServerWaitTimeout        = 20 * time.Minute
+	defaultInstanceServerFleetTimeout       = 1 * time.Hour
 	defaultInstancePrivateNICWaitTimeout    = 10 * time.Minute
 	defaultInstanceVolumeDeleteTimeout      = 10 * time.Minute
 	defaultInstanceSecurityGroupTimeout     = 1 * time.Minute
@@ -0,0 +1,592 @@
+package instance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	autoscaling "github.com/scaleway/scaleway-sdk-go/api/autoscaling/v1alpha1"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	scwvalidation "github.com/scaleway/scaleway-sdk-go/validation"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/instancehelpers"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/workerpool"
+)
+
+const (
+	// ServerFleetTagPrefix is the tag used to find the members of a fleet
+	ServerFleetTagPrefix = "fleet="
+	// ServerFleetIndexTagPrefix is the tag holding the position of a member in its fleet
+	ServerFleetIndexTagPrefix = "fleet-index="
+	// ServerFleetPendingIndexTagPrefix is the tag of a replacement during a rolling update, it holds the position of
+	// the member being replaced. The tag is swapped for an index tag once the replacement passed the health gate.
+	ServerFleetPendingIndexTagPrefix = "fleet-pending-index="
+	// ServerFleetRetiredIndexTagPrefix is the tag of a member whose replacement passed the health gate, it is set
+	// before the replacement is promoted so an interrupted swap is finished by the next update instead of undone.
+	ServerFleetRetiredIndexTagPrefix = "fleet-retired-index="
+)
+
+// ServerFleetMemberState tells whether a server of a fleet is a member, a replacement or a replaced member
+type ServerFleetMemberState int
+
+const (
+	ServerFleetMemberActive ServerFleetMemberState = iota
+	ServerFleetMemberPending
+	ServerFleetMemberRetired
+)
+
+// serverFleetTemplate holds everything needed to create one member of a fleet
+type serverFleetTemplate struct {
+	FleetID           string
+	Name              string
+	Zone              scw.Zone
+	Project           *string
+	CommercialType    string
+	ImageID           string
+	Tags              []string
+	SecurityGroupID   *string
+	PlacementGroupID  *string
+	RootVolume        map[string]any
+	EnableDynamicIP   bool
+	CloudInit         string
+	PrivateNetworkIDs []string
+	ServerType        *instanceSDK.ServerType
+}
+
+// serverFleetMember is a server belonging to a fleet, ordered by Index
+type serverFleetMember struct {
+	Index  int
+	Server *instanceSDK.Server
+	// State is pending on replacements and retired on replaced members whose rolling update did not complete
+	State ServerFleetMemberState
+}
+
+// ServerFleetTag returns the tag identifying the members of the fleet fleetID
+func ServerFleetTag(fleetID string) string {
+	return ServerFleetTagPrefix + fleetID
+}
+
+// ServerFleetMemberName returns the name of the member at the given index
+func ServerFleetMemberName(fleetName string, index int) string {
+	return fmt.Sprintf("%s-%d", fleetName, index)
+}
+
+// ServerFleetMemberTags returns the tags to set on a fleet member, user tags first
+func ServerFleetMemberTags(userTags []string, fleetID string, index int) []string {
+	tags := make([]string, 0, len(userTags)+2)
+	tags = append(tags, userTags...)
+
+	return append(tags, ServerFleetTag(fleetID), ServerFleetIndexTagPrefix+strconv.Itoa(index))
+}
+
+// ServerFleetPendingMemberTags returns the tags to set on the replacement of the member at the given index
+func ServerFleetPendingMemberTags(userTags []string, fleetID string, index int) []string {
+	tags := make([]string, 0, len(userTags)+2)
+	tags = append(tags, userTags...)
+
+	return append(tags, ServerFleetTag(fleetID), ServerFleetPendingIndexTagPrefix+strconv.Itoa(index))
+}
+
+// ServerFleetPendingMemberName returns the name of the replacement of the member at the given index
+func ServerFleetPendingMemberName(fleetName string, index int) string {
+	return ServerFleetMemberName(fleetName, index) + "-pending"
+}
+
+// ServerFleetRetiredMemberTags returns the tags to set on the member at the given index once its replacement passed the health gate
+func ServerFleetRetiredMemberTags(userTags []string, fleetID string, index int) []string {
+	tags := make([]string, 0, len(userTags)+2)
+	tags = append(tags, userTags...)
+
+	return append(tags, ServerFleetTag(fleetID), ServerFleetRetiredIndexTagPrefix+strconv.Itoa(index))
+}
+
+// ServerFleetRetiredMemberName returns the name of the member at the given index once its replacement passed the health gate
+func ServerFleetRetiredMemberName(fleetName string, index int) string {
+	return ServerFleetMemberName(fleetName, index) + "-retired"
+}
+
+// SplitServerFleetTags separates user tags from the fleet bookkeeping tags and returns the member index and the
+// state of the server in the fleet. The index is -1 when the server does not carry an index tag.
+func SplitServerFleetTags(tags []string) ([]string, int, ServerFleetMemberState) {
+	userTags := []string(nil)
+	index := -1
+	state := ServerFleetMemberActive
+
+	for _, tag := range tags {
+		switch {
+		case strings.HasPrefix(tag, ServerFleetIndexTagPrefix):
+			i, err := strconv.Atoi(strings.TrimPrefix(tag, ServerFleetIndexTagPrefix))
+			if err == nil {
+				index = i
+			}
+		case strings.HasPrefix(tag, ServerFleetPendingIndexTagPrefix):
+			i, err := strconv.Atoi(strings.TrimPrefix(tag, ServerFleetPendingIndexTagPrefix))
+			if err == nil {
+				index = i
+				state = ServerFleetMemberPending
+			}
+		case strings.HasPrefix(tag, ServerFleetRetiredIndexTagPrefix):
+			i, err := strconv.Atoi(strings.TrimPrefix(tag, ServerFleetRetiredIndexTagPrefix))
+			if err == nil {
+				index = i
+				state = ServerFleetMemberRetired
+			}
+		case strings.HasPrefix(tag, ServerFleetTagPrefix):
+		default:
+			userTags = append(userTags, tag)
+		}
+	}
+
+	return userTags, index, state
+}
+
+// ServerFleetNameFromMember returns the fleet name from the name of the member at the given index,
+// the name of a replacement is accepted as well
+func ServerFleetNameFromMember(memberName string, index int) string {
+	return strings.TrimSuffix(strings.TrimSuffix(memberName, "-pending"), fmt.Sprintf("-%d", index))
+}
+
+// SplitServerFleetBatches splits items in consecutive batches of at most batchSize elements
+func SplitServerFleetBatches[T any](items []T, batchSize int) [][]T {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	batches := make([][]T, 0, (len(items)+batchSize-1)/batchSize)
+
+	for start := 0; start < len(items); start += batchSize {
+		end := min(start+batchSize, len(items))
+		batches = append(batches, items[start:end])
+	}
+
+	return batches
+}
+
+// expandServerFleetTemplate builds the member template from the resource data, or from the autoscaling instance
+// template referenced by instance_template_id. The image label is resolved to a UUID once so every member boots the same image.
+func expandServerFleetTemplate(ctx context.Context, d *schema.ResourceData, m any, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, fleetID string) (*serverFleetTemplate, error) {
+	tpl := &serverFleetTemplate{
+		FleetID:           fleetID,
+		Name:              d.Get("name").(string),
+		Zone:              zone,
+		Project:           types.ExpandStringPtr(d.Get("project_id")),
+		CommercialType:    d.Get("type").(string),
+		Tags:              types.ExpandStrings(d.Get("tags")),
+		EnableDynamicIP:   d.Get("enable_dynamic_ip").(bool),
+		CloudInit:         d.Get("cloud_init").(string),
+		PrivateNetworkIDs: types.ExpandStrings(d.Get("private_network_ids")),
+		RootVolume: map[string]any{
+			"volume_type": d.Get("root_volume_type").(string),
+			"size_in_gb":  d.Get("root_volume_size_in_gb").(int),
+		},
+	}
+
+	if securityGroupID, ok := d.GetOk("security_group_id"); ok {
+		tpl.SecurityGroupID = types.ExpandStringPtr(zonal.ExpandID(securityGroupID).ID)
+	}
+
+	if placementGroupID, ok := d.GetOk("placement_group_id"); ok {
+		tpl.PlacementGroupID = types.ExpandStringPtr(zonal.ExpandID(placementGroupID).ID)
+	}
+
+	imageUUID := locality.ExpandID(d.Get("image"))
+
+	if instanceTemplateID, ok := d.GetOk("instance_template_id"); ok {
+		var err error
+
+		imageUUID, err = expandServerFleetInstanceTemplate(ctx, m, tpl, zonal.ExpandID(instanceTemplateID).ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tpl.ServerType = getServerType(ctx, api.API, zone, tpl.CommercialType)
+	if tpl.ServerType == nil {
+		return nil, fmt.Errorf("could not find a server type associated with %s in zone %s", tpl.CommercialType, zone)
+	}
+
+	if !scwvalidation.IsUUID(imageUUID) {
+		rootVolume := prepareRootVolume(tpl.RootVolume, tpl.ServerType, imageUUID).VolumeTemplate()
+
+		image, err := marketplace.NewAPI(meta.ExtractScwClient(m)).GetLocalImageByLabel(&marketplace.GetLocalImageByLabelRequest{
+			CommercialType: tpl.CommercialType,
+			Zone:           zone,
+			ImageLabel:     formatImageLabel(imageUUID),
+			Type:           volumeTypeToMarketplaceFilter(rootVolume.VolumeType),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("could not get image '%s': %w", zonal.NewID(zone, imageUUID), err)
+		}
+
+		imageUUID = image.ID
+	}
+
+	tpl.ImageID = imageUUID
+
+	return tpl, nil
+}
+
+// expandServerFleetInstanceTemplate copies the specification of an autoscaling instance template in the member
+// template and returns its image. Only the boot volume of the instance template is used and its tags are not applied.
+func expandServerFleetInstanceTemplate(ctx context.Context, m any, tpl *serverFleetTemplate, instanceTemplateID string) (string, error) {
+	instanceTemplate, err := autoscaling.NewAPI(meta.ExtractScwClient(m)).GetInstanceTemplate(&autoscaling.GetInstanceTemplateRequest{
+		Zone:       tpl.Zone,
+		TemplateID: instanceTemplateID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("could not get instance template %s: %w", zonal.NewIDString(tpl.Zone, instanceTemplateID), err)
+	}
+
+	if instanceTemplate.ImageID == nil {
+		return "", fmt.Errorf("instance template %s has no image_id, it cannot be used by a server fleet", instanceTemplate.Name)
+	}
+
+	if len(instanceTemplate.Volumes) > 1 {
+		return "", fmt.Errorf("instance template %s has additional volumes, only templates with a single boot volume can be used by a server fleet", instanceTemplate.Name)
+	}
+
+	tpl.CommercialType = instanceTemplate.CommercialType
+	tpl.SecurityGroupID = instanceTemplate.SecurityGroupID
+	tpl.PlacementGroupID = instanceTemplate.PlacementGroupID
+	tpl.PrivateNetworkIDs = instanceTemplate.PrivateNetworkIDs
+	tpl.EnableDynamicIP = instanceTemplate.PublicIPsV4Count != nil && *instanceTemplate.PublicIPsV4Count > 0
+	tpl.CloudInit = ""
+	tpl.RootVolume = map[string]any{}
+
+	if instanceTemplate.CloudInit != nil {
+		tpl.CloudInit = string(*instanceTemplate.CloudInit)
+	}
+
+	for _, volume := range instanceTemplate.Volumes {
+		tpl.RootVolume["volume_type"] = instanceSDK.VolumeVolumeTypeLSSD.String()
+		if volume.VolumeType.String() != instanceSDK.VolumeVolumeTypeLSSD.String() {
+			tpl.RootVolume["volume_type"] = instanceSDK.VolumeVolumeTypeSbsVolume.String()
+		}
+
+		switch {
+		case volume.FromEmpty != nil:
+			tpl.RootVolume["size_in_gb"] = int(uint64(volume.FromEmpty.Size) / gb)
+		case volume.FromSnapshot != nil && volume.FromSnapshot.Size != nil:
+			tpl.RootVolume["size_in_gb"] = int(uint64(*volume.FromSnapshot.Size) / gb)
+		}
+	}
+
+	return *instanceTemplate.ImageID, nil
+}
+
+// resolveServerFleetMembers sorts out the servers left by an interrupted rolling update. It returns the members of
+// the fleet, the replacements among them which still need to be promoted and the servers to delete.
+// A replacement is a member once the member it replaces was retired, the swap was committed after the health gate.
+// The other replacements did not pass the gate and the retired members were already replaced, both are deleted.
+func resolveServerFleetMembers(servers []*serverFleetMember) ([]*serverFleetMember, []*serverFleetMember, []*serverFleetMember) {
+	activeIndexes := map[int]bool{}
+	retiredIndexes := map[int]bool{}
+
+	for _, server := range servers {
+		switch server.State {
+		case ServerFleetMemberActive:
+			activeIndexes[server.Index] = true
+		case ServerFleetMemberRetired:
+			retiredIndexes[server.Index] = true
+		}
+	}
+
+	members := make([]*serverFleetMember, 0, len(servers))
+	toPromote := []*serverFleetMember(nil)
+	toDelete := []*serverFleetMember(nil)
+
+	for _, server := range servers {
+		switch server.State {
+		case ServerFleetMemberActive:
+			members = append(members, server)
+		case ServerFleetMemberPending:
+			if retiredIndexes[server.Index] && !activeIndexes[server.Index] {
+				activeIndexes[server.Index] = true
+				members = append(members, server)
+				toPromote = append(toPromote, server)
+			} else {
+				toDelete = append(toDelete, server)
+			}
+		case ServerFleetMemberRetired:
+			toDelete = append(toDelete, server)
+		}
+	}
+
+	return members, toPromote, toDelete
+}
+
+// listServerFleetMembers returns the servers of a fleet ordered by index, replacements and retired members included
+func listServerFleetMembers(ctx context.Context, api *instanceSDK.API, zone scw.Zone, fleetID string) ([]*serverFleetMember, error) {
+	res, err := api.ListServers(&instanceSDK.ListServersRequest{
+		Zone: zone,
+		Tags: []string{ServerFleetTag(fleetID)},
+	}, scw.WithContext(ctx), scw.WithAllPages())
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*serverFleetMember, 0, len(res.Servers))
+
+	for _, server := range res.Servers {
+		_, index, state := SplitServerFleetTags(server.Tags)
+		members = append(members, &serverFleetMember{
+			Index:  index,
+			Server: server,
+			State:  state,
+		})
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].Index < members[j].Index
+	})
+
+	return members, nil
+}
+
+// createServerFleetMember creates one member from the template and waits for it to be running.
+// The server is returned along with the error when it was created but could not be set up, so it can be cleaned up.
+// A pending member is the replacement of the member at the same index during a rolling update.
+func createServerFleetMember(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, tpl *serverFleetTemplate, index int, pending bool, timeout time.Duration) (*instanceSDK.Server, error) {
+	req := &instanceSDK.CreateServerRequest{
+		Zone:              tpl.Zone,
+		Name:              ServerFleetMemberName(tpl.Name, index),
+		Project:           tpl.Project,
+		CommercialType:    tpl.CommercialType,
+		SecurityGroup:     tpl.SecurityGroupID,
+		PlacementGroup:    tpl.PlacementGroupID,
+		DynamicIPRequired: new(tpl.EnableDynamicIP),
+		Tags:              ServerFleetMemberTags(tpl.Tags, tpl.FleetID, index),
+		Image:             new(tpl.ImageID),
+		Volumes: map[string]*instanceSDK.VolumeServerTemplate{
+			"0": prepareRootVolume(tpl.RootVolume, tpl.ServerType, tpl.ImageID).VolumeTemplate(),
+		},
+	}
+
+	if pending {
+		req.Name = ServerFleetPendingMemberName(tpl.Name, index)
+		req.Tags = ServerFleetPendingMemberTags(tpl.Tags, tpl.FleetID, index)
+	}
+
+	res, err := api.CreateServer(req, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fleet member %s: %w", req.Name, err)
+	}
+
+	serverID := res.Server.ID
+
+	_, err = waitForServer(ctx, api.API, tpl.Zone, serverID, timeout)
+	if err != nil {
+		return res.Server, err
+	}
+
+	if tpl.CloudInit != "" {
+		err = api.SetServerUserData(&instanceSDK.SetServerUserDataRequest{
+			Zone:     tpl.Zone,
+			ServerID: serverID,
+			Key:      "cloud-init",
+			Content:  bytes.NewBufferString(tpl.CloudInit),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return res.Server, err
+		}
+	}
+
+	for _, privateNetworkID := range tpl.PrivateNetworkIDs {
+		pn, err := api.CreatePrivateNIC(&instanceSDK.CreatePrivateNICRequest{
+			Zone:             tpl.Zone,
+			ServerID:         serverID,
+			PrivateNetworkID: locality.ExpandID(privateNetworkID),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return res.Server, err
+		}
+
+		_, err = waitForPrivateNIC(ctx, api.API, tpl.Zone, serverID, pn.PrivateNic.ID, timeout)
+		if err != nil {
+			return res.Server, err
+		}
+	}
+
+	err = reachState(ctx, api, tpl.Zone, serverID, instanceSDK.ServerStateRunning)
+	if err != nil {
+		return res.Server, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("fleet member %s created (ID: %s)", req.Name, serverID))
+
+	server, err := waitForServer(ctx, api.API, tpl.Zone, serverID, timeout)
+	if err != nil {
+		return res.Server, err
+	}
+
+	return server, nil
+}
+
+// checkServerFleetMemberHealth waits for the grace period then ensures the member is still running
+func checkServerFleetMemberHealth(ctx context.Context, api *instanceSDK.API, zone scw.Zone, serverID string, gracePeriod time.Duration) error {
+	if gracePeriod > 0 {
+		select {
+		case <-time.After(gracePeriod):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	res, err := api.GetServer(&instanceSDK.GetServerRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if res.Server.State != instanceSDK.ServerStateRunning {
+		return fmt.Errorf("fleet member %s (ID: %s) failed health check: state is %s", res.Server.Name, serverID, res.Server.State)
+	}
+
+	return nil
+}
+
+// deleteServerFleetMember terminates a member and removes its root volume
+func deleteServerFleetMember(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, zone scw.Zone, server *instanceSDK.Server, timeout time.Duration) error {
+	rootVolumeID := ""
+	if rootVolume, ok := server.Volumes["0"]; ok && rootVolume != nil {
+		rootVolumeID = rootVolume.ID
+	}
+
+	err := terminateServer(ctx, api, zone, server.ID, timeout)
+	if err != nil {
+		err = deleteServer(ctx, api, zone, server.ID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Terminating a server keeps SBS volumes, the root volume is deleted in both cases like in ResourceInstanceServerDelete
+	if rootVolumeID == "" {
+		return nil
+	}
+
+	err = api.DeleteUnknownVolume(&instancehelpers.DeleteUnknownVolumeRequest{
+		Zone:     zone,
+		VolumeID: rootVolumeID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return err
+	}
+
+	return nil
+}
+
+// runServerFleetTasks runs tasks through a bounded worker pool and merges their errors
+func runServerFleetTasks(parallelism int, tasks []workerpool.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	pool := workerpool.NewWorkerPool(min(parallelism, len(tasks)))
+
+	for _, task := range tasks {
+		pool.AddTask(task)
+	}
+
+	if errs := pool.CloseAndWait(); len(errs) > 0 {
+		return multierror.Append(nil, errs...)
+	}
+
+	return nil
+}
+
+// rollServerFleetMembers replaces members batch by batch. A replacement is created as a pending member, it must pass
+// the health gate before the member it replaces is retired, then the replacement takes its index and the retired
+// member is deleted. A replacement failing the gate is deleted so the fleet never holds two members with the same
+// index. Once a member is retired the swap is committed: when the promotion or the deletion fails, the next update
+// finishes it with resolveServerFleetMembers. The roll stops on the first failing batch.
+func rollServerFleetMembers(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, tpl *serverFleetTemplate, members []*serverFleetMember, batchSize int, gracePeriod time.Duration, timeout time.Duration) error {
+	for batchIndex, batch := range SplitServerFleetBatches(members, batchSize) {
+		tasks := make([]workerpool.Task, 0, len(batch))
+
+		for _, member := range batch {
+			tasks = append(tasks, func() error {
+				replacement, err := createServerFleetMember(ctx, api, tpl, member.Index, true, timeout)
+				if replacement == nil {
+					return err
+				}
+
+				if err == nil {
+					err = checkServerFleetMemberHealth(ctx, api.API, tpl.Zone, replacement.ID, gracePeriod)
+				}
+
+				if err != nil {
+					if deleteErr := deleteServerFleetMember(ctx, api, tpl.Zone, replacement, timeout); deleteErr != nil {
+						return multierror.Append(err, deleteErr)
+					}
+
+					return err
+				}
+
+				err = retireServerFleetMember(ctx, api.API, tpl, member.Index, member.Server.ID)
+				if err != nil {
+					if deleteErr := deleteServerFleetMember(ctx, api, tpl.Zone, replacement, timeout); deleteErr != nil {
+						return multierror.Append(err, deleteErr)
+					}
+
+					return err
+				}
+
+				err = promoteServerFleetMember(ctx, api.API, tpl, member.Index, replacement.ID)
+				if err != nil {
+					return err
+				}
+
+				return deleteServerFleetMember(ctx, api, tpl.Zone, member.Server, timeout)
+			})
+		}
+
+		err := runServerFleetTasks(len(tasks), tasks)
+		if err != nil {
+			return fmt.Errorf("rolling update stopped at batch %d: %w", batchIndex+1, err)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("fleet %s: batch %d rolled (%d members)", tpl.FleetID, batchIndex+1, len(batch)))
+	}
+
+	return nil
+}
+
+// retireServerFleetMember marks the member at the given index as replaced, its replacement passed the health gate
+func retireServerFleetMember(ctx context.Context, api *instanceSDK.API, tpl *serverFleetTemplate, index int, serverID string) error {
+	_, err := api.UpdateServer(&instanceSDK.UpdateServerRequest{
+		Zone:     tpl.Zone,
+		ServerID: serverID,
+		Name:     new(ServerFleetRetiredMemberName(tpl.Name, index)),
+		Tags:     new(ServerFleetRetiredMemberTags(tpl.Tags, tpl.FleetID, index)),
+	}, scw.WithContext(ctx))
+
+	return err
+}
+
+// promoteServerFleetMember gives a replacement that passed the health gate the name and index of the member it replaced
+func promoteServerFleetMember(ctx context.Context, api *instanceSDK.API, tpl *serverFleetTemplate, index int, serverID string) error {
+	_, err := api.UpdateServer(&instanceSDK.UpdateServerRequest{
+		Zone:     tpl.Zone,
+		ServerID: serverID,
+		Name:     new(ServerFleetMemberName(tpl.Name, index)),
+		Tags:     new(ServerFleetMemberTags(tpl.Tags, tpl.FleetID, index)),
+	}, scw.WithContext(ctx))
+
+	return err
+}
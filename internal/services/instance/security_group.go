@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -31,8 +33,9 @@ func ResourceSecurityGroup() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupTimeout),
 		},
-		SchemaFunc: securityGroupSchema,
-		Identity:   identity.DefaultZonal(),
+		SchemaFunc:    securityGroupSchema,
+		Identity:      identity.DefaultZonal(),
+		CustomizeDiff: customDiffSecurityGroupRulesConflicts,
 	}
 }
 
@@ -90,6 +93,19 @@ func securityGroupSchema() map[string]*schema.Schema {
 			Default:       false,
 			ConflictsWith: []string{"inbound_rule", "outbound_rule"},
 		},
+		"ignore_external_rules": {
+			Type:          schema.TypeBool,
+			Description:   "Only manage the rules defined in this resource and keep the other rules of the security group",
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"external_rules"},
+		},
+		"reject_rule_conflicts": {
+			Type:        schema.TypeBool,
+			Description: "Fail the plan when an inline rule is a duplicate of an earlier rule or is shadowed by it, a warning is returned otherwise",
+			Optional:    true,
+			Default:     false,
+		},
 		"enable_default_security": {
 			Type:        schema.TypeBool,
 			Description: "Enable blocking of SMTP on IPv4 and IPv6",
@@ -233,6 +249,18 @@ func getSecurityGroupRules(ctx context.Context, instanceAPI *instanceSDK.API, zo
 		apiRules[apiRule.Direction] = append(apiRules[apiRule.Direction], apiRule)
 	}
 
+	// Rules owned by other resources are not part of the state.
+	if d.Get("ignore_external_rules").(bool) {
+		for direction := range apiRules {
+			wantedRules, err := expandSecurityGroupRules(stateRules[direction])
+			if err != nil {
+				return nil, nil, err
+			}
+
+			apiRules[direction], _ = MatchSecurityGroupRules(wantedRules, apiRules[direction])
+		}
+	}
+
 	// We make sure that we keep state rule if they match their api rule.
 	for direction := range apiRules {
 		for index, apiRule := range apiRules[direction] {
@@ -331,14 +359,63 @@ func ResourceInstanceSecurityGroupUpdate(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
+	var diags diag.Diagnostics
+
 	if !d.Get("external_rules").(bool) {
 		err = updateSecurityGroupeRules(ctx, d, zone, ID, instanceAPI)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		diags = securityGroupRulesConflictWarnings(d)
+	}
+
+	return append(diags, ResourceInstanceSecurityGroupRead(ctx, d, m)...)
+}
+
+// listExternalSecurityGroupRules returns the editable rules of the security group that were not in the previous state
+func listExternalSecurityGroupRules(ctx context.Context, d *schema.ResourceData, zone scw.Zone, securityGroupID string, instanceAPI *instanceSDK.API) ([]*instanceSDK.SecurityGroupRule, error) {
+	resRules, err := instanceAPI.ListSecurityGroupRules(&instanceSDK.ListSecurityGroupRulesRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(resRules.Rules, func(i, j int) bool {
+		return resRules.Rules[i].Position < resRules.Rules[j].Position
+	})
+
+	oldInbound, _ := d.GetChange("inbound_rule")
+	oldOutbound, _ := d.GetChange("outbound_rule")
+
+	oldRules := map[instanceSDK.SecurityGroupRuleDirection][]any{
+		instanceSDK.SecurityGroupRuleDirectionInbound:  oldInbound.([]any),
+		instanceSDK.SecurityGroupRuleDirectionOutbound: oldOutbound.([]any),
+	}
+
+	externalRules := []*instanceSDK.SecurityGroupRule(nil)
+
+	for _, direction := range []instanceSDK.SecurityGroupRuleDirection{instanceSDK.SecurityGroupRuleDirectionInbound, instanceSDK.SecurityGroupRuleDirectionOutbound} {
+		ownedRules, err := expandSecurityGroupRules(oldRules[direction])
+		if err != nil {
+			return nil, err
+		}
+
+		apiRules := []*instanceSDK.SecurityGroupRule(nil)
+
+		for _, apiRule := range resRules.Rules {
+			if apiRule.Editable && apiRule.Direction == direction {
+				apiRules = append(apiRules, apiRule)
+			}
+		}
+
+		_, unmatched := MatchSecurityGroupRules(ownedRules, apiRules)
+		externalRules = append(externalRules, unmatched...)
 	}
 
-	return ResourceInstanceSecurityGroupRead(ctx, d, m)
+	return externalRules, nil
 }
 
 // updateSecurityGroupeRules handles updating SecurityGroupRules
@@ -371,6 +448,27 @@ func updateSecurityGroupeRules(ctx context.Context, d *schema.ResourceData, zone
 		}
 	}
 
+	if d.Get("ignore_external_rules").(bool) {
+		externalRules, err := listExternalSecurityGroupRules(ctx, d, zone, securityGroupID, instanceAPI)
+		if err != nil {
+			return err
+		}
+
+		for _, rule := range externalRules {
+			setGroupRules = append(setGroupRules, &instanceSDK.SetSecurityGroupRulesRequestRule{
+				ID:           &rule.ID,
+				Zone:         &zone,
+				Protocol:     rule.Protocol,
+				IPRange:      rule.IPRange,
+				Action:       rule.Action,
+				DestPortTo:   rule.DestPortTo,
+				DestPortFrom: rule.DestPortFrom,
+				Direction:    rule.Direction,
+				Editable:     new(true),
+			})
+		}
+	}
+
 	_, err := instanceAPI.SetSecurityGroupRules(&instanceSDK.SetSecurityGroupRulesRequest{
 		SecurityGroupID: securityGroupID,
 		Zone:            zone,
@@ -577,3 +675,185 @@ func SecurityGroupRuleEquals(ruleA, ruleB *instanceSDK.SecurityGroupRule) (bool,
 		ipEqual &&
 		ruleA.Protocol == ruleB.Protocol, nil
 }
+
+// expandSecurityGroupRules transforms a list of state rules to api ones.
+func expandSecurityGroupRules(rawRules []any) ([]*instanceSDK.SecurityGroupRule, error) {
+	rules := make([]*instanceSDK.SecurityGroupRule, 0, len(rawRules))
+
+	for _, rawRule := range rawRules {
+		rule, err := securityGroupRuleExpand(rawRule)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// MatchSecurityGroupRules pairs every wanted rule with the first unused equal api rule.
+// It returns the matched api rules in the order of wanted and the api rules left unmatched.
+func MatchSecurityGroupRules(wanted []*instanceSDK.SecurityGroupRule, apiRules []*instanceSDK.SecurityGroupRule) ([]*instanceSDK.SecurityGroupRule, []*instanceSDK.SecurityGroupRule) {
+	used := make([]bool, len(apiRules))
+	matched := []*instanceSDK.SecurityGroupRule(nil)
+
+	for _, wantedRule := range wanted {
+		for i, apiRule := range apiRules {
+			if used[i] {
+				continue
+			}
+
+			if ok, _ := SecurityGroupRuleEquals(wantedRule, apiRule); ok {
+				used[i] = true
+				matched = append(matched, apiRule)
+
+				break
+			}
+		}
+	}
+
+	unmatched := []*instanceSDK.SecurityGroupRule(nil)
+
+	for i, apiRule := range apiRules {
+		if !used[i] {
+			unmatched = append(unmatched, apiRule)
+		}
+	}
+
+	return matched, unmatched
+}
+
+// securityGroupRulePorts returns the port range matched by a rule, a rule without port matches every port.
+func securityGroupRulePorts(rule *instanceSDK.SecurityGroupRule) (uint32, uint32) {
+	if rule.DestPortFrom == nil || *rule.DestPortFrom == 0 {
+		return 0, 65535
+	}
+
+	if rule.DestPortTo == nil || *rule.DestPortTo == 0 {
+		return *rule.DestPortFrom, *rule.DestPortFrom
+	}
+
+	return *rule.DestPortFrom, *rule.DestPortTo
+}
+
+// SecurityGroupRuleCovers returns true when every packet matched by ruleB is also matched by ruleA.
+func SecurityGroupRuleCovers(ruleA, ruleB *instanceSDK.SecurityGroupRule) bool {
+	if ruleA.Protocol != instanceSDK.SecurityGroupRuleProtocolANY && ruleA.Protocol != ruleB.Protocol {
+		return false
+	}
+
+	if ruleA.Protocol != instanceSDK.SecurityGroupRuleProtocolICMP {
+		fromA, toA := securityGroupRulePorts(ruleA)
+		fromB, toB := securityGroupRulePorts(ruleB)
+
+		if fromB < fromA || toB > toA {
+			return false
+		}
+	}
+
+	onesA, bitsA := ruleA.IPRange.Mask.Size()
+	onesB, bitsB := ruleB.IPRange.Mask.Size()
+
+	return bitsA == bitsB && onesA <= onesB && ruleA.IPRange.Contains(ruleB.IPRange.IP)
+}
+
+// SecurityGroupRuleConflict describes a rule that can never match or is defined twice.
+type SecurityGroupRuleConflict struct {
+	Direction instanceSDK.SecurityGroupRuleDirection
+	// Index of the conflicting rule in its direction
+	Index int
+	// ShadowedBy is the index of the earlier rule that hides the conflicting rule
+	ShadowedBy int
+	Duplicate  bool
+}
+
+func (c SecurityGroupRuleConflict) Error() string {
+	if c.Duplicate {
+		return fmt.Sprintf("%s rule #%d is a duplicate of %s rule #%d", c.Direction, c.Index, c.Direction, c.ShadowedBy)
+	}
+
+	return fmt.Sprintf("%s rule #%d is shadowed by %s rule #%d with a different action and will never match", c.Direction, c.Index, c.Direction, c.ShadowedBy)
+}
+
+// FindSecurityGroupRuleConflicts returns duplicated rules and rules shadowed by an earlier rule with a different action.
+// Rules are evaluated in order, the first matching rule wins.
+func FindSecurityGroupRuleConflicts(direction instanceSDK.SecurityGroupRuleDirection, rules []*instanceSDK.SecurityGroupRule) []SecurityGroupRuleConflict {
+	conflicts := []SecurityGroupRuleConflict(nil)
+
+	for i, rule := range rules {
+		for j := range i {
+			if ok, _ := SecurityGroupRuleEquals(rules[j], rule); ok {
+				conflicts = append(conflicts, SecurityGroupRuleConflict{Direction: direction, Index: i, ShadowedBy: j, Duplicate: true})
+
+				break
+			}
+
+			if rules[j].Action != rule.Action && SecurityGroupRuleCovers(rules[j], rule) {
+				conflicts = append(conflicts, SecurityGroupRuleConflict{Direction: direction, Index: i, ShadowedBy: j})
+
+				break
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// securityGroupRulesConflictWarnings reports duplicated and shadowed inline rules once they are applied.
+func securityGroupRulesConflictWarnings(d *schema.ResourceData) diag.Diagnostics {
+	if !d.HasChanges("inbound_rule", "outbound_rule") {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+
+	for _, direction := range []instanceSDK.SecurityGroupRuleDirection{instanceSDK.SecurityGroupRuleDirectionInbound, instanceSDK.SecurityGroupRuleDirectionOutbound} {
+		key := direction.String() + "_rule"
+
+		rules, err := expandSecurityGroupRules(d.Get(key).([]any))
+		if err != nil {
+			continue
+		}
+
+		for _, conflict := range FindSecurityGroupRuleConflicts(direction, rules) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       conflict.Error(),
+				Detail:        "Rules are evaluated in order and the first matching rule wins. Set reject_rule_conflicts to fail the plan instead.",
+				AttributePath: cty.GetAttrPath(key).IndexInt(conflict.Index),
+			})
+		}
+	}
+
+	return diags
+}
+
+// customDiffSecurityGroupRulesConflicts rejects duplicated and shadowed inline rules at plan time when
+// reject_rule_conflicts is set, they are reported as warnings on apply otherwise.
+func customDiffSecurityGroupRulesConflicts(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if !diff.Get("reject_rule_conflicts").(bool) || !diff.HasChanges("inbound_rule", "outbound_rule") {
+		return nil
+	}
+
+	var errs *multierror.Error
+
+	for _, direction := range []instanceSDK.SecurityGroupRuleDirection{instanceSDK.SecurityGroupRuleDirectionInbound, instanceSDK.SecurityGroupRuleDirectionOutbound} {
+		key := direction.String() + "_rule"
+		if !diff.NewValueKnown(key) {
+			continue
+		}
+
+		rules, err := expandSecurityGroupRules(diff.Get(key).([]any))
+		if err != nil {
+			// Invalid ip ranges are reported by the schema validation
+			continue
+		}
+
+		for _, conflict := range FindSecurityGroupRuleConflicts(direction, rules) {
+			errs = multierror.Append(errs, conflict)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
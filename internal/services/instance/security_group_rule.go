@@ -0,0 +1,375 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func ResourceSecurityGroupRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceInstanceSecurityGroupRuleCreate,
+		ReadContext:   ResourceInstanceSecurityGroupRuleRead,
+		UpdateContext: ResourceInstanceSecurityGroupRuleUpdate,
+		DeleteContext: ResourceInstanceSecurityGroupRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupRuleTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    securityGroupRuleResourceSchema,
+		CustomizeDiff: customDiffSecurityGroupRuleConflicts,
+		Identity:      identity.DefaultZonal(),
+	}
+}
+
+func securityGroupRuleResourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"security_group_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "The security group the rule belongs to",
+			ValidateDiagFunc: verify.IsUUIDWithLocality(),
+		},
+		"direction": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.ValidateEnum[instanceSDK.SecurityGroupRuleDirection](),
+			Description:      "Direction of the traffic matched by the rule (inbound or outbound)",
+		},
+		"action": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.ValidateEnum[instanceSDK.SecurityGroupRuleAction](),
+			Description:      "Action when rule match request (drop or accept)",
+		},
+		"protocol": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			Default:          instanceSDK.SecurityGroupRuleProtocolTCP.String(),
+			ValidateDiagFunc: verify.ValidateEnum[instanceSDK.SecurityGroupRuleProtocol](),
+			Description:      "Protocol for this rule (TCP, UDP, ICMP or ANY)",
+		},
+		"port": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			ForceNew:      true,
+			ValidateFunc:  validation.IsPortNumber,
+			ConflictsWith: []string{"port_range"},
+			Description:   "Network port for this rule",
+		},
+		"port_range": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"port"},
+			Description:   "Port range for this rule (e.g: 1-1024, 22-22)",
+		},
+		"ip_range": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "0.0.0.0/0",
+			ValidateFunc: validation.IsCIDRNetwork(0, 128),
+			Description:  "Ip range for this rule (e.g: 192.168.1.0/24)",
+		},
+		"reject_rule_conflicts": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Fail the plan when the rule is a duplicate of an earlier rule of the security group or is shadowed by it",
+		},
+		"conflicts": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The conflicts of the rule with the earlier rules of the security group, checked on every plan",
+		},
+		"position": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The position of the rule in the security group, rules are evaluated in order",
+		},
+		"rule_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the rule in the security group",
+		},
+		"zone": zonal.Schema(),
+	}
+}
+
+// securityGroupRuleResourceExpand reuses the inline rule normalisation of scaleway_instance_security_group
+func securityGroupRuleResourceExpand(get func(string) any) (*instanceSDK.SecurityGroupRule, error) {
+	rule, err := securityGroupRuleExpand(map[string]any{
+		"action":     get("action"),
+		"protocol":   get("protocol"),
+		"port":       get("port"),
+		"port_range": get("port_range"),
+		"ip":         "",
+		"ip_range":   get("ip_range"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Direction = instanceSDK.SecurityGroupRuleDirection(get("direction").(string))
+
+	return rule, nil
+}
+
+func ResourceInstanceSecurityGroupRuleCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, securityGroupID, err := NewAPIWithZoneAndID(m, d.Get("security_group_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err := securityGroupRuleResourceExpand(d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+
+	conflicts, err := listSecurityGroupRuleConflicts(ctx, instanceAPI, zone, securityGroupID, rule, "")
+	if err == nil {
+		for _, conflict := range conflicts {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  conflict,
+				Detail:   "Rules are evaluated in order and the first matching rule wins, the rule is appended at the end of the security group.",
+			})
+		}
+	}
+
+	res, err := instanceAPI.CreateSecurityGroupRule(&instanceSDK.CreateSecurityGroupRuleRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+		Protocol:        rule.Protocol,
+		Direction:       rule.Direction,
+		Action:          rule.Action,
+		IPRange:         rule.IPRange,
+		DestPortFrom:    rule.DestPortFrom,
+		DestPortTo:      rule.DestPortTo,
+		Editable:        new(true),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, fmt.Sprintf("%s/%s", securityGroupID, res.Rule.ID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(diags, ResourceInstanceSecurityGroupRuleRead(ctx, d, m)...)
+}
+
+func ResourceInstanceSecurityGroupRuleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, securityGroupID, ruleID, err := NewAPIWithZoneAndNestedID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetSecurityGroupRule(&instanceSDK.GetSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	rawRule, err := securityGroupRuleFlatten(res.Rule, "ip_range")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("security_group_id", zonal.NewIDString(zone, securityGroupID))
+	_ = d.Set("direction", res.Rule.Direction.String())
+	_ = d.Set("action", rawRule["action"])
+	_ = d.Set("protocol", rawRule["protocol"])
+	_ = d.Set("ip_range", rawRule["ip_range"])
+	_ = d.Set("position", int(res.Rule.Position))
+	_ = d.Set("rule_id", res.Rule.ID)
+	_ = d.Set("zone", zone.String())
+
+	// Keep the port notation used in the configuration, "22-22" and 22 are the same rule for the API.
+	if port, ok := rawRule["port"]; ok && d.Get("port_range").(string) == "" {
+		_ = d.Set("port", int(port.(uint32)))
+	} else if port, ok := rawRule["port"]; ok {
+		_ = d.Set("port_range", fmt.Sprintf("%d-%d", port.(uint32), port.(uint32)))
+	} else if portRange, ok := rawRule["port_range"]; ok {
+		_ = d.Set("port_range", portRange)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, fmt.Sprintf("%s/%s", securityGroupID, res.Rule.ID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func ResourceInstanceSecurityGroupRuleDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	instanceAPI, zone, securityGroupID, ruleID, err := NewAPIWithZoneAndNestedID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = instanceAPI.DeleteSecurityGroupRule(&instanceSDK.DeleteSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// ResourceInstanceSecurityGroupRuleUpdate only stores the arguments which are not sent to the API, changing the rule replaces it
+func ResourceInstanceSecurityGroupRuleUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	return ResourceInstanceSecurityGroupRuleRead(ctx, d, m)
+}
+
+// FindSecurityGroupRuleConflictsInGroup returns the rules of a security group which duplicate the rule or shadow it
+// with a different action. The rule is compared with the rules ahead of it in the group, or with all of them when
+// ruleID is empty as new rules are appended at the end. Rules which are not editable or in another direction are ignored.
+func FindSecurityGroupRuleConflictsInGroup(groupRules []*instanceSDK.SecurityGroupRule, rule *instanceSDK.SecurityGroupRule, ruleID string) []*instanceSDK.SecurityGroupRule {
+	var ownRule *instanceSDK.SecurityGroupRule
+
+	for _, groupRule := range groupRules {
+		if ruleID != "" && groupRule.ID == ruleID {
+			ownRule = groupRule
+		}
+	}
+
+	earlierRules := []*instanceSDK.SecurityGroupRule(nil)
+
+	for _, groupRule := range groupRules {
+		if !groupRule.Editable || groupRule.Direction != rule.Direction || groupRule.ID == ruleID {
+			continue
+		}
+
+		if ownRule != nil && groupRule.Position >= ownRule.Position {
+			continue
+		}
+
+		earlierRules = append(earlierRules, groupRule)
+	}
+
+	sort.Slice(earlierRules, func(i, j int) bool {
+		return earlierRules[i].Position < earlierRules[j].Position
+	})
+
+	conflicts := []*instanceSDK.SecurityGroupRule(nil)
+
+	for _, earlierRule := range earlierRules {
+		if ok, _ := SecurityGroupRuleEquals(earlierRule, rule); ok {
+			conflicts = append(conflicts, earlierRule)
+		} else if earlierRule.Action != rule.Action && SecurityGroupRuleCovers(earlierRule, rule) {
+			conflicts = append(conflicts, earlierRule)
+		}
+	}
+
+	return conflicts
+}
+
+// listSecurityGroupRuleConflicts lists the rules of the security group and describes the ones conflicting with the rule
+func listSecurityGroupRuleConflicts(ctx context.Context, instanceAPI *instanceSDK.API, zone scw.Zone, securityGroupID string, rule *instanceSDK.SecurityGroupRule, ruleID string) ([]string, error) {
+	res, err := instanceAPI.ListSecurityGroupRules(&instanceSDK.ListSecurityGroupRulesRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []string(nil)
+
+	for _, conflictingRule := range FindSecurityGroupRuleConflictsInGroup(res.Rules, rule, ruleID) {
+		if ok, _ := SecurityGroupRuleEquals(conflictingRule, rule); ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s rule duplicates rule %s of security group %s", rule.Direction, conflictingRule.ID, securityGroupID))
+		} else {
+			conflicts = append(conflicts, fmt.Sprintf("%s rule is shadowed by rule %s of security group %s (position %d) and will never match", rule.Direction, conflictingRule.ID, securityGroupID, conflictingRule.Position))
+		}
+	}
+
+	return conflicts, nil
+}
+
+// customDiffSecurityGroupRuleConflicts compares the rule with the rules of its security group on every plan, so a
+// conflict introduced by another rule is reported as well. The conflicts are planned in the conflicts attribute,
+// the plan fails instead when reject_rule_conflicts is set.
+func customDiffSecurityGroupRuleConflicts(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	for _, key := range []string{"security_group_id", "direction", "action", "protocol", "port", "port_range", "ip_range"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	instanceAPI, zone, securityGroupID, err := NewAPIWithZoneAndID(m, diff.Get("security_group_id").(string))
+	if err != nil {
+		return err
+	}
+
+	rule, err := securityGroupRuleResourceExpand(diff.Get)
+	if err != nil {
+		return nil //nolint:nilerr // invalid ports are reported on creation
+	}
+
+	// A new or replaced rule is appended at the end of the security group
+	replaced := diff.Id() == "" || diff.HasChanges("security_group_id", "direction", "action", "protocol", "port", "port_range", "ip_range")
+
+	ruleID := ""
+	if !replaced {
+		ruleID = diff.Get("rule_id").(string)
+	}
+
+	conflicts, err := listSecurityGroupRuleConflicts(ctx, instanceAPI, zone, securityGroupID, rule, ruleID)
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if len(conflicts) > 0 && diff.Get("reject_rule_conflicts").(bool) {
+		return errors.New(strings.Join(conflicts, "\n"))
+	}
+
+	if !replaced && slices.Equal(conflicts, types.ExpandStrings(diff.Get("conflicts"))) {
+		return nil
+	}
+
+	return diff.SetNew("conflicts", conflicts)
+}
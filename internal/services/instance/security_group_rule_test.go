@@ -0,0 +1,289 @@
+package instance_test
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSecurityGroupRule(t *testing.T, id string, action instanceSDK.SecurityGroupRuleAction, protocol instanceSDK.SecurityGroupRuleProtocol, ipRange string, ports ...uint32) *instanceSDK.SecurityGroupRule {
+	t.Helper()
+
+	_, ipNet, err := net.ParseCIDR(ipRange)
+	require.NoError(t, err)
+
+	rule := &instanceSDK.SecurityGroupRule{
+		ID:       id,
+		Action:   action,
+		Protocol: protocol,
+		IPRange:  scw.IPNet{IPNet: *ipNet},
+	}
+
+	if len(ports) > 0 {
+		rule.DestPortFrom = &ports[0]
+	}
+
+	if len(ports) > 1 {
+		rule.DestPortTo = &ports[1]
+	}
+
+	return rule
+}
+
+func TestMatchSecurityGroupRules(t *testing.T) {
+	accept := instanceSDK.SecurityGroupRuleActionAccept
+	tcp := instanceSDK.SecurityGroupRuleProtocolTCP
+
+	ssh := testSecurityGroupRule(t, "", accept, tcp, "0.0.0.0/0", 22)
+	https := testSecurityGroupRule(t, "", accept, tcp, "0.0.0.0/0", 443)
+
+	apiSSH := testSecurityGroupRule(t, "ssh", accept, tcp, "0.0.0.0/0", 22)
+	apiHTTPS := testSecurityGroupRule(t, "https", accept, tcp, "0.0.0.0/0", 443)
+	apiExternal := testSecurityGroupRule(t, "external", accept, tcp, "10.0.0.0/8", 8080)
+
+	matched, unmatched := instance.MatchSecurityGroupRules(
+		[]*instanceSDK.SecurityGroupRule{https, ssh},
+		[]*instanceSDK.SecurityGroupRule{apiSSH, apiExternal, apiHTTPS},
+	)
+	assert.Equal(t, []*instanceSDK.SecurityGroupRule{apiHTTPS, apiSSH}, matched)
+	assert.Equal(t, []*instanceSDK.SecurityGroupRule{apiExternal}, unmatched)
+
+	// An api rule is only matched once
+	matched, unmatched = instance.MatchSecurityGroupRules(
+		[]*instanceSDK.SecurityGroupRule{ssh, ssh},
+		[]*instanceSDK.SecurityGroupRule{apiSSH},
+	)
+	assert.Equal(t, []*instanceSDK.SecurityGroupRule{apiSSH}, matched)
+	assert.Nil(t, unmatched)
+}
+
+func TestSecurityGroupRuleCovers(t *testing.T) {
+	accept := instanceSDK.SecurityGroupRuleActionAccept
+
+	tests := []struct {
+		name     string
+		ruleA    *instanceSDK.SecurityGroupRule
+		ruleB    *instanceSDK.SecurityGroupRule
+		expected bool
+	}{
+		{
+			name:     "anyProtocolAllPorts",
+			ruleA:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolANY, "0.0.0.0/0"),
+			ruleB:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolUDP, "10.0.0.0/8", 53),
+			expected: true,
+		},
+		{
+			name:     "otherProtocol",
+			ruleA:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "0.0.0.0/0"),
+			ruleB:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolUDP, "0.0.0.0/0", 53),
+			expected: false,
+		},
+		{
+			name:     "portInRange",
+			ruleA:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "0.0.0.0/0", 1, 1024),
+			ruleB:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "0.0.0.0/0", 22),
+			expected: true,
+		},
+		{
+			name:     "rangeOverlapping",
+			ruleA:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "0.0.0.0/0", 1, 1024),
+			ruleB:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "0.0.0.0/0", 1000, 2000),
+			expected: false,
+		},
+		{
+			name:     "smallerIPRange",
+			ruleA:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "192.168.1.0/24", 22),
+			ruleB:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "192.168.0.0/16", 22),
+			expected: false,
+		},
+		{
+			name:     "largerIPRange",
+			ruleA:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "192.168.0.0/16", 22),
+			ruleB:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolTCP, "192.168.1.0/24", 22),
+			expected: true,
+		},
+		{
+			name:     "icmpIgnoresPorts",
+			ruleA:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolICMP, "0.0.0.0/0", 22),
+			ruleB:    testSecurityGroupRule(t, "", accept, instanceSDK.SecurityGroupRuleProtocolICMP, "10.0.0.1/32"),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, instance.SecurityGroupRuleCovers(tt.ruleA, tt.ruleB))
+		})
+	}
+}
+
+func TestFindSecurityGroupRuleConflicts(t *testing.T) {
+	accept := instanceSDK.SecurityGroupRuleActionAccept
+	drop := instanceSDK.SecurityGroupRuleActionDrop
+	tcp := instanceSDK.SecurityGroupRuleProtocolTCP
+	inbound := instanceSDK.SecurityGroupRuleDirectionInbound
+
+	rules := []*instanceSDK.SecurityGroupRule{
+		testSecurityGroupRule(t, "", accept, tcp, "0.0.0.0/0", 22),
+		testSecurityGroupRule(t, "", drop, tcp, "0.0.0.0/0", 1, 1024),
+		testSecurityGroupRule(t, "", accept, tcp, "0.0.0.0/0", 22),
+		testSecurityGroupRule(t, "", accept, tcp, "10.0.0.0/8", 80),
+		testSecurityGroupRule(t, "", drop, tcp, "0.0.0.0/0", 8080),
+	}
+
+	conflicts := instance.FindSecurityGroupRuleConflicts(inbound, rules)
+	assert.Equal(t, []instance.SecurityGroupRuleConflict{
+		{Direction: inbound, Index: 2, ShadowedBy: 0, Duplicate: true},
+		{Direction: inbound, Index: 3, ShadowedBy: 1},
+	}, conflicts)
+	assert.Equal(t, "inbound rule #2 is a duplicate of inbound rule #0", conflicts[0].Error())
+	assert.Equal(t, "inbound rule #3 is shadowed by inbound rule #1 with a different action and will never match", conflicts[1].Error())
+
+	assert.Empty(t, instance.FindSecurityGroupRuleConflicts(inbound, rules[:2]))
+}
+
+func TestFindSecurityGroupRuleConflictsInGroup(t *testing.T) {
+	accept := instanceSDK.SecurityGroupRuleActionAccept
+	drop := instanceSDK.SecurityGroupRuleActionDrop
+	tcp := instanceSDK.SecurityGroupRuleProtocolTCP
+
+	groupRule := func(id string, position uint32, direction instanceSDK.SecurityGroupRuleDirection, action instanceSDK.SecurityGroupRuleAction, ipRange string, ports ...uint32) *instanceSDK.SecurityGroupRule {
+		rule := testSecurityGroupRule(t, id, action, tcp, ipRange, ports...)
+		rule.Position = position
+		rule.Direction = direction
+		rule.Editable = true
+
+		return rule
+	}
+
+	sshDrop := groupRule("ssh-drop", 1, instanceSDK.SecurityGroupRuleDirectionInbound, drop, "0.0.0.0/0", 22)
+	sshAccept := groupRule("ssh-accept", 2, instanceSDK.SecurityGroupRuleDirectionInbound, accept, "10.0.0.0/8", 22)
+	sshOutbound := groupRule("ssh-outbound", 3, instanceSDK.SecurityGroupRuleDirectionOutbound, drop, "0.0.0.0/0", 22)
+	sshDuplicate := groupRule("ssh-duplicate", 4, instanceSDK.SecurityGroupRuleDirectionInbound, drop, "0.0.0.0/0", 22)
+	groupRules := []*instanceSDK.SecurityGroupRule{sshDuplicate, sshOutbound, sshAccept, sshDrop}
+
+	// Existing rules are only compared with the rules ahead of them
+	assert.Empty(t, instance.FindSecurityGroupRuleConflictsInGroup(groupRules, sshDrop, "ssh-drop"))
+	assert.Equal(t, []*instanceSDK.SecurityGroupRule{sshDrop}, instance.FindSecurityGroupRuleConflictsInGroup(groupRules, sshAccept, "ssh-accept"))
+	assert.Equal(t, []*instanceSDK.SecurityGroupRule{sshDrop}, instance.FindSecurityGroupRuleConflictsInGroup(groupRules, sshDuplicate, "ssh-duplicate"))
+
+	// A new rule is appended and compared with every rule of its direction
+	newRule := groupRule("", 0, instanceSDK.SecurityGroupRuleDirectionInbound, accept, "10.0.0.0/8", 22)
+	assert.Equal(t, []*instanceSDK.SecurityGroupRule{sshDrop, sshAccept, sshDuplicate}, instance.FindSecurityGroupRuleConflictsInGroup(groupRules, newRule, ""))
+
+	newRule.Direction = instanceSDK.SecurityGroupRuleDirectionOutbound
+	assert.Equal(t, []*instanceSDK.SecurityGroupRule{sshOutbound}, instance.FindSecurityGroupRuleConflictsInGroup(groupRules, newRule, ""))
+}
+
+func TestAccSecurityGroupRule_Conflicts(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	config := func(rejectRuleConflicts bool) string {
+		return fmt.Sprintf(`
+			resource "scaleway_instance_security_group" "main" {
+				name                  = "tf-tests-security-group-rule-conflicts"
+				ignore_external_rules = true
+			}
+
+			resource "scaleway_instance_security_group_rule" "ssh" {
+				security_group_id = scaleway_instance_security_group.main.id
+				direction         = "inbound"
+				action            = "drop"
+				port              = 22
+			}
+
+			resource "scaleway_instance_security_group_rule" "ssh_duplicate" {
+				security_group_id     = scaleway_instance_security_group.main.id
+				direction             = "inbound"
+				action                = "drop"
+				port                  = 22
+				reject_rule_conflicts = %t
+
+				depends_on = [scaleway_instance_security_group_rule.ssh]
+			}
+		`, rejectRuleConflicts)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isSecurityGroupDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_security_group" "main" {
+						name                  = "tf-tests-security-group-rule-conflicts"
+						ignore_external_rules = true
+					}
+
+					resource "scaleway_instance_security_group_rule" "ssh" {
+						security_group_id = scaleway_instance_security_group.main.id
+						direction         = "inbound"
+						action            = "drop"
+						port              = 22
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.ssh", "conflicts.#", "0"),
+				),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.ssh", "conflicts.#", "0"),
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.ssh_duplicate", "conflicts.#", "1"),
+					resource.TestMatchResourceAttr("scaleway_instance_security_group_rule.ssh_duplicate", "conflicts.0", regexp.MustCompile("inbound rule duplicates rule")),
+				),
+			},
+			{
+				Config:   config(false),
+				PlanOnly: true,
+			},
+			{
+				Config:      config(true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("inbound rule duplicates rule"),
+			},
+		},
+	})
+}
+
+func TestAccSecurityGroup_RejectRuleConflicts(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_security_group" "main" {
+						reject_rule_conflicts = true
+
+						inbound_rule {
+							action = "drop"
+							port   = 22
+						}
+
+						inbound_rule {
+							action   = "accept"
+							port     = 22
+							ip_range = "10.0.0.0/8"
+						}
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("inbound rule #1 is shadowed by inbound rule #0"),
+			},
+		},
+	})
+}
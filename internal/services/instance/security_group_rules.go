@@ -26,8 +26,9 @@ func ResourceSecurityGroupRules() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupRuleTimeout),
 		},
-		SchemaFunc: securityGroupRulesSchema,
-		Identity:   identity.DefaultZonal(),
+		SchemaFunc:    securityGroupRulesSchema,
+		Identity:      identity.DefaultZonal(),
+		CustomizeDiff: customDiffSecurityGroupRulesConflicts,
 	}
 }
 
@@ -54,6 +55,18 @@ func securityGroupRulesSchema() map[string]*schema.Schema {
 			Description: "Outbound rules for this set of security group rules",
 			Elem:        securityGroupRuleSchema(),
 		},
+		"ignore_external_rules": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Only manage the rules defined in this resource and keep the other rules of the security group",
+		},
+		"reject_rule_conflicts": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Fail the plan when a rule is a duplicate of an earlier rule or is shadowed by it, a warning is returned otherwise",
+		},
 	}
 }
 
@@ -129,7 +142,9 @@ func ResourceInstanceSecurityGroupRulesUpdate(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
-	return ResourceInstanceSecurityGroupRulesRead(ctx, d, m)
+	diags := securityGroupRulesConflictWarnings(d)
+
+	return append(diags, ResourceInstanceSecurityGroupRulesRead(ctx, d, m)...)
 }
 
 func ResourceInstanceSecurityGroupRulesDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -279,11 +279,14 @@ func serverSchema() map[string]*schema.Schema {
 			Deprecated:       "bootscript is not supported anymore.",
 		},
 		"cloud_init": {
-			Type:         schema.TypeString,
-			Optional:     true,
-			Computed:     true,
-			Description:  "The cloud init script associated with this server",
-			ValidateFunc: validation.StringLenBetween(0, 127998),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The cloud init script associated with this server",
+			ValidateDiagFunc: validation.AllDiag(
+				validation.ToDiagFunc(validation.StringLenBetween(0, 127998)),
+				validateCloudInit(),
+			),
 		},
 		"user_data": {
 			Type:        schema.TypeMap,
@@ -293,6 +296,7 @@ func serverSchema() map[string]*schema.Schema {
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			ValidateDiagFunc: validateUserDataMap(),
 			DiffSuppressFunc: func(k, _, _ string, _ *schema.ResourceData) bool {
 				return k == "user_data.ssh-host-fingerprints"
 			},
@@ -0,0 +1,568 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	scwvalidation "github.com/scaleway/scaleway-sdk-go/validation"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/instancehelpers"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/workerpool"
+)
+
+// serverFleetTemplateAttributes are the attributes that require members to be replaced
+var serverFleetTemplateAttributes = []string{
+	"instance_template_id",
+	"type",
+	"image",
+	"root_volume_type",
+	"root_volume_size_in_gb",
+	"placement_group_id",
+	"private_network_ids",
+	"cloud_init",
+	"enable_dynamic_ip",
+}
+
+func ResourceServerFleet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceInstanceServerFleetCreate,
+		ReadContext:   ResourceInstanceServerFleetRead,
+		UpdateContext: ResourceInstanceServerFleetUpdate,
+		DeleteContext: ResourceInstanceServerFleetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceServerFleetTimeout),
+			Read:    schema.DefaultTimeout(DefaultInstanceServerWaitTimeout),
+			Update:  schema.DefaultTimeout(defaultInstanceServerFleetTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceServerFleetTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceServerFleetTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    serverFleetSchema,
+		Identity:      identity.DefaultZonal(),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("placement_group_id", "security_group_id"),
+			customdiff.ComputedIf("servers", func(_ context.Context, diff *schema.ResourceDiff, _ any) bool {
+				return diff.HasChanges(append([]string{"size", "name"}, serverFleetTemplateAttributes...)...)
+			}),
+		),
+	}
+}
+
+func serverFleetSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The name of the fleet, used as a prefix for the name of its servers",
+		},
+		"size": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The number of servers in the fleet",
+		},
+		"instance_template_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+			ExactlyOneOf:     []string{"instance_template_id", "type"},
+			ConflictsWith: []string{
+				"image",
+				"root_volume_type",
+				"root_volume_size_in_gb",
+				"security_group_id",
+				"placement_group_id",
+				"private_network_ids",
+				"cloud_init",
+				"enable_dynamic_ip",
+			},
+			Description: "The autoscaling instance template the servers are created from, instead of type, image, volumes and networking arguments",
+		},
+		"type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			DiffSuppressFunc: dsf.IgnoreCase,
+			ExactlyOneOf:     []string{"instance_template_id", "type"},
+			RequiredWith:     []string{"image"},
+			Description:      "The instance type of the servers",
+		},
+		"image": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			DiffSuppressFunc: dsf.Locality,
+			RequiredWith:     []string{"type"},
+			Description:      "The UUID or the label of the base image used by the servers",
+		},
+		"root_volume_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				instanceSDK.VolumeVolumeTypeLSSD.String(),
+				instanceSDK.VolumeVolumeTypeSbsVolume.String(),
+			}, false),
+			Description: "Volume type of the root volume of the servers",
+		},
+		"root_volume_size_in_gb": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Size of the root volume of the servers in gigabytes",
+		},
+		"tags": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "The tags associated with the servers",
+		},
+		"security_group_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			DiffSuppressFunc: dsf.Locality,
+			Description:      "The security group the servers are attached to",
+		},
+		"placement_group_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+			Description:      "The placement group the servers are attached to",
+		},
+		"private_network_ids": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: dsf.Locality,
+			},
+			Optional:    true,
+			Description: "The private networks attached to every server",
+		},
+		"cloud_init": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The cloud init script applied to every server",
+		},
+		"enable_dynamic_ip": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Enable dynamic IP on the servers",
+		},
+		"parallelism": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      5,
+			ValidateFunc: validation.IntBetween(1, 50),
+			Description:  "The maximum number of servers created or deleted at the same time",
+		},
+		"rolling_update": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Configuration of the rolling replacement of the servers when the template changes",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"batch_size": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      1,
+						ValidateFunc: validation.IntAtLeast(1),
+						Description:  "The number of servers replaced at the same time",
+					},
+					"health_check_grace_period": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "0s",
+						ValidateDiagFunc: verify.IsDuration(),
+						Description:      "How long a replacement must stay running before the server it replaces is deleted",
+					},
+				},
+			},
+		},
+		"servers": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The servers of the fleet ordered by index",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The position of the server in the fleet",
+					},
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The zoned ID of the server",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the server",
+					},
+					"state": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The state of the server",
+					},
+					"public_ips": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "The public IP addresses of the server",
+					},
+					"image_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The image the server was created from",
+					},
+				},
+			},
+		},
+		"zone":            zonal.Schema(),
+		"organization_id": account.OrganizationIDSchema(),
+		"project_id":      account.ProjectIDSchema(),
+	}
+}
+
+func ResourceInstanceServerFleetCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, err := instancehelpers.InstanceAndBlockAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, ok := d.GetOk("name"); !ok {
+		_ = d.Set("name", types.NewRandomName("fleet"))
+	}
+
+	fleetID := uuid.New().String()
+
+	tpl, err := expandServerFleetTemplate(ctx, d, m, api, zone, fleetID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, fleetID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	indexes := make([]int, 0, d.Get("size").(int))
+	for i := range d.Get("size").(int) {
+		indexes = append(indexes, i)
+	}
+
+	err = scaleUpServerFleet(ctx, api, tpl, indexes, d.Get("parallelism").(int), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceInstanceServerFleetRead(ctx, d, m)
+}
+
+func ResourceInstanceServerFleetRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, fleetID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	members, err := listServerFleetMembers(ctx, api, zone, fleetID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Replacements which did not pass the health gate and retired members are cleaned up by the next update
+	members, _, _ = resolveServerFleetMembers(members)
+
+	if len(members) == 0 && d.Get("size").(int) > 0 && !d.IsNewResource() {
+		d.SetId("")
+
+		return nil
+	}
+
+	servers := make([]any, 0, len(members))
+
+	for _, member := range members {
+		publicIPs := make([]string, 0, len(member.Server.PublicIPs))
+		for _, ip := range member.Server.PublicIPs {
+			publicIPs = append(publicIPs, ip.Address.String())
+		}
+
+		imageID := ""
+		if member.Server.Image != nil {
+			imageID = zonal.NewIDString(zone, member.Server.Image.ID)
+		}
+
+		servers = append(servers, map[string]any{
+			"index":      member.Index,
+			"id":         zonal.NewIDString(zone, member.Server.ID),
+			"name":       member.Server.Name,
+			"state":      member.Server.State.String(),
+			"public_ips": publicIPs,
+			"image_id":   imageID,
+		})
+	}
+
+	if len(members) > 0 {
+		first := members[0].Server
+		userTags, _, _ := SplitServerFleetTags(first.Tags)
+
+		_ = d.Set("name", ServerFleetNameFromMember(first.Name, members[0].Index))
+		_ = d.Set("type", first.CommercialType)
+		_ = d.Set("tags", userTags)
+		_ = d.Set("organization_id", first.Organization)
+		_ = d.Set("project_id", first.Project)
+
+		// Keep the image label when one is used, like scaleway_instance_server
+		image := zonal.ExpandID(d.Get("image").(string))
+		if first.Image != nil && (image.ID == "" || scwvalidation.IsUUID(image.ID)) {
+			_ = d.Set("image", zonal.NewIDString(zone, first.Image.ID))
+		}
+
+		if first.SecurityGroup != nil {
+			_ = d.Set("security_group_id", zonal.NewIDString(zone, first.SecurityGroup.ID))
+		}
+
+		if rootVolume, ok := first.Volumes["0"]; ok && rootVolume != nil {
+			_ = d.Set("root_volume_type", rootVolume.VolumeType.String())
+			if rootVolume.Size != nil {
+				_ = d.Set("root_volume_size_in_gb", int(uint64(*rootVolume.Size)/gb))
+			}
+		}
+	}
+
+	_ = d.Set("size", len(members))
+	_ = d.Set("servers", servers)
+	_ = d.Set("zone", zone.String())
+
+	err = identity.SetZonalIdentity(d, zone, fleetID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func ResourceInstanceServerFleetUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, fleetID, err := instancehelpers.InstanceAndBlockAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parallelism := d.Get("parallelism").(int)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	members, err := listServerFleetMembers(ctx, api.API, zone, fleetID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tpl, err := expandServerFleetTemplate(ctx, d, m, api, zone, fleetID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	////
+	// Finish the swaps and delete the servers left by an interrupted rolling update
+	////
+	members, toPromote, toDelete := resolveServerFleetMembers(members)
+	if len(toPromote)+len(toDelete) > 0 {
+		tasks := make([]workerpool.Task, 0, len(toPromote)+len(toDelete))
+		for _, member := range toPromote {
+			tasks = append(tasks, func() error {
+				return promoteServerFleetMember(ctx, api.API, tpl, member.Index, member.Server.ID)
+			})
+		}
+
+		for _, member := range toDelete {
+			tasks = append(tasks, func() error {
+				return deleteServerFleetMember(ctx, api, zone, member.Server, timeout)
+			})
+		}
+
+		err = runServerFleetTasks(parallelism, tasks)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	////
+	// Scale down first so removed servers are not rolled
+	////
+	size := d.Get("size").(int)
+	if len(members) > size {
+		tasks := make([]workerpool.Task, 0, len(members)-size)
+		for _, member := range members[size:] {
+			tasks = append(tasks, func() error {
+				return deleteServerFleetMember(ctx, api, zone, member.Server, timeout)
+			})
+		}
+
+		err = runServerFleetTasks(parallelism, tasks)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		members = members[:size]
+	}
+
+	////
+	// Update servers in place
+	////
+	if d.HasChanges("name", "tags", "security_group_id") {
+		tasks := make([]workerpool.Task, 0, len(members))
+		for _, member := range members {
+			tasks = append(tasks, func() error {
+				return updateServerFleetMember(ctx, api.API, tpl, member)
+			})
+		}
+
+		err = runServerFleetTasks(parallelism, tasks)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	////
+	// Replace servers when the template changed
+	////
+	if d.HasChanges(serverFleetTemplateAttributes...) && len(members) > 0 {
+		batchSize := 1
+		gracePeriod := time.Duration(0)
+
+		if _, ok := d.GetOk("rolling_update"); ok {
+			batchSize = d.Get("rolling_update.0.batch_size").(int)
+
+			duration, err := types.ExpandDuration(d.Get("rolling_update.0.health_check_grace_period"))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if duration != nil {
+				gracePeriod = *duration
+			}
+		}
+
+		err = rollServerFleetMembers(ctx, api, tpl, members, batchSize, gracePeriod, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	////
+	// Scale up
+	////
+	if len(members) < size {
+		indexes := make([]int, 0, size-len(members))
+
+		next := 0
+		if len(members) > 0 {
+			next = members[len(members)-1].Index + 1
+		}
+
+		for range size - len(members) {
+			indexes = append(indexes, next)
+			next++
+		}
+
+		err = scaleUpServerFleet(ctx, api, tpl, indexes, parallelism, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceInstanceServerFleetRead(ctx, d, m)
+}
+
+func ResourceInstanceServerFleetDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, fleetID, err := instancehelpers.InstanceAndBlockAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	members, err := listServerFleetMembers(ctx, api.API, zone, fleetID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tasks := make([]workerpool.Task, 0, len(members))
+	for _, member := range members {
+		tasks = append(tasks, func() error {
+			return deleteServerFleetMember(ctx, api, zone, member.Server, d.Timeout(schema.TimeoutDelete))
+		})
+	}
+
+	err = runServerFleetTasks(d.Get("parallelism").(int), tasks)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// scaleUpServerFleet creates the members at the given indexes through a bounded worker pool
+func scaleUpServerFleet(ctx context.Context, api *instancehelpers.BlockAndInstanceAPI, tpl *serverFleetTemplate, indexes []int, parallelism int, timeout time.Duration) error {
+	tasks := make([]workerpool.Task, 0, len(indexes))
+	for _, index := range indexes {
+		tasks = append(tasks, func() error {
+			_, err := createServerFleetMember(ctx, api, tpl, index, false, timeout)
+
+			return err
+		})
+	}
+
+	err := runServerFleetTasks(parallelism, tasks)
+	if err != nil {
+		return fmt.Errorf("failed to scale up fleet %s: %w", tpl.FleetID, err)
+	}
+
+	return nil
+}
+
+// updateServerFleetMember applies the attributes that do not require a replacement
+func updateServerFleetMember(ctx context.Context, api *instanceSDK.API, tpl *serverFleetTemplate, member *serverFleetMember) error {
+	req := &instanceSDK.UpdateServerRequest{
+		Zone:     tpl.Zone,
+		ServerID: member.Server.ID,
+		Name:     new(ServerFleetMemberName(tpl.Name, member.Index)),
+		Tags:     new(ServerFleetMemberTags(tpl.Tags, tpl.FleetID, member.Index)),
+	}
+
+	if tpl.SecurityGroupID != nil {
+		req.SecurityGroup = &instanceSDK.SecurityGroupTemplate{
+			ID:   *tpl.SecurityGroupID,
+			Name: types.NewRandomName("sg"), // this value will be ignored by the API
+		}
+	}
+
+	_, err := api.UpdateServer(req, scw.WithContext(ctx))
+
+	return err
+}
@@ -0,0 +1,300 @@
+package instance_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
+	instancechecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/testfuncs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitServerFleetBatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []int
+		batchSize int
+		expected  [][]int
+	}{
+		{
+			name:      "empty",
+			items:     []int{},
+			batchSize: 2,
+			expected:  [][]int{},
+		},
+		{
+			name:      "exact",
+			items:     []int{0, 1, 2, 3},
+			batchSize: 2,
+			expected:  [][]int{{0, 1}, {2, 3}},
+		},
+		{
+			name:      "remainder",
+			items:     []int{0, 1, 2, 3, 4},
+			batchSize: 2,
+			expected:  [][]int{{0, 1}, {2, 3}, {4}},
+		},
+		{
+			name:      "batchLargerThanItems",
+			items:     []int{0, 1},
+			batchSize: 10,
+			expected:  [][]int{{0, 1}},
+		},
+		{
+			name:      "invalidBatchSize",
+			items:     []int{0, 1},
+			batchSize: 0,
+			expected:  [][]int{{0}, {1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, instance.SplitServerFleetBatches(tt.items, tt.batchSize))
+		})
+	}
+}
+
+func TestServerFleetTags(t *testing.T) {
+	tags := instance.ServerFleetMemberTags([]string{"web", "prod"}, "11111111-1111-1111-1111-111111111111", 12)
+	assert.Equal(t, []string{"web", "prod", "fleet=11111111-1111-1111-1111-111111111111", "fleet-index=12"}, tags)
+
+	userTags, index, state := instance.SplitServerFleetTags(tags)
+	assert.Equal(t, []string{"web", "prod"}, userTags)
+	assert.Equal(t, 12, index)
+	assert.Equal(t, instance.ServerFleetMemberActive, state)
+
+	userTags, index, state = instance.SplitServerFleetTags(instance.ServerFleetPendingMemberTags([]string{"web"}, "11111111-1111-1111-1111-111111111111", 4))
+	assert.Equal(t, []string{"web"}, userTags)
+	assert.Equal(t, 4, index)
+	assert.Equal(t, instance.ServerFleetMemberPending, state)
+
+	userTags, index, state = instance.SplitServerFleetTags(instance.ServerFleetRetiredMemberTags([]string{"web"}, "11111111-1111-1111-1111-111111111111", 5))
+	assert.Equal(t, []string{"web"}, userTags)
+	assert.Equal(t, 5, index)
+	assert.Equal(t, instance.ServerFleetMemberRetired, state)
+
+	userTags, index, state = instance.SplitServerFleetTags([]string{"fleet=11111111-1111-1111-1111-111111111111"})
+	assert.Nil(t, userTags)
+	assert.Equal(t, -1, index)
+	assert.Equal(t, instance.ServerFleetMemberActive, state)
+
+	assert.Equal(t, "web-3", instance.ServerFleetMemberName("web", 3))
+	assert.Equal(t, "web-3-pending", instance.ServerFleetPendingMemberName("web", 3))
+	assert.Equal(t, "web-3-retired", instance.ServerFleetRetiredMemberName("web", 3))
+	assert.Equal(t, "web-prod", instance.ServerFleetNameFromMember("web-prod-3", 3))
+	assert.Equal(t, "web-prod", instance.ServerFleetNameFromMember("web-prod-3-pending", 3))
+}
+
+func TestAccServerFleet_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isServerFleetDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_server_fleet" "main" {
+						name  = "tf-tests-fleet-basic"
+						size  = 2
+						type  = "DEV1-S"
+						image = "ubuntu_jammy"
+						tags  = ["terraform-test", "fleet"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isServerFleetSize(tt, "scaleway_instance_server_fleet.main", 2),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "servers.#", "2"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "servers.0.name", "tf-tests-fleet-basic-0"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "servers.1.name", "tf-tests-fleet-basic-1"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "servers.0.state", "running"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "tags.#", "2"),
+				),
+			},
+			{
+				Config: `
+					resource "scaleway_instance_server_fleet" "main" {
+						name  = "tf-tests-fleet-basic"
+						size  = 1
+						type  = "DEV1-S"
+						image = "ubuntu_jammy"
+						tags  = ["terraform-test", "fleet", "scaled-down"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isServerFleetSize(tt, "scaleway_instance_server_fleet.main", 1),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "servers.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "servers.0.index", "0"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "tags.2", "scaled-down"),
+				),
+			},
+			{
+				ResourceName:            "scaleway_instance_server_fleet.main",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"image", "parallelism", "enable_dynamic_ip"},
+			},
+		},
+	})
+}
+
+func TestAccServerFleet_RollingUpdate(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isServerFleetDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_server_fleet" "main" {
+						name  = "tf-tests-fleet-rolling"
+						size  = 2
+						type  = "DEV1-S"
+						image = "ubuntu_jammy"
+					}
+				`,
+				Check: isServerFleetSize(tt, "scaleway_instance_server_fleet.main", 2),
+			},
+			{
+				Config: `
+					resource "scaleway_instance_server_fleet" "main" {
+						name  = "tf-tests-fleet-rolling"
+						size  = 2
+						type  = "DEV1-M"
+						image = "ubuntu_jammy"
+
+						rolling_update {
+							batch_size                = 1
+							health_check_grace_period = "10s"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isServerFleetSize(tt, "scaleway_instance_server_fleet.main", 2),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "type", "DEV1-M"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "servers.0.name", "tf-tests-fleet-rolling-0"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "servers.1.name", "tf-tests-fleet-rolling-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerFleet_InstanceTemplate(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isServerFleetDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "scaleway_marketplace_image" "ubuntu" {
+						label         = "ubuntu_jammy"
+						instance_type = "PLAY2-MICRO"
+						image_type    = "instance_sbs"
+					}
+
+					resource "scaleway_autoscaling_instance_template" "main" {
+						name            = "tf-tests-fleet-template"
+						commercial_type = "PLAY2-MICRO"
+						image_id        = data.scaleway_marketplace_image.ubuntu.id
+						volumes {
+							name        = "root"
+							volume_type = "sbs"
+							boot        = true
+							from_empty {
+								size = 20
+							}
+						}
+					}
+
+					resource "scaleway_instance_server_fleet" "main" {
+						name                 = "tf-tests-fleet-template"
+						size                 = 1
+						instance_template_id = scaleway_autoscaling_instance_template.main.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isServerFleetSize(tt, "scaleway_instance_server_fleet.main", 1),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "type", "PLAY2-MICRO"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "root_volume_type", "sbs_volume"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_fleet.main", "root_volume_size_in_gb", "20"),
+				),
+			},
+		},
+	})
+}
+
+func isServerFleetSize(tt *acctest.TestTools, n string, size int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		api, zone, fleetID, err := instance.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := api.ListServers(&instanceSDK.ListServersRequest{
+			Zone: zone,
+			Tags: []string{instance.ServerFleetTag(fleetID)},
+		}, scw.WithAllPages())
+		if err != nil {
+			return err
+		}
+
+		if len(res.Servers) != size {
+			return fmt.Errorf("fleet %s has %d servers, expected %d", rs.Primary.ID, len(res.Servers), size)
+		}
+
+		return nil
+	}
+}
+
+func isServerFleetDestroyed(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		ctx := context.Background()
+
+		return retry.RetryContext(ctx, instancechecks.DestroyWaitTimeout, func() *retry.RetryError {
+			for _, rs := range state.RootModule().Resources {
+				if rs.Type != "scaleway_instance_server_fleet" {
+					continue
+				}
+
+				api, zone, fleetID, err := instance.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+				if err != nil {
+					return retry.NonRetryableError(err)
+				}
+
+				res, err := api.ListServers(&instanceSDK.ListServersRequest{
+					Zone: zone,
+					Tags: []string{instance.ServerFleetTag(fleetID)},
+				}, scw.WithAllPages())
+				if err != nil {
+					return retry.NonRetryableError(err)
+				}
+
+				if len(res.Servers) > 0 {
+					return retry.RetryableError(fmt.Errorf("fleet (%s) still has %d servers", rs.Primary.ID, len(res.Servers)))
+				}
+			}
+
+			return nil
+		})
+	}
+}
@@ -2,12 +2,15 @@ package instancetestfuncs
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/logging"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
 )
 
 func AddTestSweepers() {
@@ -29,8 +32,13 @@ func AddTestSweepers() {
 		F:    testSweepSecurityGroup,
 	})
 	resource.AddTestSweepers("scaleway_instance_server", &resource.Sweeper{
-		Name: "scaleway_instance_server",
-		F:    testSweepServer,
+		Name:         "scaleway_instance_server",
+		Dependencies: []string{"scaleway_instance_server_fleet"},
+		F:            testSweepServer,
+	})
+	resource.AddTestSweepers("scaleway_instance_server_fleet", &resource.Sweeper{
+		Name: "scaleway_instance_server_fleet",
+		F:    testSweepServerFleet,
 	})
 	resource.AddTestSweepers("scaleway_instance_snapshot", &resource.Sweeper{
 		Name: "scaleway_instance_snapshot",
@@ -137,6 +145,49 @@ func testSweepServer(_ string) error {
 	})
 }
 
+func testSweepServerFleet(_ string) error {
+	return acctest.SweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+		instanceAPI := instanceSDK.NewAPI(scwClient)
+
+		logging.L.Debugf("sweeper: destroying the instance server fleets in (%s)", zone)
+
+		listServers, err := instanceAPI.ListServers(&instanceSDK.ListServersRequest{Zone: zone}, scw.WithAllPages())
+		if err != nil {
+			logging.L.Warningf("error listing servers in (%s) in sweeper: %s", zone, err)
+
+			return nil
+		}
+
+		for _, srv := range listServers.Servers {
+			if !slices.ContainsFunc(srv.Tags, func(tag string) bool { return strings.HasPrefix(tag, instance.ServerFleetTagPrefix) }) {
+				continue
+			}
+
+			switch srv.State {
+			case instanceSDK.ServerStateStopped, instanceSDK.ServerStateStoppedInPlace:
+				err := instanceAPI.DeleteServer(&instanceSDK.DeleteServerRequest{
+					Zone:     zone,
+					ServerID: srv.ID,
+				})
+				if err != nil {
+					return fmt.Errorf("error deleting fleet server in sweeper: %w", err)
+				}
+			case instanceSDK.ServerStateRunning:
+				_, err := instanceAPI.ServerAction(&instanceSDK.ServerActionRequest{
+					Zone:     zone,
+					ServerID: srv.ID,
+					Action:   instanceSDK.ServerActionTerminate,
+				})
+				if err != nil {
+					return fmt.Errorf("error terminating fleet server in sweeper: %w", err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
 func testSweepSecurityGroup(_ string) error {
 	return acctest.SweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
 		instanceAPI := instanceSDK.NewAPI(scwClient)
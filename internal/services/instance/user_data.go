@@ -3,11 +3,16 @@ package instance
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	instanceSDK "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
@@ -15,6 +20,7 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance/cloudinit"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
 )
 
@@ -37,7 +43,10 @@ func ResourceUserData() *schema.Resource {
 		SchemaVersion: 0,
 		SchemaFunc:    userDataSchema,
 		Identity:      identity.DefaultZonal(),
-		CustomizeDiff: cdf.LocalityCheck("server_id"),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("server_id"),
+			customDiffUserDataCloudInit,
+		),
 	}
 }
 
@@ -55,9 +64,20 @@ func userDataSchema() map[string]*schema.Schema {
 			Description: "The key of the user data to set.",
 		},
 		"value": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "The value of the user data to set.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"value", "cloud_init_parts"},
+			Description:  "The value of the user data to set.",
+		},
+		"cloud_init_parts": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Render the value as a cloud-init MIME multipart message",
+			Elem: &schema.Resource{
+				Schema: cloudInitPartsSchema(),
+			},
 		},
 		"zone": zonal.Schema(),
 	}
@@ -77,7 +97,13 @@ func ResourceInstanceUserDataCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	key := d.Get("key").(string)
-	value := bytes.NewBufferString(d.Get("value").(string))
+
+	content, err := expandUserDataContent(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	value := bytes.NewBuffer(content)
 
 	userDataRequest := &instanceSDK.SetServerUserDataRequest{
 		Zone:     zone,
@@ -109,7 +135,7 @@ func ResourceInstanceUserDataCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	return setUserDataState(d, userData, zone, serverID, key)
+	return append(userDataCloudConfigWarnings(d), setUserDataState(d, userData, zone, serverID, key)...)
 }
 
 func setUserDataState(d *schema.ResourceData, serverUserDataRawValue io.Reader, zone scw.Zone, serverID, key string) diag.Diagnostics {
@@ -120,7 +146,7 @@ func setUserDataState(d *schema.ResourceData, serverUserDataRawValue io.Reader,
 
 	_ = d.Set("server_id", zonal.NewID(zone, serverID).String())
 	_ = d.Set("key", key)
-	_ = d.Set("value", string(userDataValue))
+	_ = d.Set("value", cloudinit.EncodeForState(userDataValue))
 	_ = d.Set("zone", zone.String())
 
 	return nil
@@ -188,9 +214,16 @@ func ResourceInstanceUserDataUpdate(ctx context.Context, d *schema.ResourceData,
 		userDataRequest.Zone = scw.Zone(v.(string))
 	}
 
-	if d.HasChanges("value") {
-		value := d.Get("value")
-		userDataRequest.Content = bytes.NewBufferString(value.(string))
+	var diags diag.Diagnostics
+
+	if d.HasChanges("value", "cloud_init_parts") {
+		content, err := expandUserDataContent(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		userDataRequest.Content = bytes.NewBuffer(content)
+		diags = userDataCloudConfigWarnings(d)
 	}
 
 	err = instanceAPI.SetServerUserData(userDataRequest, scw.WithContext(ctx))
@@ -203,7 +236,7 @@ func ResourceInstanceUserDataUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	return ResourceInstanceUserDataRead(ctx, d, m)
+	return append(diags, ResourceInstanceUserDataRead(ctx, d, m)...)
 }
 
 func ResourceInstanceUserDataDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -230,3 +263,219 @@ func ResourceInstanceUserDataDelete(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+// cloudInitPartsSchema is shared by the user data resource and the cloud-init data source
+func cloudInitPartsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"gzip": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Compress the rendered message with gzip, the result is base64 encoded",
+		},
+		"part": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Description: "A part of the MIME multipart message",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"content_type": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      cloudinit.ContentTypeCloudConfig,
+						ValidateFunc: validation.StringInSlice(cloudinit.ContentTypes(), false),
+						Description:  "The MIME content type of the part",
+					},
+					"content": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The content of the part",
+					},
+					"filename": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The filename of the part",
+					},
+					"merge_type": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The cloud-init merge type of the part, e.g. `list(append)+dict(recurse_array)+str()`",
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandCloudInitParts converts a cloud_init_parts block to parts and returns whether gzip is enabled
+func expandCloudInitParts(raw map[string]any) ([]cloudinit.Part, bool) {
+	rawParts := raw["part"].([]any)
+	parts := make([]cloudinit.Part, 0, len(rawParts))
+
+	for _, rawPart := range rawParts {
+		part := rawPart.(map[string]any)
+		parts = append(parts, cloudinit.Part{
+			ContentType: part["content_type"].(string),
+			Content:     part["content"].(string),
+			Filename:    part["filename"].(string),
+			MergeType:   part["merge_type"].(string),
+		})
+	}
+
+	return parts, raw["gzip"].(bool)
+}
+
+// validateCloudInitParts validates every cloud-config part, partsPath is the path of the list of parts
+func validateCloudInitParts(parts []cloudinit.Part, partsPath cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for i, part := range parts {
+		if part.ContentType != cloudinit.ContentTypeCloudConfig {
+			continue
+		}
+
+		diags = append(diags, cloudConfigDiagnostics(part.Content, partsPath.IndexInt(i).GetAttr("content"))...)
+	}
+
+	return diags
+}
+
+// expandUserDataContent returns the bytes to upload. value is uploaded as is, cloud_init_parts is rendered and
+// its gzip payload decoded so that a plain value is never mistaken for a base64 encoded message.
+func expandUserDataContent(d *schema.ResourceData) ([]byte, error) {
+	rawParts, ok := d.GetOk("cloud_init_parts.0")
+	if !ok {
+		return []byte(d.Get("value").(string)), nil
+	}
+
+	parts, gzip := expandCloudInitParts(rawParts.(map[string]any))
+
+	rendered, err := cloudinit.Render(parts, gzip, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return cloudinit.DecodeRendered(rendered), nil
+}
+
+// cloudConfigDiagnostics converts the issues found in a cloud-config document to diagnostics
+func cloudConfigDiagnostics(content string, path cty.Path) diag.Diagnostics {
+	if !cloudinit.IsCloudConfig(content) {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+
+	for _, issue := range cloudinit.ValidateCloudConfig(content) {
+		severity := diag.Error
+		if issue.Severity == cloudinit.SeverityWarning {
+			severity = diag.Warning
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity:      severity,
+			Summary:       "invalid cloud-config: " + issue.String(),
+			Detail:        "See https://cloudinit.readthedocs.io/en/latest/reference/modules.html for the list of supported keys.",
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
+
+// validateCloudInit validates cloud-config documents at plan time, shell scripts and MIME messages are not checked
+func validateCloudInit() schema.SchemaValidateDiagFunc {
+	return func(i any, path cty.Path) diag.Diagnostics {
+		content, ok := i.(string)
+		if !ok {
+			return nil
+		}
+
+		return cloudConfigDiagnostics(content, path)
+	}
+}
+
+// validateUserDataMap validates the cloud-init key of a user data map
+func validateUserDataMap() schema.SchemaValidateDiagFunc {
+	return func(i any, path cty.Path) diag.Diagnostics {
+		userData, ok := i.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		content, ok := userData["cloud-init"].(string)
+		if !ok {
+			return nil
+		}
+
+		return cloudConfigDiagnostics(content, path.IndexString("cloud-init"))
+	}
+}
+
+// userDataCloudInitPartsPath is the path of the parts of the cloud_init_parts block
+var userDataCloudInitPartsPath = cty.GetAttrPath("cloud_init_parts").IndexInt(0).GetAttr("part")
+
+// userDataCloudConfigWarnings returns the warnings about the cloud-config documents of the user data.
+// A CustomizeDiff can only fail the plan, so the warnings are reported when the user data is applied.
+func userDataCloudConfigWarnings(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if rawParts, ok := d.GetOk("cloud_init_parts.0"); ok {
+		parts, _ := expandCloudInitParts(rawParts.(map[string]any))
+		diags = validateCloudInitParts(parts, userDataCloudInitPartsPath)
+	} else if d.Get("key").(string) == "cloud-init" {
+		diags = cloudConfigDiagnostics(d.Get("value").(string), cty.GetAttrPath("value"))
+	}
+
+	var warnings diag.Diagnostics
+
+	for _, diagnostic := range diags {
+		if diagnostic.Severity == diag.Warning {
+			warnings = append(warnings, diagnostic)
+		}
+	}
+
+	return warnings
+}
+
+// customDiffUserDataCloudInit renders cloud_init_parts in the plan and rejects invalid cloud-config documents,
+// the warnings are reported on apply by userDataCloudConfigWarnings
+func customDiffUserDataCloudInit(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	var diags diag.Diagnostics
+
+	if rawParts, ok := diff.GetOk("cloud_init_parts.0"); ok {
+		parts, gzip := expandCloudInitParts(rawParts.(map[string]any))
+		diags = validateCloudInitParts(parts, userDataCloudInitPartsPath)
+
+		if diff.NewValueKnown("cloud_init_parts") {
+			rendered, err := cloudinit.Render(parts, gzip, false)
+			if err != nil {
+				return err
+			}
+
+			if rendered != diff.Get("value").(string) {
+				err = diff.SetNew("value", rendered)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	} else if diff.Get("key").(string) == "cloud-init" && diff.NewValueKnown("value") {
+		diags = cloudConfigDiagnostics(diff.Get("value").(string), cty.GetAttrPath("value"))
+	}
+
+	var errs []string
+
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Summary)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,429 @@
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	vpcSDK "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/mutexkv"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+const (
+	// ipBlockTagPrefix is the tag used to find the IPs of a block
+	ipBlockTagPrefix = "ip-block="
+	// maxIPBlockSize is the maximum number of IPs of a block, they are booked one by one
+	maxIPBlockSize = 256
+)
+
+// ipBlockMutexKV serializes the bookings of the blocks of a private network, so that two blocks do not pick the same free range
+var ipBlockMutexKV = mutexkv.NewMutexKV()
+
+func ResourceIPBlock() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceIPAMIPBlockCreate,
+		ReadContext:   ResourceIPAMIPBlockRead,
+		UpdateContext: ResourceIPAMIPBlockUpdate,
+		DeleteContext: ResourceIPAMIPBlockDelete,
+		Identity:      identity.DefaultRegional(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    ipBlockSchema,
+	}
+}
+
+func ipBlockSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"source": {
+			Type:        schema.TypeList,
+			Required:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "The Private Network subnet in which to book the IPs",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"private_network_id": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ForceNew:         true,
+						Description:      "Private Network the IPs live in",
+						DiffSuppressFunc: dsf.Locality,
+					},
+					"subnet_id": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Computed:    true,
+						ForceNew:    true,
+						Description: "Private Network subnet the IPs live in, the first subnet of the IP version by default",
+					},
+				},
+			},
+		},
+		"size": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntBetween(1, maxIPBlockSize),
+			Description:  "The number of consecutive IPs to book",
+		},
+		"start_address": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsIPAddress,
+			Description:  "The first IP of the block, the first free range of the subnet is booked by default",
+		},
+		"is_ipv6": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+			Description: "Book IPv6 instead of IPv4 addresses",
+		},
+		"tags": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The tags associated with the IPs",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"project_id": account.ProjectIDSchema(),
+		"region":     regional.Schema(),
+		// Computed elements
+		"end_address": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The last IP of the block",
+		},
+		"addresses": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IPs of the block, in ascending order",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"ip_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IDs of the IPs of the block, in the order of the addresses",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func ipBlockTag(blockID string) string {
+	return ipBlockTagPrefix + blockID
+}
+
+func ipBlockUserTags(tags []string) []string {
+	userTags := []string(nil)
+
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, ipBlockTagPrefix) {
+			userTags = append(userTags, tag)
+		}
+	}
+
+	return userTags
+}
+
+// listIPBlockIPs returns the IPs of a block sorted by address
+func listIPBlockIPs(ctx context.Context, ipamAPI *ipam.API, region scw.Region, blockID string) ([]*ipam.IP, error) {
+	res, err := ipamAPI.ListIPs(&ipam.ListIPsRequest{
+		Region: region,
+		Tags:   []string{ipBlockTag(blockID)},
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(res.IPs, func(a, b *ipam.IP) int {
+		return slices.Compare(a.Address.IP.To16(), b.Address.IP.To16())
+	})
+
+	return res.IPs, nil
+}
+
+// ipBlockSubnet returns the subnet of the private network in which the block is booked
+func ipBlockSubnet(ctx context.Context, m any, region scw.Region, privateNetworkID string, subnetID string, isIPv6 bool) (*vpcSDK.Subnet, netip.Prefix, error) {
+	vpcAPI, err := vpc.NewAPI(m)
+	if err != nil {
+		return nil, netip.Prefix{}, err
+	}
+
+	pn, err := vpcAPI.GetPrivateNetwork(&vpcSDK.GetPrivateNetworkRequest{
+		PrivateNetworkID: privateNetworkID,
+		Region:           region,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, netip.Prefix{}, err
+	}
+
+	for _, subnet := range pn.Subnets {
+		prefix, err := types.IPNetToPrefix(subnet.Subnet)
+		if err != nil {
+			return nil, netip.Prefix{}, err
+		}
+
+		if prefix.Addr().Is6() != isIPv6 || (subnetID != "" && subnet.ID != subnetID) {
+			continue
+		}
+
+		return subnet, prefix, nil
+	}
+
+	if subnetID != "" {
+		return nil, netip.Prefix{}, fmt.Errorf("subnet %s of the IP version not found in private network %s", subnetID, privateNetworkID)
+	}
+
+	return nil, netip.Prefix{}, fmt.Errorf("private network %s has no subnet of the IP version", privateNetworkID)
+}
+
+// ipBlockAddresses returns the addresses to book, the range starting at start_address or the first free range of the subnet
+func ipBlockAddresses(ctx context.Context, ipamAPI *ipam.API, region scw.Region, privateNetworkID string, subnet *vpcSDK.Subnet, prefix netip.Prefix, size int, startAddress string) ([]netip.Addr, error) {
+	if startAddress == "" {
+		res, err := ipamAPI.ListIPs(&ipam.ListIPsRequest{
+			Region:           region,
+			PrivateNetworkID: &privateNetworkID,
+			IsIPv6:           new(prefix.Addr().Is6()),
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("error fetching IPs from IPAM: %w", err)
+		}
+
+		used := make([]netip.Addr, 0, len(res.IPs))
+
+		for _, ip := range res.IPs {
+			if addr, ok := netip.AddrFromSlice(ip.Address.IP); ok {
+				used = append(used, addr.Unmap())
+			}
+		}
+
+		start, err := types.NextFreeAddrRange(prefix, size, used)
+		if err != nil {
+			return nil, fmt.Errorf("subnet %s: %w", subnet.ID, err)
+		}
+
+		return types.AddrRange(start, size)
+	}
+
+	start, err := netip.ParseAddr(startAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := types.AddrRange(start.Unmap(), size)
+	if err != nil {
+		return nil, err
+	}
+
+	if last := addrs[len(addrs)-1]; !prefix.Contains(start.Unmap()) || !prefix.Contains(last) {
+		return nil, fmt.Errorf("the block %s-%s is not in the subnet %s of private network %s", start, last, prefix, privateNetworkID)
+	}
+
+	return addrs, nil
+}
+
+func ResourceIPAMIPBlockCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	ipamAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	privateNetworkID := locality.ExpandID(d.Get("source.0.private_network_id").(string))
+	isIPv6 := d.Get("is_ipv6").(bool)
+	size := d.Get("size").(int)
+
+	lockKey := fmt.Sprintf("%s/%s", region, privateNetworkID)
+	ipBlockMutexKV.Lock(lockKey)
+	defer ipBlockMutexKV.Unlock(lockKey)
+
+	subnet, prefix, err := ipBlockSubnet(ctx, m, region, privateNetworkID, d.Get("source.0.subnet_id").(string), isIPv6)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	addrs, err := ipBlockAddresses(ctx, ipamAPI, region, privateNetworkID, subnet, prefix, size, d.Get("start_address").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	blockID := uuid.New().String()
+	tags := append(types.ExpandStrings(d.Get("tags")), ipBlockTag(blockID))
+	bookedIDs := make([]string, 0, size)
+
+	for _, addr := range addrs {
+		res, err := ipamAPI.BookIP(&ipam.BookIPRequest{
+			Region:    region,
+			ProjectID: d.Get("project_id").(string),
+			IsIPv6:    isIPv6,
+			Source: &ipam.Source{
+				PrivateNetworkID: &privateNetworkID,
+				SubnetID:         &subnet.ID,
+			},
+			Address: new(net.IP(addr.AsSlice())),
+			Tags:    tags,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			// The block is booked as a whole, release the IPs booked before the failure
+			err = fmt.Errorf("error booking %s: %w", addr, err)
+
+			return diag.FromErr(errors.Join(err, releaseIPs(ctx, ipamAPI, region, bookedIDs)))
+		}
+
+		bookedIDs = append(bookedIDs, res.ID)
+	}
+
+	err = identity.SetRegionalIdentity(d, region, blockID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceIPAMIPBlockRead(ctx, d, m)
+}
+
+func releaseIPs(ctx context.Context, ipamAPI *ipam.API, region scw.Region, ipIDs []string) error {
+	errs := []error(nil)
+
+	for _, ipID := range ipIDs {
+		err := ipamAPI.ReleaseIP(&ipam.ReleaseIPRequest{
+			Region: region,
+			IPID:   ipID,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			errs = append(errs, fmt.Errorf("error releasing IP %s: %w", ipID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func ResourceIPAMIPBlockRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	ipamAPI, region, blockID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ips, err := listIPBlockIPs(ctx, ipamAPI, region, blockID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(ips) == 0 {
+		d.SetId("")
+
+		return nil
+	}
+
+	if size := d.Get("size").(int); size != 0 && len(ips) != size {
+		tflog.Warn(ctx, fmt.Sprintf("IP block %s has %d IPs instead of %d, it will be replaced", blockID, len(ips), size))
+	}
+
+	addresses := make([]string, 0, len(ips))
+	ipIDs := make([]string, 0, len(ips))
+
+	for _, ip := range ips {
+		addresses = append(addresses, ip.Address.IP.String())
+		ipIDs = append(ipIDs, regional.NewIDString(region, ip.ID))
+	}
+
+	first := ips[0]
+
+	if first.Source != nil && first.Source.PrivateNetworkID != nil {
+		_ = d.Set("source", []map[string]any{{
+			"private_network_id": regional.NewIDString(region, *first.Source.PrivateNetworkID),
+			"subnet_id":          types.FlattenStringPtr(first.Source.SubnetID),
+		}})
+	}
+
+	_ = d.Set("size", len(ips))
+	_ = d.Set("start_address", addresses[0])
+	_ = d.Set("end_address", addresses[len(addresses)-1])
+	_ = d.Set("addresses", addresses)
+	_ = d.Set("ip_ids", ipIDs)
+	_ = d.Set("is_ipv6", first.IsIPv6)
+	_ = d.Set("tags", ipBlockUserTags(first.Tags))
+	_ = d.Set("project_id", first.ProjectID)
+	_ = d.Set("region", region.String())
+
+	err = identity.SetRegionalIdentity(d, region, blockID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func ResourceIPAMIPBlockUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	ipamAPI, region, blockID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("tags") {
+		ips, err := listIPBlockIPs(ctx, ipamAPI, region, blockID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		tags := append(types.ExpandStrings(d.Get("tags")), ipBlockTag(blockID))
+
+		for _, ip := range ips {
+			_, err = ipamAPI.UpdateIP(&ipam.UpdateIPRequest{
+				IPID:   ip.ID,
+				Region: region,
+				Tags:   &tags,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return ResourceIPAMIPBlockRead(ctx, d, m)
+}
+
+func ResourceIPAMIPBlockDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	ipamAPI, region, blockID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ips, err := listIPBlockIPs(ctx, ipamAPI, region, blockID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ipIDs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		ipIDs = append(ipIDs, ip.ID)
+	}
+
+	return diag.FromErr(releaseIPs(ctx, ipamAPI, region, ipIDs))
+}
@@ -141,6 +141,7 @@ func ResourceCluster() *schema.Resource {
 
 				return nil
 			},
+			customDiffClusterUpgradeStrategy,
 		),
 	}
 }
@@ -303,6 +304,30 @@ func clusterSchema() map[string]*schema.Schema {
 			Default:     true,
 			Description: "Whether the pools should be automatically upgraded alongside the cluster, or have to be upgraded separately.",
 		},
+		"upgrade_strategy": {
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Optional:    true,
+			Description: "Upgrade the cluster one minor version at a time, then its pools one by one",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"pool_order": {
+						Type: schema.TypeList,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+						Optional:    true,
+						Description: "Names of the pools to upgrade first, in order. The other pools are upgraded afterwards sorted by name. The names are checked against the pools of the cluster before the control plane is upgraded",
+					},
+					"allow_multiple_minor_versions": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Allow a version change spanning several minor versions, the cluster is upgraded through each of them",
+					},
+				},
+			},
+		},
 		"region":          regional.Schema(),
 		"organization_id": account.OrganizationIDSchema(),
 		"project_id":      account.ProjectIDSchema(),
@@ -327,6 +352,12 @@ func clusterSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Wildcard DNS pointing to all the ready nodes",
 		},
+		"ephemeral_kubeconfig": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Do not store the kubeconfig file and the admin token in the state, use the scaleway_k8s_kubeconfig ephemeral resource to get them",
+		},
 		"kubeconfig": {
 			Type:        schema.TypeList,
 			Computed:    true,
@@ -678,7 +709,9 @@ func ResourceK8SClusterRead(ctx context.Context, d *schema.ResourceData, m any)
 	////
 	// Read kubeconfig
 	////
-	kubeconfig, err := flattenKubeconfig(ctx, k8sAPI, region, clusterID)
+	ephemeralKubeconfig, _ := d.Get("ephemeral_kubeconfig").(bool)
+
+	kubeconfig, err := flattenKubeconfig(ctx, k8sAPI, region, clusterID, !ephemeralKubeconfig)
 	if err != nil {
 		if httperrors.Is403(err) {
 			return diag.Diagnostics{diag.Diagnostic{
@@ -948,7 +981,25 @@ func ResourceK8SClusterUpdate(ctx context.Context, d *schema.ResourceData, m any
 	////
 	upgradePools := d.Get("upgrade_pools").(bool)
 
-	if canUpgrade {
+	if strategy := expandUpgradeStrategy(d.Get("upgrade_strategy"), upgradePools); canUpgrade && strategy != nil {
+		cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
+			Region:    region,
+			ClusterID: clusterID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return append(diag.FromErr(err), diags...)
+		}
+
+		path, err := planClusterUpgrade(ctx, k8sAPI, region, clusterID, cluster.Version, version, cluster.FeatureGates, cluster.AdmissionPlugins, strategy)
+		if err != nil {
+			return append(diag.FromErr(err), diags...)
+		}
+
+		err = upgradeCluster(ctx, k8sAPI, region, clusterID, path, strategy, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return append(diag.FromErr(err), diags...)
+		}
+	} else if canUpgrade {
 		upgradeRequest := &k8s.UpgradeClusterRequest{
 			Region:       region,
 			ClusterID:    clusterID,
@@ -1156,3 +1207,42 @@ func openIDConnectConfigSchema() *schema.Resource {
 		},
 	}
 }
+
+// customDiffClusterUpgradeStrategy runs the upgrade pre-flight checks at plan time when the version of a cluster with an upgrade_strategy changes
+func customDiffClusterUpgradeStrategy(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if diff.Id() == "" || !diff.HasChange("version") {
+		return nil
+	}
+
+	strategy := expandUpgradeStrategy(diff.Get("upgrade_strategy"), diff.Get("upgrade_pools").(bool))
+	if strategy == nil || !diff.NewValueKnown("version") || !diff.NewValueKnown("feature_gates") || !diff.NewValueKnown("admission_plugins") {
+		return nil
+	}
+
+	k8sAPI, region, clusterID, err := NewAPIWithRegionAndID(m, diff.Id())
+	if err != nil {
+		return err
+	}
+
+	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	target, err := resolveTargetVersion(ctx, k8sAPI, region, diff.Get("version").(string))
+	if err != nil {
+		return err
+	}
+
+	if cluster.Version == target {
+		return nil
+	}
+
+	// feature_gates and admission_plugins are updated before the upgrade, check the planned values
+	_, err = planClusterUpgrade(ctx, k8sAPI, region, clusterID, cluster.Version, target, types.ExpandStrings(diff.Get("feature_gates")), types.ExpandStrings(diff.Get("admission_plugins")), strategy)
+
+	return err
+}
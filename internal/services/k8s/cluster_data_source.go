@@ -23,6 +23,7 @@ func DataSourceCluster() *schema.Resource {
 	// Set 'Optional' schema elements
 	datasource.AddOptionalFieldsToSchema(dsSchema, "name", "region", "project_id")
 	delete(dsSchema, "delete_additional_resources")
+	delete(dsSchema, "ephemeral_kubeconfig")
 
 	dsSchema["name"].ConflictsWith = []string{"cluster_id"}
 	dsSchema["cluster_id"] = &schema.Schema{
@@ -0,0 +1,269 @@
+package k8s
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+var (
+	_ action.Action               = (*ClusterUpgradeAction)(nil)
+	_ action.ActionWithConfigure  = (*ClusterUpgradeAction)(nil)
+	_ action.ActionWithModifyPlan = (*ClusterUpgradeAction)(nil)
+)
+
+// ClusterUpgradeAction upgrades a Kubernetes cluster and its pools.
+type ClusterUpgradeAction struct {
+	k8sAPI *k8s.API
+	meta   *meta.Meta
+}
+
+func (a *ClusterUpgradeAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.meta = m
+	a.k8sAPI = k8s.NewAPI(m.ScwClient())
+}
+
+func (a *ClusterUpgradeAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_cluster_upgrade"
+}
+
+type ClusterUpgradeActionModel struct {
+	ClusterID                  types.String `tfsdk:"cluster_id"`
+	Region                     types.String `tfsdk:"region"`
+	Version                    types.String `tfsdk:"version"`
+	UpgradePools               types.Bool   `tfsdk:"upgrade_pools"`
+	PoolOrder                  types.List   `tfsdk:"pool_order"`
+	AllowMultipleMinorVersions types.Bool   `tfsdk:"allow_multiple_minor_versions"`
+	Timeout                    types.String `tfsdk:"timeout"`
+}
+
+// NewClusterUpgradeAction returns a new Kubernetes cluster upgrade action.
+func NewClusterUpgradeAction() action.Action {
+	return &ClusterUpgradeAction{}
+}
+
+//go:embed descriptions/cluster_upgrade_action.md
+var clusterUpgradeActionDescription string
+
+func (a *ClusterUpgradeAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: clusterUpgradeActionDescription,
+		Description:         clusterUpgradeActionDescription,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the cluster to upgrade. Can be a plain UUID or a regional ID.",
+				Validators: []validator.String{
+					verify.IsStringUUIDOrUUIDWithLocality(),
+				},
+			},
+			"region": regional.SchemaAttribute("Region of the cluster. If not set, the region is derived from the cluster_id when possible or from the provider configuration."),
+			"version": schema.StringAttribute{
+				Required:    true,
+				Description: "Kubernetes version to upgrade to, in the form x.y.z or x.y for the latest patch version of a minor version.",
+			},
+			"upgrade_pools": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Upgrade the pools after each control plane upgrade. Defaults to true.",
+			},
+			"pool_order": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Names of the pools to upgrade first, in order. The other pools are upgraded afterwards sorted by name. The names are checked against the pools of the cluster before the control plane is upgraded.",
+			},
+			"allow_multiple_minor_versions": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Allow an upgrade spanning several minor versions, the cluster is upgraded through each of them. Requires upgrade_pools.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum duration to wait for the control plane and for each pool after an upgrade, e.g. 45m. Defaults to 30m.",
+			},
+		},
+	}
+}
+
+// clusterUpgrade is an upgrade checked against the current state of the cluster
+type clusterUpgrade struct {
+	region    scw.Region
+	clusterID string
+	target    string
+	path      []string
+	strategy  *upgradeStrategy
+	timeout   time.Duration
+}
+
+// ModifyPlan runs the pre-flight checks at plan time, they are run again before upgrading.
+func (a *ClusterUpgradeAction) ModifyPlan(ctx context.Context, req action.ModifyPlanRequest, resp *action.ModifyPlanResponse) {
+	var data ClusterUpgradeActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || a.k8sAPI == nil {
+		return
+	}
+
+	// The cluster may not exist yet or its version may change before the action is invoked
+	if data.ClusterID.IsUnknown() || data.Region.IsUnknown() || data.Version.IsUnknown() || data.UpgradePools.IsUnknown() ||
+		data.PoolOrder.IsUnknown() || data.AllowMultipleMinorVersions.IsUnknown() || data.Timeout.IsUnknown() {
+		return
+	}
+
+	_, diags := a.planUpgrade(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (a *ClusterUpgradeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data ClusterUpgradeActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if a.k8sAPI == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured k8sAPI",
+			"The action was not properly configured. The Scaleway client is missing. "+
+				"This is usually a bug in the provider. Please report it to the maintainers.",
+		)
+
+		return
+	}
+
+	upgrade, diags := a.planUpgrade(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := upgradeCluster(ctx, a.k8sAPI, upgrade.region, upgrade.clusterID, upgrade.path, upgrade.strategy, upgrade.timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error executing Kubernetes cluster upgrade action",
+			fmt.Sprintf("Failed to upgrade cluster %s to %s: %s", upgrade.clusterID, upgrade.target, err),
+		)
+
+		return
+	}
+}
+
+// planUpgrade resolves the cluster and the target version and runs the pre-flight checks
+func (a *ClusterUpgradeAction) planUpgrade(ctx context.Context, data *ClusterUpgradeActionModel) (*clusterUpgrade, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	upgrade := &clusterUpgrade{
+		clusterID: locality.ExpandID(data.ClusterID.ValueString()),
+		timeout:   defaultK8SPoolTimeout,
+	}
+
+	if !data.Timeout.IsNull() && data.Timeout.ValueString() != "" {
+		timeout, err := time.ParseDuration(data.Timeout.ValueString())
+		if err != nil || timeout <= 0 {
+			diags.AddAttributeError(path.Root("timeout"), "Invalid timeout", fmt.Sprintf("%q is not a positive duration, e.g. 45m", data.Timeout.ValueString()))
+
+			return nil, diags
+		}
+
+		upgrade.timeout = timeout
+	}
+
+	if !data.Region.IsNull() && data.Region.ValueString() != "" {
+		upgrade.region = scw.Region(data.Region.ValueString())
+	} else {
+		if derivedRegion, id, parseErr := regional.ParseID(data.ClusterID.ValueString()); parseErr == nil {
+			upgrade.region = derivedRegion
+			upgrade.clusterID = id
+		} else {
+			defaultRegion, exists := a.meta.ScwClient().GetDefaultRegion()
+			if !exists {
+				diags.AddError(
+					"Missing region",
+					"The region attribute is required to upgrade a cluster. Please provide it explicitly or configure a default region in the provider.",
+				)
+
+				return nil, diags
+			}
+
+			upgrade.region = defaultRegion
+		}
+	}
+
+	upgrade.strategy = &upgradeStrategy{
+		UpgradePools:               data.UpgradePools.IsNull() || data.UpgradePools.ValueBool(),
+		AllowMultipleMinorVersions: data.AllowMultipleMinorVersions.ValueBool(),
+	}
+
+	if !data.PoolOrder.IsNull() {
+		diags.Append(data.PoolOrder.ElementsAs(ctx, &upgrade.strategy.PoolOrder, false)...)
+
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	cluster, err := a.k8sAPI.GetCluster(&k8s.GetClusterRequest{
+		Region:    upgrade.region,
+		ClusterID: upgrade.clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		diags.AddError(
+			"Error getting Kubernetes cluster",
+			fmt.Sprintf("Failed to get cluster %s: %s", upgrade.clusterID, err),
+		)
+
+		return nil, diags
+	}
+
+	upgrade.target, err = resolveTargetVersion(ctx, a.k8sAPI, upgrade.region, data.Version.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Invalid version",
+			err.Error(),
+		)
+
+		return nil, diags
+	}
+
+	upgrade.path, err = planClusterUpgrade(ctx, a.k8sAPI, upgrade.region, upgrade.clusterID, cluster.Version, upgrade.target, cluster.FeatureGates, cluster.AdmissionPlugins, upgrade.strategy)
+	if err != nil {
+		diags.AddError(
+			"Kubernetes cluster upgrade pre-flight checks failed",
+			err.Error(),
+		)
+
+		return nil, diags
+	}
+
+	return upgrade, diags
+}
@@ -1,18 +1,23 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ipam "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -134,3 +139,157 @@ func getClusterProjectID(ctx context.Context, k8sAPI *k8s.API, pool *k8s.Pool) (
 
 	return cluster.ProjectID, nil
 }
+
+// getClusterPrivateIP returns the IPv4 address of the control plane of the cluster in its private network, as
+// reserved in IPAM when the cluster was attached to the private network.
+func getClusterPrivateIP(ctx context.Context, ipamAPI *ipam.API, region scw.Region, cluster *k8s.Cluster) (*ipam.IP, error) {
+	if cluster.PrivateNetworkID == nil {
+		return nil, fmt.Errorf("cluster %s is not attached to a private network", cluster.ID)
+	}
+
+	res, err := ipamAPI.ListIPs(&ipam.ListIPsRequest{
+		Region:           region,
+		PrivateNetworkID: cluster.PrivateNetworkID,
+		ResourceType:     ipam.ResourceTypeK8sCluster,
+		ResourceID:       &cluster.ID,
+		IsIPv6:           new(false),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.IPs) == 0 {
+		return nil, fmt.Errorf("no private IP found for cluster %s in private network %s", cluster.ID, *cluster.PrivateNetworkID)
+	}
+
+	return res.IPs[0], nil
+}
+
+// PrivateEndpoint returns the API server URL reachable from the private network of the cluster, built from the private
+// IP of the control plane. The reverse DNS name of the IP is preferred when set, the scheme and port of the public URL are kept.
+func PrivateEndpoint(clusterURL string, privateIP *ipam.IP) (string, error) {
+	parsed, err := url.Parse(clusterURL)
+	if err != nil {
+		return "", err
+	}
+
+	if privateIP == nil || privateIP.Address.IP == nil {
+		return "", fmt.Errorf("cannot compute the private endpoint of %q without a private IP", clusterURL)
+	}
+
+	host := privateIP.Address.IP.String()
+	for _, reverse := range privateIP.Reverses {
+		if reverse != nil && reverse.Hostname != "" {
+			host = reverse.Hostname
+
+			break
+		}
+	}
+
+	port := parsed.Port()
+
+	parsed.Host = host
+	if port != "" {
+		parsed.Host = net.JoinHostPort(host, port)
+	}
+
+	return parsed.String(), nil
+}
+
+// RewriteKubeconfig replaces the server of every cluster and the token of every user of a kubeconfig file.
+// Empty values are left untouched.
+func RewriteKubeconfig(raw []byte, server string, token string) ([]byte, error) {
+	if server == "" && token == "" {
+		return raw, nil
+	}
+
+	document := yaml.Node{}
+
+	err := yaml.Unmarshal(raw, &document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	if len(document.Content) == 0 {
+		return nil, errors.New("kubeconfig is empty")
+	}
+
+	root := document.Content[0]
+
+	if server != "" {
+		for _, cluster := range kubeconfigEntries(root, "clusters", "cluster") {
+			setKubeconfigValue(cluster, "server", server)
+		}
+	}
+
+	if token != "" {
+		for _, user := range kubeconfigEntries(root, "users", "user") {
+			setKubeconfigValue(user, "token", token)
+		}
+	}
+
+	var rewritten bytes.Buffer
+
+	encoder := yaml.NewEncoder(&rewritten)
+	encoder.SetIndent(2)
+
+	err = encoder.Encode(&document)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return rewritten.Bytes(), nil
+}
+
+// kubeconfigEntries returns the inner mappings of a kubeconfig list, e.g. clusters[*].cluster
+func kubeconfigEntries(root *yaml.Node, listKey string, entryKey string) []*yaml.Node {
+	list := kubeconfigValue(root, listKey)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	entries := []*yaml.Node(nil)
+
+	for _, item := range list.Content {
+		if entry := kubeconfigValue(item, entryKey); entry != nil && entry.Kind == yaml.MappingNode {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+func kubeconfigValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func setKubeconfigValue(mapping *yaml.Node, key string, value string) {
+	if node := kubeconfigValue(mapping, key); node != nil {
+		node.Kind = yaml.ScalarNode
+		node.Tag = "!!str"
+		node.Value = value
+		node.Content = nil
+
+		return
+	}
+
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+}
@@ -0,0 +1,301 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+)
+
+const kubernetesAPIRequestTimeout = 30 * time.Second
+
+// kubernetesClient is a minimal client of the Kubernetes API of a cluster, authenticated with the cluster admin token
+type kubernetesClient struct {
+	server     string
+	token      string
+	httpClient *http.Client
+}
+
+type kubernetesObjectMeta struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	OwnerReferences []struct {
+		Kind string `json:"kind"`
+	} `json:"ownerReferences,omitempty"`
+}
+
+type kubernetesPod struct {
+	Metadata kubernetesObjectMeta `json:"metadata"`
+	Status   struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+type kubernetesAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *kubernetesAPIError) Error() string {
+	return fmt.Sprintf("kubernetes API returned %d: %s", e.StatusCode, e.Message)
+}
+
+func newKubernetesClient(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string) (*kubernetesClient, error) {
+	kubeconfig, err := k8sAPI.GetClusterKubeConfig(&k8s.GetClusterKubeConfigRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := kubeconfig.GetServer()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := kubeconfig.GetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	rawCA, err := kubeconfig.GetCertificateAuthorityData()
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := base64.StdEncoding.DecodeString(rawCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cluster CA certificate: %w", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(ca) {
+		return nil, errors.New("failed to parse cluster CA certificate")
+	}
+
+	return &kubernetesClient{
+		server: server,
+		token:  token,
+		httpClient: &http.Client{
+			Timeout: kubernetesAPIRequestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:    rootCAs,
+					MinVersion: tls.VersionTLS12,
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *kubernetesClient) do(ctx context.Context, method string, path string, contentType string, body any, out any) error {
+	var reqBody io.Reader
+
+	if body != nil {
+		rawBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(rawBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	rawResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return &kubernetesAPIError{
+			StatusCode: resp.StatusCode,
+			Message:    string(rawResp),
+		}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(rawResp, out)
+}
+
+func (c *kubernetesClient) cordonNode(ctx context.Context, nodeName string) error {
+	patch := map[string]any{
+		"spec": map[string]any{
+			"unschedulable": true,
+		},
+	}
+
+	return c.do(ctx, http.MethodPatch, "/api/v1/nodes/"+url.PathEscape(nodeName), "application/merge-patch+json", patch, nil)
+}
+
+func (c *kubernetesClient) listNodePods(ctx context.Context, nodeName string) ([]kubernetesPod, error) {
+	query := url.Values{}
+	query.Set("fieldSelector", "spec.nodeName="+nodeName)
+
+	pods := struct {
+		Items []kubernetesPod `json:"items"`
+	}{}
+
+	err := c.do(ctx, http.MethodGet, "/api/v1/pods?"+query.Encode(), "", nil, &pods)
+	if err != nil {
+		return nil, err
+	}
+
+	return pods.Items, nil
+}
+
+// evictPod evicts a pod through the eviction API so that pod disruption budgets are respected
+func (c *kubernetesClient) evictPod(ctx context.Context, pod kubernetesPod) error {
+	eviction := map[string]any{
+		"apiVersion": "policy/v1",
+		"kind":       "Eviction",
+		"metadata": kubernetesObjectMeta{
+			Name:      pod.Metadata.Name,
+			Namespace: pod.Metadata.Namespace,
+		},
+	}
+
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/eviction", url.PathEscape(pod.Metadata.Namespace), url.PathEscape(pod.Metadata.Name))
+
+	return c.do(ctx, http.MethodPost, path, "application/json", eviction, nil)
+}
+
+// isPodEvictable returns false for the pods a drain leaves on the node: DaemonSet pods, mirror pods and finished pods
+func isPodEvictable(pod kubernetesPod) bool {
+	if _, isMirror := pod.Metadata.Annotations["kubernetes.io/config.mirror"]; isMirror {
+		return false
+	}
+
+	if pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+		return false
+	}
+
+	for _, owner := range pod.Metadata.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// drainNode evicts the pods of a node until none is left. Evictions blocked by a pod disruption budget are retried.
+func (c *kubernetesClient) drainNode(ctx context.Context, nodeName string, timeout time.Duration) error {
+	retryInterval := defaultK8SRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		pods, err := c.listNodePods(ctx, nodeName)
+		if err != nil {
+			return fmt.Errorf("listing pods of node %s: %w", nodeName, err)
+		}
+
+		remaining := 0
+
+		for _, pod := range pods {
+			if !isPodEvictable(pod) {
+				continue
+			}
+
+			remaining++
+
+			err = c.evictPod(ctx, pod)
+
+			apiErr := &kubernetesAPIError{}
+			if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusTooManyRequests) {
+				// The pod is already gone or a pod disruption budget blocks the eviction for now
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf("evicting pod %s/%s: %w", pod.Metadata.Namespace, pod.Metadata.Name, err)
+			}
+		}
+
+		if remaining == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout draining node %s: %d pods left", nodeName, remaining)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// drainPool cordons every node of a pool then drains them one by one
+func drainPool(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, poolID string, timeout time.Duration) error {
+	nodes, err := k8sAPI.ListNodes(&k8s.ListNodesRequest{
+		Region:    region,
+		ClusterID: clusterID,
+		PoolID:    &poolID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	client, err := newKubernetesClient(ctx, k8sAPI, region, clusterID)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes.Nodes {
+		err = client.cordonNode(ctx, node.Name)
+		if err != nil {
+			return fmt.Errorf("cordoning node %s: %w", node.Name, err)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for _, node := range nodes.Nodes {
+		err = client.drainNode(ctx, node.Name, time.Until(deadline))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+//nolint:testpackage // Tests need access to the unexported Kubernetes client.
+package k8s
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const kubernetesStandInToken = "stand-in-token"
+
+// kubernetesStandIn is a local Kubernetes API serving the pods of a single node. The pods listed in blocked answer
+// 429 to their first eviction, like a pod protected by a pod disruption budget.
+type kubernetesStandIn struct {
+	sync.Mutex
+
+	pods          map[string]map[string]any
+	blocked       map[string]bool
+	cordonedNodes []string
+	evictions     []string
+}
+
+func newKubernetesStandIn(t *testing.T, pods []map[string]any, blocked ...string) (*kubernetesStandIn, *kubernetesClient) {
+	t.Helper()
+
+	standIn := &kubernetesStandIn{
+		pods:    map[string]map[string]any{},
+		blocked: map[string]bool{},
+	}
+
+	for _, pod := range pods {
+		name := pod["metadata"].(map[string]any)["name"].(string)
+		standIn.pods[name] = pod
+	}
+
+	for _, name := range blocked {
+		standIn.blocked[name] = true
+	}
+
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+
+	return standIn, &kubernetesClient{
+		server:     server.URL,
+		token:      kubernetesStandInToken,
+		httpClient: server.Client(),
+	}
+}
+
+func (s *kubernetesStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+kubernetesStandInToken {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/api/v1/nodes/"):
+		if r.Header.Get("Content-Type") != "application/merge-patch+json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+
+			return
+		}
+
+		s.cordonedNodes = append(s.cordonedNodes, strings.TrimPrefix(r.URL.Path, "/api/v1/nodes/"))
+		_, _ = w.Write([]byte("{}"))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/pods":
+		if r.URL.Query().Get("fieldSelector") != "spec.nodeName=node-1" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		items := make([]map[string]any, 0, len(s.pods))
+		for _, pod := range s.pods {
+			items = append(items, pod)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/eviction"):
+		name := strings.Split(r.URL.Path, "/")[6]
+
+		if s.blocked[name] {
+			delete(s.blocked, name)
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		if _, ok := s.pods[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		s.evictions = append(s.evictions, name)
+		delete(s.pods, name)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testKubernetesPod(name string, phase string, ownerKind string, annotations map[string]string) map[string]any {
+	metadata := map[string]any{
+		"name":        name,
+		"namespace":   "default",
+		"annotations": annotations,
+	}
+
+	if ownerKind != "" {
+		metadata["ownerReferences"] = []map[string]any{{"kind": ownerKind}}
+	}
+
+	return map[string]any{
+		"metadata": metadata,
+		"status":   map[string]any{"phase": phase},
+	}
+}
+
+func setTestRetryInterval(t *testing.T) {
+	t.Helper()
+
+	previous := transport.DefaultWaitRetryInterval
+	transport.DefaultWaitRetryInterval = new(time.Millisecond)
+
+	t.Cleanup(func() {
+		transport.DefaultWaitRetryInterval = previous
+	})
+}
+
+func TestKubernetesClientDrainNode(t *testing.T) {
+	setTestRetryInterval(t)
+
+	standIn, client := newKubernetesStandIn(t, []map[string]any{
+		testKubernetesPod("web", "Running", "ReplicaSet", nil),
+		testKubernetesPod("api", "Running", "ReplicaSet", nil),
+		testKubernetesPod("node-exporter", "Running", "DaemonSet", nil),
+		testKubernetesPod("kube-proxy", "Running", "", map[string]string{"kubernetes.io/config.mirror": "hash"}),
+		testKubernetesPod("migration", "Succeeded", "Job", nil),
+	}, "api")
+
+	require.NoError(t, client.cordonNode(t.Context(), "node-1"))
+	require.NoError(t, client.drainNode(t.Context(), "node-1", time.Minute))
+
+	standIn.Lock()
+	defer standIn.Unlock()
+
+	assert.Equal(t, []string{"node-1"}, standIn.cordonedNodes)
+	assert.ElementsMatch(t, []string{"web", "api"}, standIn.evictions)
+	assert.Contains(t, standIn.pods, "node-exporter")
+	assert.Contains(t, standIn.pods, "kube-proxy")
+	assert.Contains(t, standIn.pods, "migration")
+}
+
+func TestKubernetesClientDrainNodeTimeout(t *testing.T) {
+	setTestRetryInterval(t)
+
+	standIn, client := newKubernetesStandIn(t, []map[string]any{
+		testKubernetesPod("web", "Running", "ReplicaSet", nil),
+	}, "web")
+
+	// The only eviction is blocked and the deadline is already reached after the first attempt
+	err := client.drainNode(t.Context(), "node-1", 0)
+	require.ErrorContains(t, err, "timeout draining node node-1: 1 pods left")
+
+	standIn.Lock()
+	defer standIn.Unlock()
+
+	assert.Empty(t, standIn.evictions)
+}
+
+func TestKubernetesClientErrors(t *testing.T) {
+	_, client := newKubernetesStandIn(t, nil)
+	client.token = "revoked-token"
+
+	err := client.cordonNode(t.Context(), "node-1")
+
+	apiErr := &kubernetesAPIError{}
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
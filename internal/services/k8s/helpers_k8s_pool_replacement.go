@@ -0,0 +1,163 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+)
+
+const (
+	poolReplacementStrategyRecreate  = "recreate"
+	poolReplacementStrategyBlueGreen = "blue_green"
+
+	blueGreenPoolNameSuffix = "-green"
+)
+
+// poolReplacementAttributes cannot be updated in place, changing them replaces the pool
+var poolReplacementAttributes = []string{
+	"node_type",
+	"root_volume_type",
+	"zone",
+	"container_runtime",
+}
+
+// poolZoneSchema is the zone schema without ForceNew, the replacement is handled by ResourceK8SPoolCustomDiff
+func poolZoneSchema() *schema.Schema {
+	zoneSchema := zonal.Schema()
+	zoneSchema.ForceNew = false
+
+	return zoneSchema
+}
+
+// BlueGreenPoolName returns the name of the sibling pool created during a blue/green replacement.
+// Pool names alternate between the configured name and the configured name suffixed with -green.
+func BlueGreenPoolName(name string) string {
+	if trimmed, ok := strings.CutSuffix(name, blueGreenPoolNameSuffix); ok {
+		return trimmed
+	}
+
+	return name + blueGreenPoolNameSuffix
+}
+
+// flattenPoolName keeps the configured name of a pool that went through a blue/green replacement, the name of the
+// pool in the cluster is exposed in pool_name
+func flattenPoolName(configuredName string, poolName string) string {
+	if configuredName != "" && poolName == BlueGreenPoolName(configuredName) {
+		return configuredName
+	}
+
+	return poolName
+}
+
+// replacePoolBlueGreen creates a sibling pool with the new configuration, waits for its nodes, drains the old pool
+// and deletes it. The ID of the sibling pool replaces the ID of the old pool as soon as the sibling pool is ready,
+// the old pool is kept in pending_old_pool_id until it is deleted.
+func replacePoolBlueGreen(ctx context.Context, d *schema.ResourceData, m any, k8sAPI *k8s.API, region scw.Region, oldPoolID string) diag.Diagnostics {
+	req := expandPoolCreateRequest(d, m, region)
+	poolName := d.Get("pool_name").(string)
+	if poolName == "" {
+		poolName = d.Get("name").(string)
+	}
+
+	req.Name = BlueGreenPoolName(poolName)
+
+	diags := validateRootVolumeSpecs(ctx, m.(*meta.Meta).ScwClient(), req)
+	if diags.HasError() {
+		return diags
+	}
+
+	clusterID := locality.ExpandID(d.Get("cluster_id"))
+
+	_, err := waitCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	newPool, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
+	if err != nil {
+		return append(diags, diag.FromErr(fmt.Errorf("creating sibling pool %s: %w", req.Name, err))...)
+	}
+
+	_, err = waitPoolReady(ctx, k8sAPI, region, newPool.ID, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		// The old pool still runs the workloads, remove the sibling pool so that the next apply starts over
+		_, deleteErr := k8sAPI.DeletePool(&k8s.DeletePoolRequest{
+			Region: region,
+			PoolID: newPool.ID,
+		}, scw.WithContext(ctx))
+		if deleteErr != nil && !httperrors.Is404(deleteErr) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Could not delete sibling pool",
+				Detail:   fmt.Sprintf("pool %s must be deleted manually: %v", newPool.ID, deleteErr),
+			})
+		}
+
+		return append(diags, diag.FromErr(fmt.Errorf("sibling pool %s did not become ready, pool %s was kept: %w", req.Name, oldPoolID, err))...)
+	}
+
+	d.SetId(regional.NewIDString(region, newPool.ID))
+	_ = d.Set("pending_old_pool_id", regional.NewIDString(region, oldPoolID))
+
+	err = deleteReplacedPool(ctx, d, k8sAPI, region, clusterID, oldPoolID)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// deleteReplacedPool drains the pool replaced by a blue/green replacement and deletes it. pending_old_pool_id is
+// cleared once the pool is gone, it is left untouched on failure so the next apply resumes the drain and the deletion.
+func deleteReplacedPool(ctx context.Context, d *schema.ResourceData, k8sAPI *k8s.API, region scw.Region, clusterID string, oldPoolID string) error {
+	_, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
+		Region: region,
+		PoolID: oldPoolID,
+	}, scw.WithContext(ctx))
+	if httperrors.Is404(err) {
+		_ = d.Set("pending_old_pool_id", "")
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if d.Get("drain_on_replacement").(bool) {
+		err = drainPool(ctx, k8sAPI, region, clusterID, oldPoolID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return fmt.Errorf("draining old pool %s, the drain and the deletion are resumed on the next apply: %w", oldPoolID, err)
+		}
+	}
+
+	_, err = k8sAPI.DeletePool(&k8s.DeletePoolRequest{
+		Region: region,
+		PoolID: oldPoolID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return fmt.Errorf("deleting old pool %s, the deletion is resumed on the next apply: %w", oldPoolID, err)
+	}
+
+	_, err = k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
+		PoolID: oldPoolID,
+		Region: region,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return err
+	}
+
+	_ = d.Set("pending_old_pool_id", "")
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package k8s_test
+
+import (
+	"net"
+	"testing"
+
+	ipamSDK "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeconfig = `apiVersion: v1
+clusters:
+  - name: "k8s-test"
+    cluster:
+      certificate-authority-data: Y2E=
+      server: https://11111111-1111-1111-1111-111111111111.api.k8s.fr-par.scw.cloud:6443
+contexts:
+  - name: admin@k8s-test
+    context:
+      cluster: "k8s-test"
+      user: k8s-test-admin
+current-context: admin@k8s-test
+kind: Config
+preferences: {}
+users:
+  - name: k8s-test-admin
+    user:
+      token: admin-token
+`
+
+func TestPrivateEndpoint(t *testing.T) {
+	clusterURL := "https://11111111-1111-1111-1111-111111111111.api.k8s.fr-par.scw.cloud:6443"
+	privateIP := &ipamSDK.IP{
+		Address: scw.IPNet{IPNet: net.IPNet{IP: net.ParseIP("172.16.4.2"), Mask: net.CIDRMask(22, 32)}},
+	}
+
+	endpoint, err := k8s.PrivateEndpoint(clusterURL, privateIP)
+	require.NoError(t, err)
+	assert.Equal(t, "https://172.16.4.2:6443", endpoint)
+
+	privateIP.Reverses = []*ipamSDK.Reverse{{Hostname: "control-plane.my-pn.internal"}}
+
+	endpoint, err = k8s.PrivateEndpoint(clusterURL, privateIP)
+	require.NoError(t, err)
+	assert.Equal(t, "https://control-plane.my-pn.internal:6443", endpoint)
+
+	_, err = k8s.PrivateEndpoint(clusterURL, nil)
+	assert.ErrorContains(t, err, "cannot compute the private endpoint")
+}
+
+func TestRewriteKubeconfig(t *testing.T) {
+	t.Run("unchanged", func(t *testing.T) {
+		rewritten, err := k8s.RewriteKubeconfig([]byte(testKubeconfig), "", "")
+		require.NoError(t, err)
+		assert.Equal(t, testKubeconfig, string(rewritten))
+	})
+	t.Run("server-and-token", func(t *testing.T) {
+		rewritten, err := k8s.RewriteKubeconfig([]byte(testKubeconfig), "https://private:6443", "iam-secret-key")
+		require.NoError(t, err)
+		assert.Contains(t, string(rewritten), "server: https://private:6443")
+		assert.Contains(t, string(rewritten), "token: iam-secret-key")
+		assert.NotContains(t, string(rewritten), "admin-token")
+		assert.Contains(t, string(rewritten), "certificate-authority-data: Y2E=")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := k8s.RewriteKubeconfig([]byte("clusters: ["), "https://private:6443", "")
+		assert.ErrorContains(t, err, "failed to parse kubeconfig")
+	})
+}
+
+func TestBlueGreenPoolName(t *testing.T) {
+	assert.Equal(t, "workers-green", k8s.BlueGreenPoolName("workers"))
+	assert.Equal(t, "workers", k8s.BlueGreenPoolName("workers-green"))
+	assert.Equal(t, "workers", k8s.BlueGreenPoolName(k8s.BlueGreenPoolName("workers")))
+}
@@ -0,0 +1,372 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// upgradeStrategy describes how a cluster is upgraded from one version to another
+type upgradeStrategy struct {
+	// PoolOrder holds the names of the pools upgraded first, in order
+	PoolOrder []string
+	// AllowMultipleMinorVersions allows upgrades spanning more than one minor version
+	AllowMultipleMinorVersions bool
+	// UpgradePools upgrades the pools after each control plane upgrade
+	UpgradePools bool
+}
+
+// expandUpgradeStrategy returns nil when no upgrade_strategy block is set
+func expandUpgradeStrategy(raw any, upgradePools bool) *upgradeStrategy {
+	rawList, ok := raw.([]any)
+	if !ok || len(rawList) == 0 {
+		return nil
+	}
+
+	strategy := &upgradeStrategy{
+		UpgradePools: upgradePools,
+	}
+
+	rawStrategy, ok := rawList[0].(map[string]any)
+	if !ok {
+		return strategy
+	}
+
+	for _, name := range rawStrategy["pool_order"].([]any) {
+		strategy.PoolOrder = append(strategy.PoolOrder, name.(string))
+	}
+
+	strategy.AllowMultipleMinorVersions = rawStrategy["allow_multiple_minor_versions"].(bool)
+
+	return strategy
+}
+
+type semanticVersion struct {
+	major int
+	minor int
+	patch int
+}
+
+func parseVersion(name string) (semanticVersion, error) {
+	parts := strings.Split(name, ".")
+	if len(parts) != 3 {
+		return semanticVersion{}, fmt.Errorf("version name must contain 3 parts, got %q", name)
+	}
+
+	numbers := [3]int{}
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return semanticVersion{}, fmt.Errorf("invalid version %q: %w", name, err)
+		}
+
+		numbers[i] = number
+	}
+
+	return semanticVersion{major: numbers[0], minor: numbers[1], patch: numbers[2]}, nil
+}
+
+func (v semanticVersion) less(other semanticVersion) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+
+	return v.patch < other.patch
+}
+
+// UpgradePath returns the versions a cluster goes through to be upgraded from current to target, one minor version at a time.
+// Intermediate minor versions are reached with their latest available patch version.
+func UpgradePath(current string, target string, available []string) ([]string, error) {
+	currentVersion, err := parseVersion(current)
+	if err != nil {
+		return nil, err
+	}
+
+	targetVersion, err := parseVersion(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == target {
+		return nil, nil
+	}
+
+	if targetVersion.less(currentVersion) {
+		return nil, fmt.Errorf("cannot downgrade cluster from %s to %s", current, target)
+	}
+
+	if targetVersion.major != currentVersion.major {
+		return nil, fmt.Errorf("cannot upgrade cluster from %s to %s: major version upgrades are not supported", current, target)
+	}
+
+	if !slices.Contains(available, target) {
+		return nil, fmt.Errorf("version %s is not available", target)
+	}
+
+	// Latest available patch version of every minor version
+	latestPatches := map[int]semanticVersion{}
+
+	for _, name := range available {
+		version, err := parseVersion(name)
+		if err != nil || version.major != currentVersion.major {
+			continue
+		}
+
+		if latest, ok := latestPatches[version.minor]; !ok || latest.less(version) {
+			latestPatches[version.minor] = version
+		}
+	}
+
+	path := []string(nil)
+
+	for minor := currentVersion.minor + 1; minor < targetVersion.minor; minor++ {
+		latest, ok := latestPatches[minor]
+		if !ok {
+			return nil, fmt.Errorf("cannot upgrade cluster from %s to %s: no version available for %d.%d", current, target, currentVersion.major, minor)
+		}
+
+		path = append(path, fmt.Sprintf("%d.%d.%d", latest.major, latest.minor, latest.patch))
+	}
+
+	return append(path, target), nil
+}
+
+// countMinorHops returns the number of minor versions crossed by an upgrade path
+func countMinorHops(current string, path []string) int {
+	hops := 0
+	previous := current
+
+	for _, version := range path {
+		previousMinor, _ := GetMinorVersionFromFull(previous)
+		minor, _ := GetMinorVersionFromFull(version)
+
+		if previousMinor != minor {
+			hops++
+		}
+
+		previous = version
+	}
+
+	return hops
+}
+
+// CheckUpgradeHops rejects a path crossing several minor versions unless allowed. Pools cannot stay more than one
+// minor version behind the control plane, so such a path also requires the pools to be upgraded along the way.
+func CheckUpgradeHops(current string, target string, path []string, allowMultipleMinorVersions bool, upgradePools bool) error {
+	hops := countMinorHops(current, path)
+	if hops <= 1 {
+		return nil
+	}
+
+	if !allowMultipleMinorVersions {
+		return fmt.Errorf("upgrading from %s to %s crosses %d minor versions (%s), set allow_multiple_minor_versions to upgrade through each of them", current, target, hops, strings.Join(path, " -> "))
+	}
+
+	if !upgradePools {
+		return fmt.Errorf("upgrading from %s to %s crosses %d minor versions (%s), pools would fall more than one minor version behind the control plane: set upgrade_pools to true or upgrade one minor version at a time", current, target, hops, strings.Join(path, " -> "))
+	}
+
+	return nil
+}
+
+// UpgradeIncompatibilities returns the feature gates and admission plugins that are not available in version
+func UpgradeIncompatibilities(featureGates []string, admissionPlugins []string, version *k8s.Version) []string {
+	issues := []string(nil)
+
+	for _, featureGate := range featureGates {
+		if !slices.Contains(version.AvailableFeatureGates, featureGate) {
+			issues = append(issues, fmt.Sprintf("feature gate %q is not available in version %s", featureGate, version.Name))
+		}
+	}
+
+	for _, admissionPlugin := range admissionPlugins {
+		if !slices.Contains(version.AvailableAdmissionPlugins, admissionPlugin) {
+			issues = append(issues, fmt.Sprintf("admission plugin %q is not available in version %s", admissionPlugin, version.Name))
+		}
+	}
+
+	return issues
+}
+
+// SortPoolsForUpgrade returns the pools in upgrade order: pools named in order first, then the others sorted by name
+func SortPoolsForUpgrade(pools []*k8s.Pool, order []string) ([]*k8s.Pool, error) {
+	poolsByName := make(map[string]*k8s.Pool, len(pools))
+	for _, pool := range pools {
+		poolsByName[pool.Name] = pool
+	}
+
+	sorted := make([]*k8s.Pool, 0, len(pools))
+	ordered := map[string]bool{}
+
+	for _, name := range order {
+		pool, ok := poolsByName[name]
+		if !ok {
+			return nil, fmt.Errorf("pool %q of the upgrade order does not exist in the cluster", name)
+		}
+
+		if ordered[name] {
+			return nil, fmt.Errorf("pool %q is listed twice in the upgrade order", name)
+		}
+
+		ordered[name] = true
+
+		sorted = append(sorted, pool)
+	}
+
+	remaining := make([]*k8s.Pool, 0, len(pools)-len(sorted))
+
+	for _, pool := range pools {
+		if !ordered[pool.Name] {
+			remaining = append(remaining, pool)
+		}
+	}
+
+	sort.Slice(remaining, func(i, j int) bool {
+		return remaining[i].Name < remaining[j].Name
+	})
+
+	return append(sorted, remaining...), nil
+}
+
+// planClusterUpgrade computes the upgrade path from current to target and checks every version of the path
+// supports the feature gates and admission plugins of the cluster. The pools of the upgrade order are checked against
+// the pools of the cluster as well, so an unknown pool is reported before the control plane is upgraded.
+func planClusterUpgrade(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, current string, target string, featureGates []string, admissionPlugins []string, strategy *upgradeStrategy) ([]string, error) {
+	versionsResp, err := k8sAPI.ListVersions(&k8s.ListVersionsRequest{
+		Region: region,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]*k8s.Version, len(versionsResp.Versions))
+	available := make([]string, 0, len(versionsResp.Versions))
+
+	for _, version := range versionsResp.Versions {
+		versions[version.Name] = version
+		available = append(available, version.Name)
+	}
+
+	path, err := UpgradePath(current, target, available)
+	if err != nil {
+		return nil, err
+	}
+
+	err = CheckUpgradeHops(current, target, path, strategy.AllowMultipleMinorVersions, strategy.UpgradePools)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []string(nil)
+
+	for _, version := range path {
+		issues = append(issues, UpgradeIncompatibilities(featureGates, admissionPlugins, versions[version])...)
+	}
+
+	if len(issues) > 0 {
+		return nil, fmt.Errorf("cannot upgrade from %s to %s: %s", current, target, strings.Join(issues, ", "))
+	}
+
+	if strategy.UpgradePools && len(strategy.PoolOrder) > 0 {
+		poolsResp, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+			Region:    region,
+			ClusterID: clusterID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = SortPoolsForUpgrade(poolsResp.Pools, strategy.PoolOrder)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return path, nil
+}
+
+// upgradeCluster upgrades the control plane through every version of path. After each version the pools are
+// upgraded one by one following the strategy order, the upgrade stops on the first pool that does not become ready.
+func upgradeCluster(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, path []string, strategy *upgradeStrategy, timeout time.Duration) error {
+	for _, version := range path {
+		_, err := k8sAPI.UpgradeCluster(&k8s.UpgradeClusterRequest{
+			Region:       region,
+			ClusterID:    clusterID,
+			Version:      version,
+			UpgradePools: false,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("upgrading control plane to %s: %w", version, err)
+		}
+
+		_, err = waitCluster(ctx, k8sAPI, region, clusterID, timeout)
+		if err != nil {
+			return fmt.Errorf("waiting for control plane upgrade to %s: %w", version, err)
+		}
+
+		if !strategy.UpgradePools {
+			continue
+		}
+
+		poolsResp, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+			Region:    region,
+			ClusterID: clusterID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		pools, err := SortPoolsForUpgrade(poolsResp.Pools, strategy.PoolOrder)
+		if err != nil {
+			return err
+		}
+
+		for _, pool := range pools {
+			if pool.Version == version {
+				continue
+			}
+
+			_, err = k8sAPI.UpgradePool(&k8s.UpgradePoolRequest{
+				Region:  region,
+				PoolID:  pool.ID,
+				Version: version,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return fmt.Errorf("upgrading pool %s to %s: %w", pool.Name, version, err)
+			}
+
+			_, err = waitPoolReady(ctx, k8sAPI, region, pool.ID, timeout)
+			if err != nil {
+				return fmt.Errorf("pool %s did not become ready after upgrade to %s, remaining pools were not upgraded: %w", pool.Name, version, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveTargetVersion returns the latest patch version when version is a minor version (x.y)
+func resolveTargetVersion(ctx context.Context, k8sAPI *k8s.API, region scw.Region, version string) (string, error) {
+	if version == "" {
+		return "", errors.New("target version is empty")
+	}
+
+	if len(strings.Split(version, ".")) == 2 {
+		return k8sGetLatestVersionFromMinor(ctx, k8sAPI, region, version)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,107 @@
+package k8s_test
+
+import (
+	"testing"
+
+	k8sSDK "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradePath(t *testing.T) {
+	available := []string{"1.35.3", "1.35.1", "1.34.6", "1.34.5", "1.33.2", "1.32.9", "1.31.4"}
+
+	t.Run("patch", func(t *testing.T) {
+		path, err := k8s.UpgradePath("1.35.1", "1.35.3", available)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1.35.3"}, path)
+	})
+	t.Run("one-minor", func(t *testing.T) {
+		path, err := k8s.UpgradePath("1.34.5", "1.35.1", available)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1.35.1"}, path)
+	})
+	t.Run("several-minors", func(t *testing.T) {
+		path, err := k8s.UpgradePath("1.31.4", "1.35.3", available)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1.32.9", "1.33.2", "1.34.6", "1.35.3"}, path)
+	})
+	t.Run("same-version", func(t *testing.T) {
+		path, err := k8s.UpgradePath("1.35.3", "1.35.3", available)
+		require.NoError(t, err)
+		assert.Empty(t, path)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := k8s.UpgradePath("1.35.3", "1.34.6", available)
+		assert.ErrorContains(t, err, "cannot downgrade")
+
+		_, err = k8s.UpgradePath("1.35.3", "2.0.1", append(available, "2.0.1"))
+		assert.ErrorContains(t, err, "major version upgrades are not supported")
+
+		_, err = k8s.UpgradePath("1.34.5", "1.35.2", available)
+		assert.ErrorContains(t, err, "version 1.35.2 is not available")
+
+		_, err = k8s.UpgradePath("1.29.1", "1.31.4", available)
+		assert.ErrorContains(t, err, "no version available for 1.30")
+	})
+}
+
+func TestUpgradeIncompatibilities(t *testing.T) {
+	version := &k8sSDK.Version{
+		Name:                      "1.35.3",
+		AvailableFeatureGates:     []string{"HPAScaleToZero"},
+		AvailableAdmissionPlugins: []string{"AlwaysPullImages"},
+	}
+
+	assert.Empty(t, k8s.UpgradeIncompatibilities([]string{"HPAScaleToZero"}, []string{"AlwaysPullImages"}, version))
+	assert.Equal(t, []string{
+		`feature gate "InPlacePodVerticalScaling" is not available in version 1.35.3`,
+		`admission plugin "PodNodeSelector" is not available in version 1.35.3`,
+	}, k8s.UpgradeIncompatibilities([]string{"HPAScaleToZero", "InPlacePodVerticalScaling"}, []string{"PodNodeSelector"}, version))
+}
+
+func TestSortPoolsForUpgrade(t *testing.T) {
+	pools := []*k8sSDK.Pool{
+		{ID: "1", Name: "workers"},
+		{ID: "2", Name: "gpu"},
+		{ID: "3", Name: "system"},
+		{ID: "4", Name: "batch"},
+	}
+
+	poolNames := func(pools []*k8sSDK.Pool) []string {
+		names := make([]string, 0, len(pools))
+		for _, pool := range pools {
+			names = append(names, pool.Name)
+		}
+
+		return names
+	}
+
+	sorted, err := k8s.SortPoolsForUpgrade(pools, []string{"system", "workers"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"system", "workers", "batch", "gpu"}, poolNames(sorted))
+
+	sorted, err = k8s.SortPoolsForUpgrade(pools, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"batch", "gpu", "system", "workers"}, poolNames(sorted))
+
+	_, err = k8s.SortPoolsForUpgrade(pools, []string{"unknown"})
+	assert.ErrorContains(t, err, `pool "unknown" of the upgrade order does not exist`)
+
+	_, err = k8s.SortPoolsForUpgrade(pools, []string{"gpu", "gpu"})
+	assert.ErrorContains(t, err, "listed twice")
+}
+
+func TestCheckUpgradeHops(t *testing.T) {
+	path := []string{"1.33.2", "1.34.6", "1.35.3"}
+
+	require.NoError(t, k8s.CheckUpgradeHops("1.34.5", "1.35.3", []string{"1.35.3"}, false, false))
+	require.NoError(t, k8s.CheckUpgradeHops("1.32.9", "1.35.3", path, true, true))
+
+	err := k8s.CheckUpgradeHops("1.32.9", "1.35.3", path, false, true)
+	assert.ErrorContains(t, err, "set allow_multiple_minor_versions")
+
+	err = k8s.CheckUpgradeHops("1.32.9", "1.35.3", path, true, false)
+	assert.ErrorContains(t, err, "set upgrade_pools to true")
+}
@@ -0,0 +1,361 @@
+package k8s
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	ipam "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*KubeconfigEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*KubeconfigEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*KubeconfigEphemeralResource)(nil)
+)
+
+const (
+	defaultKubeconfigTokenTTL = time.Hour
+	// kubeconfigAccessKeyPrivateKey stores the access key of the minted API key between Open and Close
+	kubeconfigAccessKeyPrivateKey = "access_key"
+)
+
+type KubeconfigEphemeralResource struct {
+	k8sAPI  *k8s.API
+	iamAPI  *iam.API
+	ipamAPI *ipam.API
+	meta    *meta.Meta
+}
+
+func NewKubeconfigEphemeralResource() ephemeral.EphemeralResource {
+	return &KubeconfigEphemeralResource{}
+}
+
+func (r *KubeconfigEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	m, ok := req.ProviderData.(*meta.Meta)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *meta.Meta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	client := m.ScwClient()
+	r.k8sAPI = k8s.NewAPI(client)
+	r.iamAPI = iam.NewAPI(client)
+	r.ipamAPI = ipam.NewAPI(client)
+	r.meta = m
+}
+
+func (r *KubeconfigEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_kubeconfig"
+}
+
+type KubeconfigEphemeralResourceModel struct {
+	ClusterID       types.String        `tfsdk:"cluster_id"`
+	Region          types.String        `tfsdk:"region"`
+	PrivateEndpoint types.Bool          `tfsdk:"private_endpoint"`
+	IAMToken        *KubeconfigIAMToken `tfsdk:"iam_token"`
+	// Output
+	ConfigFile           types.String `tfsdk:"config_file"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	Token                types.String `tfsdk:"token"`
+	ExpiresAt            types.String `tfsdk:"expires_at"`
+}
+
+type KubeconfigIAMToken struct {
+	ApplicationID types.String `tfsdk:"application_id"`
+	UserID        types.String `tfsdk:"user_id"`
+	TTL           types.String `tfsdk:"ttl"`
+}
+
+//go:embed descriptions/kubeconfig_ephemeral_resource.md
+var kubeconfigEphemeralResourceDescription string
+
+func (r *KubeconfigEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         kubeconfigEphemeralResourceDescription,
+		MarkdownDescription: kubeconfigEphemeralResourceDescription,
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the cluster. Can be a plain UUID or a regional ID.",
+				Validators: []validator.String{
+					verify.IsStringUUIDOrUUIDWithLocality(),
+				},
+			},
+			"region": regional.SchemaAttribute("Region of the cluster. If not set, the region is derived from the cluster_id when possible or from the provider configuration."),
+			"private_endpoint": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Use the API server endpoint of the private network of the cluster instead of the public one. The endpoint is built from the private IP of the control plane reserved in IPAM, the cluster must be attached to a private network.",
+			},
+			"iam_token": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Authenticate with a short-lived IAM API key instead of the cluster admin token. The API key is deleted when Terraform no longer needs the kubeconfig.",
+				Attributes: map[string]schema.Attribute{
+					"application_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "ID of the application the API key is created for. Its IAM policies define what the kubeconfig can do.",
+						Validators: []validator.String{
+							verify.IsStringUUID(),
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("user_id")),
+						},
+					},
+					"user_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "ID of the user the API key is created for. Its IAM policies define what the kubeconfig can do.",
+						Validators: []validator.String{
+							verify.IsStringUUID(),
+						},
+					},
+					"ttl": schema.StringAttribute{
+						Optional:    true,
+						Description: "Lifetime of the API key, e.g. 30m. Defaults to 1h.",
+					},
+				},
+			},
+			"config_file": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The whole kubeconfig file",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The Kubernetes API server URL",
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Description: "The Kubernetes cluster CA certificate",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token used to authenticate to the cluster",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date and time (UTC) of the expiration of the IAM token, empty when the admin token is used",
+			},
+		},
+	}
+}
+
+func (r *KubeconfigEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data KubeconfigEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.k8sAPI == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured k8sAPI",
+			"The ephemeral resource was not properly configured. The Scaleway client is missing. "+
+				"This is usually a bug in the provider. Please report it to the maintainers.",
+		)
+
+		return
+	}
+
+	clusterID := locality.ExpandID(data.ClusterID.ValueString())
+
+	var region scw.Region
+
+	if !data.Region.IsNull() && data.Region.ValueString() != "" {
+		region = scw.Region(data.Region.ValueString())
+	} else {
+		if derivedRegion, id, parseErr := regional.ParseID(data.ClusterID.ValueString()); parseErr == nil {
+			region = derivedRegion
+			clusterID = id
+		} else {
+			defaultRegion, exists := r.meta.ScwClient().GetDefaultRegion()
+			if !exists {
+				resp.Diagnostics.AddError(
+					"Missing region",
+					"The region attribute is required to read a kubeconfig. Please provide it explicitly or configure a default region in the provider.",
+				)
+
+				return
+			}
+
+			region = defaultRegion
+		}
+	}
+
+	kubeconfig, err := r.k8sAPI.GetClusterKubeConfig(&k8s.GetClusterKubeConfigRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Kubernetes cluster kubeconfig",
+			fmt.Sprintf("Failed to get kubeconfig of cluster %s: %s", clusterID, err),
+		)
+
+		return
+	}
+
+	host, err := kubeconfig.GetServer()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid kubeconfig", err.Error())
+
+		return
+	}
+
+	caCertificate, err := kubeconfig.GetCertificateAuthorityData()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid kubeconfig", err.Error())
+
+		return
+	}
+
+	token, err := kubeconfig.GetToken()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid kubeconfig", err.Error())
+
+		return
+	}
+
+	server := ""
+
+	if data.PrivateEndpoint.ValueBool() {
+		cluster, err := r.k8sAPI.GetCluster(&k8s.GetClusterRequest{
+			Region:    region,
+			ClusterID: clusterID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading Kubernetes cluster",
+				fmt.Sprintf("Failed to get cluster %s: %s", clusterID, err),
+			)
+
+			return
+		}
+
+		privateIP, err := getClusterPrivateIP(ctx, r.ipamAPI, region, cluster)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot use the private endpoint", err.Error())
+
+			return
+		}
+
+		server, err = PrivateEndpoint(host, privateIP)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot use the private endpoint", err.Error())
+
+			return
+		}
+
+		host = server
+	}
+
+	data.ExpiresAt = types.StringValue("")
+
+	if data.IAMToken != nil {
+		ttl := defaultKubeconfigTokenTTL
+
+		if !data.IAMToken.TTL.IsNull() && data.IAMToken.TTL.ValueString() != "" {
+			ttl, err = time.ParseDuration(data.IAMToken.TTL.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid ttl value", err.Error())
+
+				return
+			}
+		}
+
+		expiresAt := time.Now().Add(ttl).UTC()
+
+		apiKey, err := r.iamAPI.CreateAPIKey(&iam.CreateAPIKeyRequest{
+			ApplicationID: data.IAMToken.ApplicationID.ValueStringPointer(),
+			UserID:        data.IAMToken.UserID.ValueStringPointer(),
+			ExpiresAt:     &expiresAt,
+			Description:   "Kubeconfig of cluster " + clusterID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating IAM API key for kubeconfig",
+				err.Error(),
+			)
+
+			return
+		}
+
+		rawAccessKey, err := json.Marshal(apiKey.AccessKey)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid private state", err.Error())
+
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, kubeconfigAccessKeyPrivateKey, rawAccessKey)...)
+
+		token = *apiKey.SecretKey
+		data.ExpiresAt = types.StringValue(expiresAt.Format(time.RFC3339))
+	}
+
+	configFile, err := RewriteKubeconfig(kubeconfig.GetRaw(), server, token)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid kubeconfig", err.Error())
+
+		return
+	}
+
+	data.ConfigFile = types.StringValue(string(configFile))
+	data.Host = types.StringValue(host)
+	data.ClusterCACertificate = types.StringValue(caCertificate)
+	data.Token = types.StringValue(token)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close deletes the IAM API key minted by Open
+func (r *KubeconfigEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	rawAccessKey, diags := req.Private.GetKey(ctx, kubeconfigAccessKeyPrivateKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || len(rawAccessKey) == 0 {
+		return
+	}
+
+	accessKey := ""
+
+	err := json.Unmarshal(rawAccessKey, &accessKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid private state", err.Error())
+
+		return
+	}
+
+	err = r.iamAPI.DeleteAPIKey(&iam.DeleteAPIKeyRequest{
+		AccessKey: accessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Error deleting kubeconfig IAM API key",
+			fmt.Sprintf("API key %s will expire on its own: %s", accessKey, err),
+		)
+	}
+}
@@ -0,0 +1,104 @@
+package k8s_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	secrettestfuncs "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/secret/testfuncs"
+	vpcchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc/testfuncs"
+)
+
+func TestAccKubeconfigEphemeralResource_Basic(t *testing.T) {
+	if acctest.IsRunningOpenTofu() {
+		t.Skip("Skipping TestAccKubeconfigEphemeralResource_Basic because testing Ephemeral Resources is not yet supported on OpenTofu")
+	}
+
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestK8SVersion := testAccK8SClusterGetLatestK8SVersion(tt)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckK8SClusterDestroy(tt),
+			vpcchecks.CheckPrivateNetworkDestroy(tt),
+			secrettestfuncs.CheckSecretDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "scaleway_vpc_private_network" "main" {
+						name = "test-kubeconfig-ephemeral"
+					}
+
+					resource "scaleway_k8s_cluster" "main" {
+						name                        = "test-kubeconfig-ephemeral"
+						version                     = "%s"
+						cni                         = "cilium"
+						private_network_id          = scaleway_vpc_private_network.main.id
+						delete_additional_resources = true
+						ephemeral_kubeconfig        = true
+					}
+
+					ephemeral "scaleway_k8s_kubeconfig" "main" {
+						cluster_id = scaleway_k8s_cluster.main.id
+					}
+
+					resource "scaleway_secret" "main" {
+						name = "test-kubeconfig-ephemeral"
+					}
+
+					resource "scaleway_secret_version" "host" {
+						secret_id = scaleway_secret.main.id
+						data_wo   = ephemeral.scaleway_k8s_kubeconfig.main.host
+					}
+
+					data "scaleway_secret_version" "host" {
+						secret_id  = scaleway_secret.main.id
+						revision   = "1"
+						depends_on = [scaleway_secret_version.host]
+					}
+				`, latestK8SVersion),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckK8SClusterExists(tt, "scaleway_k8s_cluster.main"),
+					resource.TestCheckResourceAttr("scaleway_k8s_cluster.main", "ephemeral_kubeconfig", "true"),
+					resource.TestCheckResourceAttr("scaleway_k8s_cluster.main", "kubeconfig.0.config_file", ""),
+					resource.TestCheckResourceAttr("scaleway_k8s_cluster.main", "kubeconfig.0.token", ""),
+					resource.TestCheckResourceAttrSet("scaleway_k8s_cluster.main", "kubeconfig.0.host"),
+					testAccCheckK8SSecretVersionDataEquals("data.scaleway_secret_version.host", "scaleway_k8s_cluster.main", "kubeconfig.0.host"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckK8SSecretVersionDataEquals checks that the decoded data of a secret version equals an attribute of a resource
+func testAccCheckK8SSecretVersionDataEquals(secretVersion string, n string, attribute string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		secretRs, ok := state.RootModule().Resources[secretVersion]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", secretVersion)
+		}
+
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		data, err := base64.StdEncoding.DecodeString(secretRs.Primary.Attributes["data"])
+		if err != nil {
+			return fmt.Errorf("failed to decode the data of %s: %w", secretVersion, err)
+		}
+
+		if expected := rs.Primary.Attributes[attribute]; string(data) != expected {
+			return fmt.Errorf("data of %s is %q, expected %s of %s (%q)", secretVersion, data, attribute, n, expected)
+		}
+
+		return nil
+	}
+}
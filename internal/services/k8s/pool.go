@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -55,6 +56,12 @@ func ResourcePool() *schema.Resource {
 
 func poolSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		// id is declared to be planned as unknown when a blue_green replacement swaps the pool
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the pool",
+		},
 		"cluster_id": {
 			Type:        schema.TypeString,
 			Required:    true,
@@ -67,10 +74,14 @@ func poolSchema() map[string]*schema.Schema {
 			ForceNew:    true,
 			Description: "The name of the pool",
 		},
+		"pool_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the pool in the cluster, it alternates between name and name suffixed with -green on each blue_green replacement",
+		},
 		"node_type": {
 			Type:             schema.TypeString,
 			Required:         true,
-			ForceNew:         true,
 			Description:      "Server type of the pool servers",
 			DiffSuppressFunc: dsf.IgnoreCaseAndHyphen,
 		},
@@ -115,10 +126,27 @@ func poolSchema() map[string]*schema.Schema {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Default:          k8s.RuntimeContainerd.String(),
-			ForceNew:         true,
 			Description:      "Container runtime for the pool",
 			ValidateDiagFunc: verify.ValidateEnum[k8s.Runtime](),
 		},
+		"replacement_strategy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      poolReplacementStrategyRecreate,
+			Description:  "How the pool is replaced when node_type, root_volume_type, zone or container_runtime change: recreate destroys the pool before creating the new one, blue_green creates a sibling pool and deletes the old one once the new nodes are ready",
+			ValidateFunc: validation.StringInSlice([]string{poolReplacementStrategyRecreate, poolReplacementStrategyBlueGreen}, false),
+		},
+		"drain_on_replacement": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Cordon and drain the nodes of the old pool through the Kubernetes API before deleting it during a blue_green replacement",
+		},
+		"pending_old_pool_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the pool replaced by an interrupted blue_green replacement, it is drained and deleted by the next apply",
+		},
 		"wait_for_pool_ready": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -166,7 +194,6 @@ func poolSchema() map[string]*schema.Schema {
 		"root_volume_type": {
 			Type:             schema.TypeString,
 			Optional:         true,
-			ForceNew:         true,
 			Computed:         true,
 			Description:      "System volume type of the nodes composing the pool",
 			ValidateDiagFunc: verify.ValidateEnum[k8s.PoolVolumeType](),
@@ -241,7 +268,7 @@ func poolSchema() map[string]*schema.Schema {
 				},
 			},
 		},
-		"zone":   zonal.Schema(),
+		"zone":   poolZoneSchema(),
 		"region": regional.Schema(),
 		// Computed elements
 		"created_at": {
@@ -346,6 +373,61 @@ func ResourceK8SPoolCreate(ctx context.Context, d *schema.ResourceData, m any) d
 	////
 	// Create pool
 	////
+	req := expandPoolCreateRequest(d, m, region)
+
+	// Validate pool configuration
+	diags := validateRootVolumeSpecs(ctx, m.(*meta.Meta).ScwClient(), req)
+	if diags.HasError() {
+		return diags
+	}
+
+	clusterID := locality.ExpandID(d.Get("cluster_id"))
+
+	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
+		ClusterID: clusterID,
+		Region:    region,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	diags = append(diags, validatePoolSize(ctx, k8sAPI, cluster, "", req)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Check if the cluster is waiting for a pool
+	if cluster.Status == k8s.ClusterStatusCreating {
+		_, err = waitClusterStatus(ctx, k8sAPI, cluster, k8s.ClusterStatusReady, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	d.SetId(regional.NewIDString(region, res.ID))
+
+	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
+		_, err = waitPoolReady(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	_, err = waitCluster(ctx, k8sAPI, region, cluster.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceK8SPoolRead(ctx, d, m)
+}
+
+// expandPoolCreateRequest builds the creation request of a pool from its configuration
+func expandPoolCreateRequest(d *schema.ResourceData, m any, region scw.Region) *k8s.CreatePoolRequest {
 	req := &k8s.CreatePoolRequest{
 		Region:           region,
 		ClusterID:        locality.ExpandID(d.Get("cluster_id")),
@@ -426,55 +508,7 @@ func ResourceK8SPoolCreate(ctx context.Context, d *schema.ResourceData, m any) d
 		req.Taints = expandCoreV1Taints(startupTaints)
 	}
 
-	// Validate pool configuration
-	diags := validateRootVolumeSpecs(ctx, m.(*meta.Meta).ScwClient(), req)
-	if diags.HasError() {
-		return diags
-	}
-
-	clusterID := locality.ExpandID(d.Get("cluster_id"))
-
-	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
-		ClusterID: clusterID,
-		Region:    region,
-	}, scw.WithContext(ctx))
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
-	}
-
-	diags = append(diags, validatePoolSize(ctx, k8sAPI, cluster, "", req)...)
-	if diags.HasError() {
-		return diags
-	}
-
-	// Check if the cluster is waiting for a pool
-	if cluster.Status == k8s.ClusterStatusCreating {
-		_, err = waitClusterStatus(ctx, k8sAPI, cluster, k8s.ClusterStatusReady, d.Timeout(schema.TimeoutCreate))
-		if err != nil {
-			return append(diags, diag.FromErr(err)...)
-		}
-	}
-
-	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
-	}
-
-	d.SetId(regional.NewIDString(region, res.ID))
-
-	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
-		_, err = waitPoolReady(ctx, k8sAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	_, err = waitCluster(ctx, k8sAPI, region, cluster.ID, d.Timeout(schema.TimeoutCreate))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return ResourceK8SPoolRead(ctx, d, m)
+	return req
 }
 
 func ResourceK8SPoolRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -507,8 +541,21 @@ func ResourceK8SPoolRead(ctx context.Context, d *schema.ResourceData, m any) dia
 		return diag.FromErr(err)
 	}
 
+	if pendingOldPoolID := d.Get("pending_old_pool_id").(string); pendingOldPoolID != "" {
+		_, err = k8sAPI.GetPool(&k8s.GetPoolRequest{
+			Region: region,
+			PoolID: locality.ExpandID(pendingOldPoolID),
+		}, scw.WithContext(ctx))
+		if httperrors.Is404(err) {
+			_ = d.Set("pending_old_pool_id", "")
+		} else if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	_ = d.Set("cluster_id", regional.NewIDString(region, pool.ClusterID))
-	_ = d.Set("name", pool.Name)
+	_ = d.Set("name", flattenPoolName(d.Get("name").(string), pool.Name))
+	_ = d.Set("pool_name", pool.Name)
 	_ = d.Set("node_type", pool.NodeType)
 	_ = d.Set("autoscaling", pool.Autoscaling)
 	_ = d.Set("autohealing", pool.Autohealing)
@@ -613,6 +660,26 @@ func ResourceK8SPoolUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 		return diag.FromErr(err)
 	}
 
+	// Resume the blue_green replacement interrupted while the old pool was drained or deleted
+	if pendingOldPoolID, _ := d.GetChange("pending_old_pool_id"); pendingOldPoolID.(string) != "" {
+		err = deleteReplacedPool(ctx, d, k8sAPI, region, locality.ExpandID(d.Get("cluster_id")), locality.ExpandID(pendingOldPoolID))
+		if err != nil {
+			_ = d.Set("pending_old_pool_id", pendingOldPoolID)
+
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges(poolReplacementAttributes...) {
+		// Only reachable with the blue_green strategy, the diff forces a new resource otherwise
+		diags := replacePoolBlueGreen(ctx, d, m, k8sAPI, region, poolID)
+		if diags.HasError() {
+			return diags
+		}
+
+		return append(diags, ResourceK8SPoolRead(ctx, d, m)...)
+	}
+
 	////
 	// Update Pool
 	////
@@ -760,27 +827,34 @@ func ResourceK8SPoolDelete(ctx context.Context, d *schema.ResourceData, m any) d
 	}
 
 	////
-	// Delete Pool
+	// Delete Pool, along with the pool left by an interrupted blue_green replacement
 	////
-	req := &k8s.DeletePoolRequest{
-		Region: region,
-		PoolID: poolID,
+	poolIDs := []string{poolID}
+	if pendingOldPoolID := d.Get("pending_old_pool_id").(string); pendingOldPoolID != "" {
+		poolIDs = append(poolIDs, locality.ExpandID(pendingOldPoolID))
 	}
 
-	_, err = k8sAPI.DeletePool(req, scw.WithContext(ctx))
-	if err != nil {
-		if !httperrors.Is404(err) {
-			return diag.FromErr(err)
+	for _, id := range poolIDs {
+		req := &k8s.DeletePoolRequest{
+			Region: region,
+			PoolID: id,
 		}
-	}
 
-	_, err = k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
-		PoolID: poolID,
-		Region: region,
-	}, scw.WithContext(ctx))
-	if err != nil {
-		if !httperrors.Is404(err) {
-			return diag.FromErr(err)
+		_, err = k8sAPI.DeletePool(req, scw.WithContext(ctx))
+		if err != nil {
+			if !httperrors.Is404(err) {
+				return diag.FromErr(err)
+			}
+		}
+
+		_, err = k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
+			PoolID: id,
+			Region: region,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if !httperrors.Is404(err) {
+				return diag.FromErr(err)
+			}
 		}
 	}
 
@@ -795,6 +869,43 @@ func ResourceK8SPoolCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ a
 		}
 	}
 
+	if diff.Id() == "" {
+		return nil
+	}
+
+	// A pool left by an interrupted blue_green replacement is drained and deleted by the next apply
+	if diff.Get("pending_old_pool_id").(string) != "" {
+		err := diff.SetNew("pending_old_pool_id", "")
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range poolReplacementAttributes {
+		if !diff.HasChange(key) {
+			continue
+		}
+
+		if diff.Get("replacement_strategy").(string) != poolReplacementStrategyBlueGreen {
+			err := diff.ForceNew(key)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// The sibling pool comes with a new ID, name and nodes
+		for _, computedKey := range []string{"id", "pool_name", "nodes", "status", "created_at", "updated_at", "current_size"} {
+			err := diff.SetNewComputed(computedKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
 	return nil
 }
 
@@ -21,6 +21,9 @@ func DataSourcePool() *schema.Resource {
 	// Generate datasource schema from resource
 	dsSchema := datasource.SchemaFromResourceSchema(ResourcePool().SchemaFunc())
 
+	delete(dsSchema, "replacement_strategy")
+	delete(dsSchema, "drain_on_replacement")
+
 	// Set 'Optional' schema elements
 	datasource.AddOptionalFieldsToSchema(dsSchema, "name", "region", "cluster_id", "size")
 
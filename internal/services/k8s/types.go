@@ -88,7 +88,8 @@ func flattenKubeletArgs(args map[string]string) map[string]any {
 	return kubeletArgs
 }
 
-func flattenKubeconfig(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string) (map[string]any, error) {
+// flattenKubeconfig returns the kubeconfig block, the kubeconfig file and the admin token are only set when withCredentials is true
+func flattenKubeconfig(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, withCredentials bool) (map[string]any, error) {
 	kubeconfig, err := k8sAPI.GetClusterKubeConfig(&k8s.GetClusterKubeConfigRequest{
 		Region:    region,
 		ClusterID: clusterID,
@@ -107,15 +108,23 @@ func flattenKubeconfig(ctx context.Context, k8sAPI *k8s.API, region scw.Region,
 		return nil, err
 	}
 
+	kubeconf := map[string]any{}
+	kubeconf["host"] = kubeconfigServer
+	kubeconf["cluster_ca_certificate"] = kubeconfigCa
+
+	if !withCredentials {
+		kubeconf["config_file"] = ""
+		kubeconf["token"] = ""
+
+		return kubeconf, nil
+	}
+
 	kubeconfigToken, err := kubeconfig.GetToken()
 	if err != nil {
 		return nil, err
 	}
 
-	kubeconf := map[string]any{}
 	kubeconf["config_file"] = string(kubeconfig.GetRaw())
-	kubeconf["host"] = kubeconfigServer
-	kubeconf["cluster_ca_certificate"] = kubeconfigCa
 	kubeconf["token"] = kubeconfigToken
 
 	return kubeconf, nil
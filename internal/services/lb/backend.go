@@ -98,6 +98,12 @@ func backendSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Backend server IP addresses list (IPv4 or IPv6)",
 		},
+		"ignore_external_servers": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Only manage the servers listed in server_ips and keep the servers attached outside of this resource, e.g. with scaleway_lb_backend_server",
+		},
 		"send_proxy_v2": {
 			Type:        schema.TypeBool,
 			Description: "Enables PROXY protocol version 2",
@@ -517,7 +523,12 @@ func setBackendState(d *schema.ResourceData, backend *lbSDK.Backend, zone scw.Zo
 	_ = d.Set("forward_port_algorithm", flattenLbForwardPortAlgorithm(backend.ForwardPortAlgorithm))
 	_ = d.Set("sticky_sessions", flattenLbStickySessionsType(backend.StickySessions))
 	_ = d.Set("sticky_sessions_cookie_name", backend.StickySessionsCookieName)
-	_ = d.Set("server_ips", backend.Pool)
+	if d.Get("ignore_external_servers").(bool) {
+		_ = d.Set("server_ips", FilterBackendServers(backend.Pool, types.ExpandStrings(d.Get("server_ips"))))
+	} else {
+		_ = d.Set("server_ips", backend.Pool)
+	}
+
 	_ = d.Set("proxy_protocol", flattenLbProxyProtocol(backend.ProxyProtocol))
 	_ = d.Set("timeout_server", types.FlattenDuration(backend.TimeoutServer))
 	_ = d.Set("timeout_connect", types.FlattenDuration(backend.TimeoutConnect))
@@ -670,13 +681,40 @@ func resourceLbBackendUpdate(ctx context.Context, d *schema.ResourceData, m any)
 	}
 
 	// Update Backend servers
-	_, err = lbAPI.SetBackendServers(&lbSDK.ZonedAPISetBackendServersRequest{
-		Zone:      zone,
-		BackendID: ID,
-		ServerIP:  types.ExpandStrings(d.Get("server_ips")),
-	}, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
+	if d.Get("ignore_external_servers").(bool) {
+		oldIPs, newIPs := d.GetChange("server_ips")
+		toRemove, toAdd := BackendServersCompare(types.ExpandStrings(oldIPs), types.ExpandStrings(newIPs))
+
+		if len(toRemove) > 0 {
+			_, err = lbAPI.RemoveBackendServers(&lbSDK.ZonedAPIRemoveBackendServersRequest{
+				Zone:      zone,
+				BackendID: ID,
+				ServerIP:  toRemove,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if len(toAdd) > 0 {
+			_, err = lbAPI.AddBackendServers(&lbSDK.ZonedAPIAddBackendServersRequest{
+				Zone:      zone,
+				BackendID: ID,
+				ServerIP:  toAdd,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else {
+		_, err = lbAPI.SetBackendServers(&lbSDK.ZonedAPISetBackendServersRequest{
+			Zone:      zone,
+			BackendID: ID,
+			ServerIP:  types.ExpandStrings(d.Get("server_ips")),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	_, err = waitForLB(ctx, lbAPI, zone, lbID, d.Timeout(schema.TimeoutUpdate))
@@ -0,0 +1,210 @@
+package lb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+)
+
+func lbBackendServerIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"zone":       identity.DefaultZoneAttribute(),
+		"backend_id": {Type: schema.TypeString, Description: "The backend ID", RequiredForImport: true},
+		"ip":         {Type: schema.TypeString, Description: "The IP address of the backend server", RequiredForImport: true},
+	})
+}
+
+func ResourceBackendServer() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLbBackendServerCreate,
+		ReadContext:   resourceLbBackendServerRead,
+		DeleteContext: resourceLbBackendServerDelete,
+		Identity:      lbBackendServerIdentity(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultLbLbTimeout),
+			Read:    schema.DefaultTimeout(defaultLbLbTimeout),
+			Delete:  schema.DefaultTimeout(defaultLbLbTimeout),
+			Default: schema.DefaultTimeout(defaultLbLbTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    backendServerSchema,
+	}
+}
+
+func backendServerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"backend_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The backend ID to attach the server to",
+		},
+		"ip": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsIPAddress,
+			Description:  "The IP address of the server to attach (IPv4 or IPv6)",
+		},
+		"zone": zonal.Schema(),
+		// Computed
+		"lb_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The load-balancer ID of the backend",
+		},
+	}
+}
+
+func resourceLbBackendServerCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	lbAPI, zone, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// backend_id may be a plain ID, in which case the zone of the provider is used
+	backendExpand := zonal.ExpandID(d.Get("backend_id").(string))
+	if backendExpand.Zone != "" {
+		zone = backendExpand.Zone
+	}
+
+	backendID := backendExpand.ID
+
+	backend, err := lbAPI.GetBackend(&lbSDK.ZonedAPIGetBackendRequest{
+		Zone:      zone,
+		BackendID: backendID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForLB(ctx, lbAPI, zone, backend.LB.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ip := d.Get("ip").(string)
+
+	_, err = lbAPI.AddBackendServers(&lbSDK.ZonedAPIAddBackendServersRequest{
+		Zone:      zone,
+		BackendID: backendID,
+		ServerIP:  []string{ip},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForLB(ctx, lbAPI, zone, backend.LB.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetMultiPartIdentity(d, map[string]string{
+		"zone":       zone.String(),
+		"backend_id": backendID,
+		"ip":         ip,
+	}, "zone", "backend_id", "ip")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLbBackendServerRead(ctx, d, m)
+}
+
+func resourceLbBackendServerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	lbAPI, _, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zone, backendID, ip, err := ResourceLBBackendServerParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backend, err := lbAPI.GetBackend(&lbSDK.ZonedAPIGetBackendRequest{
+		Zone:      zone,
+		BackendID: backendID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if !backendServerIPsContain(backend.Pool, ip) {
+		d.SetId("")
+
+		return nil
+	}
+
+	_ = d.Set("backend_id", zonal.NewIDString(zone, backend.ID))
+	_ = d.Set("ip", ip)
+	_ = d.Set("lb_id", zonal.NewIDString(zone, backend.LB.ID))
+	_ = d.Set("zone", zone)
+
+	return diag.FromErr(identity.SetMultiPartIdentity(d, map[string]string{
+		"zone":       zone.String(),
+		"backend_id": backend.ID,
+		"ip":         ip,
+	}, "zone", "backend_id", "ip"))
+}
+
+func resourceLbBackendServerDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	lbAPI, _, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zone, backendID, ip, err := ResourceLBBackendServerParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	backend, err := lbAPI.GetBackend(&lbSDK.ZonedAPIGetBackendRequest{
+		Zone:      zone,
+		BackendID: backendID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForLB(ctx, lbAPI, zone, backend.LB.ID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = lbAPI.RemoveBackendServers(&lbSDK.ZonedAPIRemoveBackendServersRequest{
+		Zone:      zone,
+		BackendID: backendID,
+		ServerIP:  []string{ip},
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForLB(ctx, lbAPI, zone, backend.LB.ID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
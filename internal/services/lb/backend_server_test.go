@@ -0,0 +1,120 @@
+package lb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/lb"
+)
+
+func TestAccBackendServer_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isBackendDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource scaleway_lb_ip ip01 {}
+					resource scaleway_lb lb01 {
+						ip_id = scaleway_lb_ip.ip01.id
+						name = "test-lb-backend-server"
+						type = "lb-s"
+					}
+
+					resource scaleway_instance_ip ip01 {}
+					resource scaleway_instance_ip ip02 {}
+
+					resource scaleway_lb_backend bkd01 {
+						lb_id = scaleway_lb.lb01.id
+						name = "bkd01"
+						forward_protocol = "tcp"
+						forward_port = 80
+						proxy_protocol = "none"
+						server_ips = [ scaleway_instance_ip.ip01.address ]
+						ignore_external_servers = true
+					}
+
+					resource scaleway_lb_backend_server srv02 {
+						backend_id = scaleway_lb_backend.bkd01.id
+						ip = scaleway_instance_ip.ip02.address
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_lb_backend_server.srv02", "backend_id", "scaleway_lb_backend.bkd01", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_lb_backend_server.srv02", "ip", "scaleway_instance_ip.ip02", "address"),
+					resource.TestCheckResourceAttrPair("scaleway_lb_backend_server.srv02", "lb_id", "scaleway_lb.lb01", "id"),
+					resource.TestCheckResourceAttr("scaleway_lb_backend.bkd01", "server_ips.#", "1"),
+					isBackendServerCount(tt, "scaleway_lb_backend.bkd01", 2),
+				),
+			},
+			{
+				ResourceName:      "scaleway_lb_backend_server.srv02",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+					resource scaleway_lb_ip ip01 {}
+					resource scaleway_lb lb01 {
+						ip_id = scaleway_lb_ip.ip01.id
+						name = "test-lb-backend-server"
+						type = "lb-s"
+					}
+
+					resource scaleway_instance_ip ip01 {}
+					resource scaleway_instance_ip ip02 {}
+
+					resource scaleway_lb_backend bkd01 {
+						lb_id = scaleway_lb.lb01.id
+						name = "bkd01"
+						forward_protocol = "tcp"
+						forward_port = 80
+						proxy_protocol = "none"
+						server_ips = [ scaleway_instance_ip.ip01.address ]
+						ignore_external_servers = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_lb_backend.bkd01", "server_ips.#", "1"),
+					isBackendServerCount(tt, "scaleway_lb_backend.bkd01", 1),
+				),
+			},
+		},
+	})
+}
+
+// isBackendServerCount checks the number of servers of a backend, including the ones attached by scaleway_lb_backend_server
+func isBackendServerCount(tt *acctest.TestTools, n string, count int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		lbAPI, zone, ID, err := lb.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		backend, err := lbAPI.GetBackend(&lbSDK.ZonedAPIGetBackendRequest{
+			BackendID: ID,
+			Zone:      zone,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(backend.Pool) != count {
+			return fmt.Errorf("backend %s has %d servers, expected %d", rs.Primary.ID, len(backend.Pool), count)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+package lb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+func DataSourceBackendStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceLbBackendStatsRead,
+		SchemaFunc:  backendStatsSchema,
+	}
+}
+
+func backendStatsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"lb_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			Description:      "The ID of the load balancer",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+		},
+		"backend_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "Only report the servers of this backend",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+		},
+		"servers": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Health of the backend servers",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"backend_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "ID of the backend of the server",
+					},
+					"instance_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "ID of the load balancer instance running the health checks",
+					},
+					"ip": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "IP address of the server",
+					},
+					"server_state": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "State of the server as seen by the load balancer",
+					},
+					"server_state_changed_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Date and time of the last state change of the server (RFC 3339 format)",
+					},
+					"last_health_check_status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Result of the last health check of the server",
+					},
+					"healthy": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the server is running and passed its last health check",
+					},
+				},
+			},
+		},
+		"healthy_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of healthy servers",
+		},
+		"all_healthy": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether every reported server is healthy, false when no server is reported",
+		},
+		"zone": zonal.Schema(),
+	}
+}
+
+// IsBackendServerHealthy returns true when the server is running and passed its last health check
+func IsBackendServerHealthy(stats *lb.BackendServerStats) bool {
+	if stats.ServerState != lb.BackendServerStatsServerStateRunning {
+		return false
+	}
+
+	return stats.LastHealthCheckStatus == lb.BackendServerStatsHealthCheckStatusPassed ||
+		stats.LastHealthCheckStatus == lb.BackendServerStatsHealthCheckStatusCondpass
+}
+
+func DataSourceLbBackendStatsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	lbAPI, zone, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lbID := locality.ExpandID(d.Get("lb_id"))
+	if lbZone, id, err := zonal.ParseID(d.Get("lb_id").(string)); err == nil {
+		zone, lbID = lbZone, id
+	}
+
+	req := &lb.ZonedAPIListBackendStatsRequest{
+		Zone: zone,
+		LBID: lbID,
+	}
+
+	if backendID, ok := d.GetOk("backend_id"); ok {
+		req.BackendID = types.ExpandStringPtr(locality.ExpandID(backendID))
+	}
+
+	res, err := lbAPI.ListBackendStats(req, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	servers := []any(nil)
+	healthyCount := 0
+
+	for _, stats := range res.BackendServersStats {
+		healthy := IsBackendServerHealthy(stats)
+		if healthy {
+			healthyCount++
+		}
+
+		servers = append(servers, map[string]any{
+			"backend_id":               zonal.NewIDString(zone, stats.BackendID),
+			"instance_id":              stats.InstanceID,
+			"ip":                       stats.IP,
+			"server_state":             stats.ServerState.String(),
+			"server_state_changed_at":  types.FlattenTime(stats.ServerStateChangedAt),
+			"last_health_check_status": stats.LastHealthCheckStatus.String(),
+			"healthy":                  healthy,
+		})
+	}
+
+	d.SetId(zonal.NewIDString(zone, lbID))
+	_ = d.Set("lb_id", zonal.NewIDString(zone, lbID))
+	_ = d.Set("zone", zone.String())
+	_ = d.Set("servers", servers)
+	_ = d.Set("healthy_count", healthyCount)
+	_ = d.Set("all_healthy", len(servers) > 0 && healthyCount == len(servers))
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package lb_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccDataSourceBackendStats_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isBackendDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource scaleway_lb_ip ip01 {}
+					resource scaleway_lb lb01 {
+						ip_id = scaleway_lb_ip.ip01.id
+						name = "test-lb-backend-stats"
+						type = "lb-s"
+					}
+
+					resource scaleway_instance_ip ip01 {}
+
+					resource scaleway_lb_backend bkd01 {
+						lb_id = scaleway_lb.lb01.id
+						name = "bkd01"
+						forward_protocol = "tcp"
+						forward_port = 80
+						proxy_protocol = "none"
+						server_ips = [ scaleway_instance_ip.ip01.address ]
+					}
+
+					data scaleway_lb_backend_stats all {
+						lb_id = scaleway_lb.lb01.id
+						depends_on = [scaleway_lb_backend.bkd01]
+					}
+
+					data scaleway_lb_backend_stats bkd01 {
+						lb_id      = scaleway_lb.lb01.id
+						backend_id = scaleway_lb_backend.bkd01.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scaleway_lb_backend_stats.all", "servers.#", "1"),
+					resource.TestCheckResourceAttr("data.scaleway_lb_backend_stats.bkd01", "servers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.scaleway_lb_backend_stats.bkd01", "servers.0.ip", "scaleway_instance_ip.ip01", "address"),
+					resource.TestCheckResourceAttrSet("data.scaleway_lb_backend_stats.bkd01", "servers.0.server_state"),
+					resource.TestCheckResourceAttrSet("data.scaleway_lb_backend_stats.bkd01", "healthy_count"),
+					resource.TestCheckResourceAttrSet("data.scaleway_lb_backend_stats.bkd01", "all_healthy"),
+				),
+			},
+		},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -36,12 +37,19 @@ func ResourceCertificate() *schema.Resource {
 		StateUpgraders: []schema.StateUpgrader{
 			{Version: 0, Type: lbUpgradeV1SchemaType(), Upgrade: UpgradeStateV1Func},
 		},
-		SchemaFunc: certificateSchema,
+		SchemaFunc:    certificateSchema,
+		CustomizeDiff: customizeDiffCertificateRotation,
 	}
 }
 
 func certificateSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		// id is declared to be planned as unknown when a zero downtime rotation replaces the certificate
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the certificate",
+		},
 		"lb_id": {
 			Type:        schema.TypeString,
 			Required:    true,
@@ -60,13 +68,11 @@ func certificateSchema() map[string]*schema.Schema {
 			Description:   "The Let's Encrypt type certificate configuration",
 			Type:          schema.TypeList,
 			Optional:      true,
-			ForceNew:      true,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"common_name": {
 						Type:        schema.TypeString,
 						Required:    true,
-						ForceNew:    true,
 						Description: "The main domain name of the certificate",
 					},
 					"subject_alternative_name": {
@@ -75,7 +81,6 @@ func certificateSchema() map[string]*schema.Schema {
 							Type: schema.TypeString,
 						},
 						Optional:    true,
-						ForceNew:    true,
 						Description: "The alternative domain names of the certificate",
 					},
 				},
@@ -87,13 +92,11 @@ func certificateSchema() map[string]*schema.Schema {
 			Type:          schema.TypeList,
 			Description:   "The custom type certificate type configuration",
 			Optional:      true,
-			ForceNew:      true,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"certificate_chain": {
 						Type:        schema.TypeString,
 						Required:    true,
-						ForceNew:    true,
 						Sensitive:   true,
 						Description: "The full PEM-formatted certificate chain",
 					},
@@ -101,6 +104,13 @@ func certificateSchema() map[string]*schema.Schema {
 			},
 		},
 
+		"zero_downtime_rotation": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Rotate the certificate without downtime when its configuration changes: the new certificate is created and attached to the frontends before the old one is detached and deleted",
+		},
+
 		// Readonly attributes
 		"common_name": {
 			Type:        schema.TypeString,
@@ -260,6 +270,21 @@ func resourceLbCertificateUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	if d.HasChanges("letsencrypt", "custom_certificate") {
+		// Only reachable with zero_downtime_rotation, the diff forces a new resource otherwise
+		newCertificate, err := rotateLbCertificate(ctx, d, lbAPI, zone, ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = identity.SetZonalIdentity(d, zone, newCertificate.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ID = newCertificate.ID
+	}
+
 	if d.HasChange("name") {
 		req := &lbSDK.ZonedAPIUpdateCertificateRequest{
 			CertificateID: ID,
@@ -313,3 +338,145 @@ func resourceLbCertificateDelete(ctx context.Context, d *schema.ResourceData, m
 
 	return nil
 }
+
+func customizeDiffCertificateRotation(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	for _, key := range []string{"letsencrypt", "custom_certificate"} {
+		if !diff.HasChange(key) {
+			continue
+		}
+
+		if !diff.Get("zero_downtime_rotation").(bool) {
+			err := diff.ForceNew(key)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// The rotation creates a new certificate, resources referencing the ID are updated in the same apply
+		for _, computedKey := range []string{"id", "common_name", "subject_alternative_name", "fingerprint", "not_valid_before", "not_valid_after", "status"} {
+			err := diff.SetNewComputed(computedKey)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rotateLbCertificate creates a certificate with the new configuration and waits for it to be ready, adds it to the
+// frontends using the old certificate, then detaches the old certificate from these frontends and deletes it.
+func rotateLbCertificate(ctx context.Context, d *schema.ResourceData, lbAPI *lbSDK.ZonedAPI, zone scw.Zone, oldID string) (*lbSDK.Certificate, error) {
+	_, lbID, err := zonal.ParseID(d.Get("lb_id").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &lbSDK.ZonedAPICreateCertificateRequest{
+		Zone:              zone,
+		LBID:              lbID,
+		Name:              d.Get("name").(string),
+		Letsencrypt:       expandLbLetsEncrypt(d.Get("letsencrypt")),
+		CustomCertificate: expandLbCustomCertificate(d.Get("custom_certificate")),
+	}
+	if createReq.Letsencrypt == nil && createReq.CustomCertificate == nil {
+		return nil, errors.New("you need to define either letsencrypt or custom_certificate configuration")
+	}
+
+	_, err = waitForLB(ctx, lbAPI, zone, lbID, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return nil, err
+	}
+
+	createdCertificate, err := lbAPI.CreateCertificate(createReq, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	newCertificate, err := waitForCertificate(ctx, lbAPI, zone, createdCertificate.ID, d.Timeout(schema.TimeoutUpdate))
+	if err == nil && newCertificate.Status != lbSDK.CertificateStatusReady {
+		err = fmt.Errorf("certificate %s has status %s", newCertificate.ID, newCertificate.Status)
+	}
+
+	if err != nil {
+		// The frontends still use the old certificate, remove the new one so that the next apply starts over
+		_ = lbAPI.DeleteCertificate(&lbSDK.ZonedAPIDeleteCertificateRequest{
+			Zone:          zone,
+			CertificateID: createdCertificate.ID,
+		}, scw.WithContext(ctx))
+
+		return nil, fmt.Errorf("new certificate did not become ready, certificate %s was kept: %w", oldID, err)
+	}
+
+	frontends, err := lbAPI.ListFrontends(&lbSDK.ZonedAPIListFrontendsRequest{
+		Zone: zone,
+		LBID: lbID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	rotatedFrontends := []*lbSDK.Frontend(nil)
+
+	for _, frontend := range frontends.Frontends {
+		withBoth, _ := ReplaceCertificateID(frontend.CertificateIDs, oldID, newCertificate.ID)
+		if withBoth == nil {
+			continue
+		}
+
+		err = updateLbFrontendCertificates(ctx, lbAPI, zone, frontend, withBoth, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return nil, fmt.Errorf("adding certificate %s to frontend %s: %w", newCertificate.ID, frontend.ID, err)
+		}
+
+		rotatedFrontends = append(rotatedFrontends, frontend)
+	}
+
+	for _, frontend := range rotatedFrontends {
+		_, withNew := ReplaceCertificateID(frontend.CertificateIDs, oldID, newCertificate.ID)
+
+		err = updateLbFrontendCertificates(ctx, lbAPI, zone, frontend, withNew, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return nil, fmt.Errorf("detaching certificate %s from frontend %s: %w", oldID, frontend.ID, err)
+		}
+	}
+
+	err = lbAPI.DeleteCertificate(&lbSDK.ZonedAPIDeleteCertificateRequest{
+		Zone:          zone,
+		CertificateID: oldID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return nil, fmt.Errorf("deleting certificate %s: %w", oldID, err)
+	}
+
+	return newCertificate, nil
+}
+
+// updateLbFrontendCertificates sets the certificates of a frontend, keeping its other settings
+func updateLbFrontendCertificates(ctx context.Context, lbAPI *lbSDK.ZonedAPI, zone scw.Zone, frontend *lbSDK.Frontend, certificateIDs []string, timeout time.Duration) error {
+	_, err := lbAPI.UpdateFrontend(&lbSDK.ZonedAPIUpdateFrontendRequest{
+		Zone:                zone,
+		FrontendID:          frontend.ID,
+		Name:                frontend.Name,
+		InboundPort:         frontend.InboundPort,
+		BackendID:           frontend.Backend.ID,
+		TimeoutClient:       frontend.TimeoutClient,
+		CertificateIDs:      &certificateIDs,
+		EnableHTTP3:         frontend.EnableHTTP3,
+		ConnectionRateLimit: frontend.ConnectionRateLimit,
+		EnableAccessLogs:    &frontend.EnableAccessLogs,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForLB(ctx, lbAPI, zone, frontend.LB.ID, timeout)
+
+	return err
+}
@@ -235,6 +235,92 @@ EOF
 	})
 }
 
+func TestAccCertificate_ZeroDowntimeRotation(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	certificateConfig := func(subjectAlternativeNames string) string {
+		return fmt.Sprintf(`
+			resource scaleway_lb_ip ip01 {
+			}
+
+			resource scaleway_lb lb01 {
+				ip_id = scaleway_lb_ip.ip01.id
+				name = "test-lb-rotation"
+				type = "lb-s"
+			}
+
+			resource scaleway_lb_backend bkd01 {
+				lb_id = scaleway_lb.lb01.id
+				forward_protocol = "http"
+				forward_port = 80
+				proxy_protocol = "none"
+			}
+
+			resource scaleway_lb_certificate cert01 {
+				lb_id = scaleway_lb.lb01.id
+				name = "test-cert-rotation"
+				zero_downtime_rotation = true
+				letsencrypt {
+					common_name = "${replace(scaleway_lb_ip.ip01.ip_address, ".", "-")}.lb.${scaleway_lb.lb01.region}.scw.cloud"
+					subject_alternative_name = [%s]
+				}
+			}
+
+			resource scaleway_lb_frontend frt01 {
+				lb_id = scaleway_lb.lb01.id
+				backend_id = scaleway_lb_backend.bkd01.id
+				inbound_port = 443
+				certificate_ids = [scaleway_lb_certificate.cert01.id]
+			}
+		`, subjectAlternativeNames)
+	}
+
+	var firstCertificateID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			isLbDestroyed(tt),
+			lbchecks.IsIPDestroyed(tt),
+			isCertificateDestroyed(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: certificateConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_lb_frontend.frt01", "certificate_ids.0", "scaleway_lb_certificate.cert01", "id"),
+					func(state *terraform.State) error {
+						firstCertificateID = state.RootModule().Resources["scaleway_lb_certificate.cert01"].Primary.ID
+
+						return nil
+					},
+				),
+			},
+			{
+				Config: certificateConfig(`"sub1.${replace(scaleway_lb_ip.ip01.ip_address, ".", "-")}.lb.${scaleway_lb.lb01.region}.scw.cloud"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_lb_certificate.cert01", "letsencrypt.0.subject_alternative_name.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_lb_frontend.frt01", "certificate_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("scaleway_lb_frontend.frt01", "certificate_ids.0", "scaleway_lb_certificate.cert01", "id"),
+					func(state *terraform.State) error {
+						if state.RootModule().Resources["scaleway_lb_certificate.cert01"].Primary.ID == firstCertificateID {
+							return fmt.Errorf("certificate %s was not rotated", firstCertificateID)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// The frontend already uses the rotated certificate, nothing is left to apply
+				Config:   certificateConfig(`"sub1.${replace(scaleway_lb_ip.ip01.ip_address, ".", "-")}.lb.${scaleway_lb.lb01.region}.scw.cloud"`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func isCertificateDestroyed(tt *acctest.TestTools) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		for _, rs := range state.RootModule().Resources {
@@ -261,3 +261,93 @@ func getLBPrivateIPs(ctx context.Context, m any, region scw.Region, lbID string,
 
 	return allPrivateIPs, nil
 }
+
+func ResourceLBBackendServerParseID(resourceID string) (zone scw.Zone, backendID string, ip string, err error) {
+	idParts := strings.SplitN(resourceID, "/", 3)
+	if len(idParts) != 3 || net.ParseIP(idParts[2]) == nil {
+		return "", "", "", fmt.Errorf("can't parse backend server resource id: %s", resourceID)
+	}
+
+	return scw.Zone(idParts[0]), idParts[1], idParts[2], nil
+}
+
+// BackendServerIPsEqual compares two server IPs, IPv6 addresses may be written in different forms
+func BackendServerIPsEqual(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil {
+		return a == b
+	}
+
+	return ipA.Equal(ipB)
+}
+
+func backendServerIPsContain(ips []string, ip string) bool {
+	for _, candidate := range ips {
+		if BackendServerIPsEqual(candidate, ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BackendServersCompare returns the server IPs to remove from and to add to a backend to go from oldIPs to newIPs
+func BackendServersCompare(oldIPs, newIPs []string) ([]string, []string) {
+	var toRemove, toAdd []string
+
+	for _, ip := range oldIPs {
+		if !backendServerIPsContain(newIPs, ip) {
+			toRemove = append(toRemove, ip)
+		}
+	}
+
+	for _, ip := range newIPs {
+		if !backendServerIPsContain(oldIPs, ip) {
+			toAdd = append(toAdd, ip)
+		}
+	}
+
+	return toRemove, toAdd
+}
+
+// FilterBackendServers returns the servers of the backend pool that are managed, in pool order
+func FilterBackendServers(pool []string, managed []string) []string {
+	filtered := []string{}
+
+	for _, ip := range pool {
+		if backendServerIPsContain(managed, ip) {
+			filtered = append(filtered, ip)
+		}
+	}
+
+	return filtered
+}
+
+// ReplaceCertificateID returns the certificates of a frontend during a certificate rotation: first with the new
+// certificate right after the old one, then with the new certificate in place of the old one.
+// Both lists are nil when the frontend does not use the old certificate.
+func ReplaceCertificateID(certificateIDs []string, oldID string, newID string) ([]string, []string) {
+	var withBoth, withNew []string
+
+	found := false
+
+	for _, id := range certificateIDs {
+		if id != oldID {
+			withBoth = append(withBoth, id)
+			withNew = append(withNew, id)
+
+			continue
+		}
+
+		found = true
+
+		withBoth = append(withBoth, oldID, newID)
+		withNew = append(withNew, newID)
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	return withBoth, withNew
+}
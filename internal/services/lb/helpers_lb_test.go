@@ -158,3 +158,63 @@ func TestPrivateNetworksCompare(t *testing.T) {
 		})
 	}
 }
+
+func TestBackendServersCompare(t *testing.T) {
+	toRemove, toAdd := lb.BackendServersCompare(
+		[]string{"10.0.0.1", "10.0.0.2", "2001:db8::1"},
+		[]string{"10.0.0.2", "10.0.0.3", "2001:0db8:0000:0000:0000:0000:0000:0001"},
+	)
+	assert.Equal(t, []string{"10.0.0.1"}, toRemove)
+	assert.Equal(t, []string{"10.0.0.3"}, toAdd)
+
+	toRemove, toAdd = lb.BackendServersCompare(nil, nil)
+	assert.Empty(t, toRemove)
+	assert.Empty(t, toAdd)
+}
+
+func TestFilterBackendServers(t *testing.T) {
+	pool := []string{"10.0.0.3", "10.0.0.1", "2001:db8::1", "10.0.0.2"}
+
+	assert.Equal(t, []string{"10.0.0.1", "2001:db8::1"}, lb.FilterBackendServers(pool, []string{"2001:0db8::0001", "10.0.0.1"}))
+	assert.Empty(t, lb.FilterBackendServers(pool, nil))
+}
+
+func TestResourceLBBackendServerParseID(t *testing.T) {
+	zone, backendID, ip, err := lb.ResourceLBBackendServerParseID("fr-par-1/11111111-1111-1111-1111-111111111111/2001:db8::1")
+	assert.NoError(t, err)
+	assert.Equal(t, "fr-par-1", zone.String())
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", backendID)
+	assert.Equal(t, "2001:db8::1", ip)
+
+	_, _, _, err = lb.ResourceLBBackendServerParseID("fr-par-1/11111111-1111-1111-1111-111111111111")
+	assert.Error(t, err)
+}
+
+func TestIsBackendServerHealthy(t *testing.T) {
+	assert.True(t, lb.IsBackendServerHealthy(&lbSDK.BackendServerStats{
+		ServerState:           lbSDK.BackendServerStatsServerStateRunning,
+		LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusPassed,
+	}))
+	assert.True(t, lb.IsBackendServerHealthy(&lbSDK.BackendServerStats{
+		ServerState:           lbSDK.BackendServerStatsServerStateRunning,
+		LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusCondpass,
+	}))
+	assert.False(t, lb.IsBackendServerHealthy(&lbSDK.BackendServerStats{
+		ServerState:           lbSDK.BackendServerStatsServerStateRunning,
+		LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusFailed,
+	}))
+	assert.False(t, lb.IsBackendServerHealthy(&lbSDK.BackendServerStats{
+		ServerState:           lbSDK.BackendServerStatsServerStateStopped,
+		LastHealthCheckStatus: lbSDK.BackendServerStatsHealthCheckStatusPassed,
+	}))
+}
+
+func TestReplaceCertificateID(t *testing.T) {
+	withBoth, withNew := lb.ReplaceCertificateID([]string{"default", "old", "other"}, "old", "new")
+	assert.Equal(t, []string{"default", "old", "new", "other"}, withBoth)
+	assert.Equal(t, []string{"default", "new", "other"}, withNew)
+
+	withBoth, withNew = lb.ReplaceCertificateID([]string{"default", "other"}, "old", "new")
+	assert.Nil(t, withBoth)
+	assert.Nil(t, withNew)
+}
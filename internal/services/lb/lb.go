@@ -214,6 +214,13 @@ func lbSchema() map[string]*schema.Schema {
 			DiffSuppressFunc: dsf.OrderDiff,
 			ConflictsWith:    []string{"assign_flexible_ip", "assign_flexible_ipv6"},
 		},
+		"subscriber_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "The ID of the subscriber notified of the health changes of the backend servers",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+		},
 		"private_ips": {
 			Type:        schema.TypeList,
 			Computed:    true,
@@ -281,6 +288,13 @@ func resourceLbCreate(ctx context.Context, d *schema.ResourceData, m any) diag.D
 		return diag.FromErr(err)
 	}
 
+	if subscriberID, ok := d.GetOk("subscriber_id"); ok {
+		err = setLBSubscriber(ctx, lbAPI, zone, lb.ID, locality.ExpandID(subscriberID), d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if !d.Get("external_private_networks").(bool) {
 		// attach private network
 		pnConfigs, pnExist := d.GetOk("private_network")
@@ -391,6 +405,12 @@ func setLBState(ctx context.Context, d *schema.ResourceData, m any, lbAPI *lbSDK
 
 	_ = d.Set("private_ips", allPrivateIPs)
 
+	if lb.Subscriber != nil {
+		_ = d.Set("subscriber_id", zonal.NewIDString(lb.Zone, lb.Subscriber.ID))
+	} else {
+		_ = d.Set("subscriber_id", "")
+	}
+
 	return nil
 }
 
@@ -528,6 +548,13 @@ func resourceLbUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.D
 		}
 	}
 
+	if d.HasChange("subscriber_id") {
+		err = setLBSubscriber(ctx, lbAPI, zone, ID, locality.ExpandID(d.Get("subscriber_id")), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	////
 	// Attach / Detach Private Networks
 	////
@@ -0,0 +1,232 @@
+package lb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+func ResourceSubscriber() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLbSubscriberCreate,
+		ReadContext:   resourceLbSubscriberRead,
+		UpdateContext: resourceLbSubscriberUpdate,
+		DeleteContext: resourceLbSubscriberDelete,
+		Identity:      identity.DefaultZonal(),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    subscriberSchema,
+	}
+}
+
+func subscriberSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The name of the subscriber",
+		},
+		"email_config": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"email_config", "webhook_config"},
+			Description:  "Email address notified of the health changes of the backend servers",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"email": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The email address to send alerts to",
+					},
+				},
+			},
+		},
+		"webhook_config": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"email_config", "webhook_config"},
+			Description:  "Webhook called on the health changes of the backend servers",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"uri": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						Description:  "The URI of the webhook",
+					},
+				},
+			},
+		},
+		"zone":       zonal.Schema(),
+		"project_id": account.ProjectIDSchema(),
+	}
+}
+
+func expandLbSubscriberEmailConfig(raw any) *lbSDK.SubscriberEmailConfig {
+	rawList, ok := raw.([]any)
+	if !ok || len(rawList) == 0 || rawList[0] == nil {
+		return nil
+	}
+
+	rawConfig := rawList[0].(map[string]any)
+
+	return &lbSDK.SubscriberEmailConfig{
+		Email: rawConfig["email"].(string),
+	}
+}
+
+func flattenLbSubscriberEmailConfig(config *lbSDK.SubscriberEmailConfig) []map[string]any {
+	if config == nil {
+		return nil
+	}
+
+	return []map[string]any{
+		{
+			"email": config.Email,
+		},
+	}
+}
+
+func expandLbSubscriberWebhookConfig(raw any) *lbSDK.SubscriberWebhookConfig {
+	rawList, ok := raw.([]any)
+	if !ok || len(rawList) == 0 || rawList[0] == nil {
+		return nil
+	}
+
+	rawConfig := rawList[0].(map[string]any)
+
+	return &lbSDK.SubscriberWebhookConfig{
+		URI: rawConfig["uri"].(string),
+	}
+}
+
+func flattenLbSubscriberWebhookConfig(config *lbSDK.SubscriberWebhookConfig) []map[string]any {
+	if config == nil {
+		return nil
+	}
+
+	return []map[string]any{
+		{
+			"uri": config.URI,
+		},
+	}
+}
+
+func resourceLbSubscriberCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	lbAPI, zone, err := lbAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subscriber, err := lbAPI.CreateSubscriber(&lbSDK.ZonedAPICreateSubscriberRequest{
+		Zone:          zone,
+		Name:          types.ExpandOrGenerateString(d.Get("name"), "lb-subscriber"),
+		EmailConfig:   expandLbSubscriberEmailConfig(d.Get("email_config")),
+		WebhookConfig: expandLbSubscriberWebhookConfig(d.Get("webhook_config")),
+		ProjectID:     types.ExpandStringPtr(d.Get("project_id")),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, subscriber.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceLbSubscriberRead(ctx, d, m)
+}
+
+func resourceLbSubscriberRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	lbAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subscriber, err := lbAPI.GetSubscriber(&lbSDK.ZonedAPIGetSubscriberRequest{
+		Zone:         zone,
+		SubscriberID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	// The subscriber does not carry its project, it is the one of the configuration or the provider default
+	projectID, _, err := meta.ExtractProjectID(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("name", subscriber.Name)
+	_ = d.Set("email_config", flattenLbSubscriberEmailConfig(subscriber.EmailConfig))
+	_ = d.Set("webhook_config", flattenLbSubscriberWebhookConfig(subscriber.WebhookConfig))
+	_ = d.Set("zone", zone.String())
+	_ = d.Set("project_id", projectID)
+
+	err = identity.SetZonalIdentity(d, zone, subscriber.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceLbSubscriberUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	lbAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "email_config", "webhook_config") {
+		_, err = lbAPI.UpdateSubscriber(&lbSDK.ZonedAPIUpdateSubscriberRequest{
+			Zone:          zone,
+			SubscriberID:  ID,
+			Name:          d.Get("name").(string),
+			EmailConfig:   expandLbSubscriberEmailConfig(d.Get("email_config")),
+			WebhookConfig: expandLbSubscriberWebhookConfig(d.Get("webhook_config")),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceLbSubscriberRead(ctx, d, m)
+}
+
+func resourceLbSubscriberDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	lbAPI, zone, ID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = lbAPI.DeleteSubscriber(&lbSDK.ZonedAPIDeleteSubscriberRequest{
+		Zone:         zone,
+		SubscriberID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package lb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	lbSDK "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/lb"
+)
+
+func TestAccSubscriber_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			isLbDestroyed(tt),
+			isSubscriberDestroyed(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource scaleway_lb_subscriber main {
+						name = "test-lb-subscriber"
+
+						email_config {
+							email = "alerts@example.com"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isSubscriberPresent(tt, "scaleway_lb_subscriber.main"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "name", "test-lb-subscriber"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "email_config.0.email", "alerts@example.com"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "webhook_config.#", "0"),
+					resource.TestCheckResourceAttrSet("scaleway_lb_subscriber.main", "project_id"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_lb_subscriber.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+					resource scaleway_lb_subscriber main {
+						name = "test-lb-subscriber-webhook"
+
+						webhook_config {
+							uri = "https://example.com/lb-alerts"
+						}
+					}
+
+					resource scaleway_lb_ip ip01 {}
+
+					resource scaleway_lb lb01 {
+						ip_id         = scaleway_lb_ip.ip01.id
+						name          = "test-lb-subscriber"
+						type          = "lb-s"
+						subscriber_id = scaleway_lb_subscriber.main.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isSubscriberPresent(tt, "scaleway_lb_subscriber.main"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "name", "test-lb-subscriber-webhook"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "webhook_config.0.uri", "https://example.com/lb-alerts"),
+					resource.TestCheckResourceAttr("scaleway_lb_subscriber.main", "email_config.#", "0"),
+					isLbPresent(tt, "scaleway_lb.lb01"),
+					resource.TestCheckResourceAttrPair("scaleway_lb.lb01", "subscriber_id", "scaleway_lb_subscriber.main", "id"),
+				),
+			},
+			{
+				Config: `
+					resource scaleway_lb_subscriber main {
+						name = "test-lb-subscriber-webhook"
+
+						webhook_config {
+							uri = "https://example.com/lb-alerts"
+						}
+					}
+
+					resource scaleway_lb_ip ip01 {}
+
+					resource scaleway_lb lb01 {
+						ip_id = scaleway_lb_ip.ip01.id
+						name  = "test-lb-subscriber"
+						type  = "lb-s"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_lb.lb01", "subscriber_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func isSubscriberPresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		lbAPI, zone, ID, err := lb.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = lbAPI.GetSubscriber(&lbSDK.ZonedAPIGetSubscriberRequest{
+			SubscriberID: ID,
+			Zone:         zone,
+		})
+
+		return err
+	}
+}
+
+func isSubscriberDestroyed(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_lb_subscriber" {
+				continue
+			}
+
+			lbAPI, zone, ID, err := lb.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			_, err = lbAPI.GetSubscriber(&lbSDK.ZonedAPIGetSubscriberRequest{
+				SubscriberID: ID,
+				Zone:         zone,
+			})
+
+			// If no error resource still exist
+			if err == nil {
+				return fmt.Errorf("LB Subscriber (%s) still exists", rs.Primary.ID)
+			}
+
+			// Unexpected api error we return it
+			if !httperrors.Is404(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -459,6 +459,35 @@ func attachLBPrivateNetworks(ctx context.Context, lbAPI *lb.ZonedAPI, zone scw.Z
 	return privateNetworks, nil
 }
 
+// setLBSubscriber subscribes the load balancer to subscriberID, or unsubscribes it when subscriberID is empty
+func setLBSubscriber(ctx context.Context, lbAPI *lb.ZonedAPI, zone scw.Zone, lbID string, subscriberID string, timeout time.Duration) error {
+	var err error
+
+	if subscriberID == "" {
+		_, err = lbAPI.UnsubscribeFromLB(&lb.ZonedAPIUnsubscribeFromLBRequest{
+			Zone: zone,
+			LBID: lbID,
+		}, scw.WithContext(ctx))
+	} else {
+		_, err = lbAPI.SubscribeToLB(&lb.ZonedAPISubscribeToLBRequest{
+			Zone:         zone,
+			LBID:         lbID,
+			SubscriberID: subscriberID,
+		}, scw.WithContext(ctx))
+	}
+
+	if err != nil && !httperrors.Is404(err) {
+		return err
+	}
+
+	_, err = waitForLB(ctx, lbAPI, zone, lbID, timeout)
+	if err != nil && !httperrors.Is404(err) {
+		return err
+	}
+
+	return nil
+}
+
 func flattenLbInstances(instances []*lb.Instance) any {
 	if instances == nil {
 		return nil
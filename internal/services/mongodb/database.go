@@ -0,0 +1,294 @@
+package mongodb
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/database.md
+var databaseDescription string
+
+func ResourceDatabase() *schema.Resource {
+	return &schema.Resource{
+		Description:   databaseDescription,
+		CreateContext: ResourceDatabaseCreate,
+		ReadContext:   ResourceDatabaseRead,
+		UpdateContext: ResourceDatabaseUpdate,
+		DeleteContext: ResourceDatabaseDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Read:    schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Update:  schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Delete:  schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    databaseSchema,
+		CustomizeDiff: cdf.LocalityCheck("instance_id"),
+	}
+}
+
+func databaseSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"instance_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			Description:      "Instance on which the database is created",
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(1, 63),
+			Description:  "Name of the database",
+		},
+		"collections": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Collections of the database, MongoDB only keeps databases holding at least one collection. Removing a collection drops it with its documents",
+		},
+		"user_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "User to connect as, the user must be allowed to create the database, e.g. with the db_admin role",
+		},
+		"password": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ExactlyOneOf: []string{"password", "password_wo"},
+			Description:  "Password of the user. Only one of `password` or `password_wo` should be specified.",
+		},
+		"password_wo": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			WriteOnly:    true,
+			ExactlyOneOf: []string{"password", "password_wo"},
+			RequiredWith: []string{"password_wo_version"},
+			Description:  "Password of the user in [write-only](https://registry.terraform.io/providers/scaleway/scaleway/latest/docs/guides/using-write-only-arguments) mode. The password is only available during create and update: the collections are not refreshed and the database is left in place on destroy.",
+		},
+		"password_wo_version": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			RequiredWith: []string{"password_wo"},
+			Description:  "The version of the [write-only](https://registry.terraform.io/providers/scaleway/scaleway/latest/docs/guides/using-write-only-arguments) password.",
+		},
+		"host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Host to connect to instead of the public endpoint of the instance, e.g. an IP of its private network endpoint",
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "Port to connect to instead of the port of the endpoint",
+		},
+		// Common
+		"region": regional.Schema(),
+	}
+}
+
+func ResourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	region, instanceID, err := regional.ParseID(d.Get("instance_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instance, err := waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := mongoConnect(ctx, d, mongodbAPI, instance)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer mongoDisconnect(client)
+
+	name := d.Get("name").(string)
+
+	for _, collection := range types.ExpandStrings(d.Get("collections").(*schema.Set).List()) {
+		err = mongoCreateCollection(ctx, client, name, collection)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(ResourceDatabaseID(region, instanceID, name))
+
+	return ResourceDatabaseRead(ctx, d, m)
+}
+
+func ResourceDatabaseRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	region, instanceID, name, err := ResourceDatabaseParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databases, err := mongodbAPI.ListDatabases(&mongodb.ListDatabasesRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}, scw.WithContext(ctx), scw.WithAllPages())
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if !slices.ContainsFunc(databases.Databases, func(database *mongodb.Database) bool { return database.Name == name }) {
+		d.SetId("")
+
+		return nil
+	}
+
+	_ = d.Set("instance_id", regional.NewIDString(region, instanceID))
+	_ = d.Set("name", name)
+	_ = d.Set("region", region.String())
+
+	if d.Get("password").(string) == "" {
+		// the collections cannot be refreshed with a write-only password
+		return nil
+	}
+
+	instance, err := mongodbAPI.GetInstance(&mongodb.GetInstanceRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := mongoConnect(ctx, d, mongodbAPI, instance)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Unable to refresh the collections of the database",
+			Detail:   err.Error(),
+		}}
+	}
+	defer mongoDisconnect(client)
+
+	collections, err := mongoListCollectionNames(ctx, client, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the collections created outside of Terraform are not managed by the resource
+	managed := types.ExpandStrings(d.Get("collections").(*schema.Set).List())
+	_ = d.Set("collections", slices.DeleteFunc(managed, func(collection string) bool {
+		return !slices.Contains(collections, collection)
+	}))
+
+	return nil
+}
+
+func ResourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	region, instanceID, name, err := ResourceDatabaseParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("collections") {
+		instance, err := waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		client, err := mongoConnect(ctx, d, mongodbAPI, instance)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer mongoDisconnect(client)
+
+		oldCollections, newCollections := d.GetChange("collections")
+
+		for _, collection := range types.ExpandStrings(newCollections.(*schema.Set).Difference(oldCollections.(*schema.Set)).List()) {
+			err = mongoCreateCollection(ctx, client, name, collection)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		for _, collection := range types.ExpandStrings(oldCollections.(*schema.Set).Difference(newCollections.(*schema.Set)).List()) {
+			err = client.Database(name).Collection(collection).Drop(ctx)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return ResourceDatabaseRead(ctx, d, m)
+}
+
+func ResourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	region, instanceID, name, err := ResourceDatabaseParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instance, err := waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	client, err := mongoConnect(ctx, d, mongodbAPI, instance)
+	if errors.Is(err, errMongoNoPassword) {
+		return mongoDeleteWarning("database " + name)
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer mongoDisconnect(client)
+
+	err = client.Database(name).Drop(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// ResourceDatabaseID returns the ID of a database, in the form "region/instance_id/name"
+func ResourceDatabaseID(region scw.Region, instanceID string, name string) string {
+	return regional.NewIDString(region, instanceID+"/"+name)
+}
+
+// ResourceDatabaseParseID extracts region, instance ID and database name from the resource identifier
+func ResourceDatabaseParseID(resourceID string) (region scw.Region, instanceID string, name string, err error) {
+	return ResourceUserParseID(resourceID)
+}
@@ -0,0 +1,78 @@
+package mongodb_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+const databaseInstanceConfig = `
+	resource "scaleway_mongodb_instance" "main" {
+		name              = "test-mongodb-database"
+		version           = "7.0.12"
+		node_type         = "MGDB-PLAY2-NANO"
+		node_number       = 1
+		user_name         = "initial_user"
+		password          = "initial_password123"
+		volume_size_in_gb = 5
+	}
+`
+
+func TestAccMongoDBDatabase_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: databaseInstanceConfig + `
+					resource "scaleway_mongodb_database" "main" {
+						instance_id = scaleway_mongodb_instance.main.id
+						name        = "app"
+						collections = ["orders", "customers"]
+						user_name   = scaleway_mongodb_instance.main.user_name
+						password    = scaleway_mongodb_instance.main.password
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_mongodb_database.main", "name", "app"),
+					resource.TestCheckResourceAttr("scaleway_mongodb_database.main", "collections.#", "2"),
+					resource.TestCheckTypeSetElemAttr("scaleway_mongodb_database.main", "collections.*", "orders"),
+					resource.TestCheckTypeSetElemAttr("scaleway_mongodb_database.main", "collections.*", "customers"),
+					resource.TestCheckResourceAttrPair("scaleway_mongodb_database.main", "instance_id", "scaleway_mongodb_instance.main", "id"),
+				),
+			},
+			{
+				Config: databaseInstanceConfig + `
+					resource "scaleway_mongodb_database" "main" {
+						instance_id = scaleway_mongodb_instance.main.id
+						name        = "app"
+						collections = ["orders", "invoices"]
+						user_name   = scaleway_mongodb_instance.main.user_name
+						password    = scaleway_mongodb_instance.main.password
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_mongodb_database.main", "collections.#", "2"),
+					resource.TestCheckTypeSetElemAttr("scaleway_mongodb_database.main", "collections.*", "orders"),
+					resource.TestCheckTypeSetElemAttr("scaleway_mongodb_database.main", "collections.*", "invoices"),
+				),
+			},
+			{
+				Config: databaseInstanceConfig + `
+					resource "scaleway_mongodb_database" "main" {
+						instance_id = scaleway_mongodb_instance.main.id
+						name        = "app"
+						collections = ["orders", "invoices"]
+						user_name   = scaleway_mongodb_instance.main.user_name
+						password    = scaleway_mongodb_instance.main.password
+					}
+				`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
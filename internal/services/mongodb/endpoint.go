@@ -0,0 +1,243 @@
+package mongodb
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/endpoint.md
+var endpointDescription string
+
+func mongodbEndpointIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"region":      identity.DefaultRegionAttribute(),
+		"instance_id": {Type: schema.TypeString, Description: "The MongoDB instance ID", RequiredForImport: true},
+		"endpoint_id": {Type: schema.TypeString, Description: "The MongoDB endpoint ID", RequiredForImport: true},
+	})
+}
+
+func ResourceEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Description:   endpointDescription,
+		CreateContext: ResourceEndpointCreate,
+		ReadContext:   ResourceEndpointRead,
+		DeleteContext: ResourceEndpointDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Read:    schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Delete:  schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultMongodbInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    endpointSchema,
+		Identity:      mongodbEndpointIdentity(),
+		CustomizeDiff: cdf.LocalityCheck("instance_id", "private_network.#.pn_id"),
+	}
+}
+
+func endpointSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"instance_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			Description:      "Instance the endpoint is attached to",
+		},
+		"private_network": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"private_network", "public_network"},
+			Description:  "Private network the instance is exposed to",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"pn_id": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ForceNew:         true,
+						ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+						DiffSuppressFunc: dsf.Locality,
+						Description:      "The private network ID",
+					},
+				},
+			},
+		},
+		"public_network": {
+			Type:         schema.TypeBool,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"private_network", "public_network"},
+			Description:  "Expose the instance on the internet",
+		},
+		// Computed
+		"dns_record": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The DNS record of the endpoint",
+		},
+		"port": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "TCP port of the endpoint",
+		},
+		// Common
+		"region": regional.Schema(),
+	}
+}
+
+func ResourceEndpointCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	region, instanceID, err := regional.ParseID(d.Get("instance_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec := &mongodb.EndpointSpec{}
+	if privateNetworkID, ok := d.GetOk("private_network.0.pn_id"); ok {
+		spec.PrivateNetwork = &mongodb.EndpointSpecPrivateNetworkDetails{
+			PrivateNetworkID: locality.ExpandID(privateNetworkID),
+		}
+	} else {
+		spec.PublicNetwork = &mongodb.EndpointSpecPublicNetworkDetails{}
+	}
+
+	endpoint, err := mongodbAPI.CreateEndpoint(&mongodb.CreateEndpointRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Endpoint:   spec,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := identity.SetMultiPartIdentity(d, map[string]string{
+		"region":      string(region),
+		"instance_id": instanceID,
+		"endpoint_id": endpoint.ID,
+	}, "region", "instance_id", "endpoint_id"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceEndpointRead(ctx, d, m)
+}
+
+func ResourceEndpointRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	idParts := identity.ParseMultiPartID(d.Id(), "region", "instance_id", "endpoint_id")
+	region := scw.Region(idParts["region"])
+	instanceID := idParts["instance_id"]
+	endpointID := idParts["endpoint_id"]
+
+	instance, err := waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	var endpoint *mongodb.Endpoint
+
+	for _, e := range instance.Endpoints {
+		if e.ID == endpointID {
+			endpoint = e
+
+			break
+		}
+	}
+
+	if endpoint == nil {
+		d.SetId("")
+
+		return nil
+	}
+
+	if err := identity.SetMultiPartIdentity(d, map[string]string{
+		"region":      string(region),
+		"instance_id": instanceID,
+		"endpoint_id": endpoint.ID,
+	}, "region", "instance_id", "endpoint_id"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("instance_id", regional.NewIDString(region, instanceID))
+	_ = d.Set("dns_record", endpoint.DNSRecord)
+	_ = d.Set("port", int(endpoint.Port))
+	_ = d.Set("region", string(region))
+
+	if endpoint.PrivateNetwork != nil {
+		_ = d.Set("private_network", []map[string]any{{
+			"pn_id": regional.NewIDString(region, endpoint.PrivateNetwork.PrivateNetworkID),
+		}})
+		_ = d.Set("public_network", false)
+	} else {
+		_ = d.Set("private_network", nil)
+		_ = d.Set("public_network", endpoint.PublicNetwork != nil)
+	}
+
+	return nil
+}
+
+func ResourceEndpointDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	mongodbAPI := newAPI(m)
+
+	idParts := identity.ParseMultiPartID(d.Id(), "region", "instance_id", "endpoint_id")
+	region := scw.Region(idParts["region"])
+	instanceID := idParts["instance_id"]
+
+	_, err := waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	err = mongodbAPI.DeleteEndpoint(&mongodb.DeleteEndpointRequest{
+		Region:     region,
+		EndpointID: idParts["endpoint_id"],
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForInstance(ctx, mongodbAPI, region, instanceID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package mongodb_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccMongoDBEndpoint_PrivateNetwork(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	config := `
+		resource "scaleway_vpc_private_network" "main" {
+			name = "test-mongodb-endpoint"
+		}
+
+		resource "scaleway_mongodb_instance" "main" {
+			name                      = "test-mongodb-endpoint"
+			version                   = "7.0.12"
+			node_type                 = "MGDB-PLAY2-NANO"
+			node_number               = 1
+			user_name                 = "initial_user"
+			password                  = "initial_password123"
+			volume_size_in_gb         = 5
+			ignore_external_endpoints = true
+
+			public_network {}
+		}
+
+		resource "scaleway_mongodb_endpoint" "main" {
+			instance_id = scaleway_mongodb_instance.main.id
+
+			private_network {
+				pn_id = scaleway_vpc_private_network.main.id
+			}
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_mongodb_endpoint.main", "instance_id", "scaleway_mongodb_instance.main", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_mongodb_endpoint.main", "private_network.0.pn_id", "scaleway_vpc_private_network.main", "id"),
+					resource.TestCheckResourceAttrSet("scaleway_mongodb_endpoint.main", "dns_record"),
+					resource.TestCheckResourceAttrSet("scaleway_mongodb_endpoint.main", "port"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_mongodb_endpoint.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// The endpoint managed by scaleway_mongodb_endpoint does not show up on the instance
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,179 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const (
+	mongoConnectTimeout = 30 * time.Second
+	// mongoCodeNamespaceExists is returned when creating a collection that exists
+	mongoCodeNamespaceExists = 48
+)
+
+// errMongoNoPassword is returned outside of create and update when the password is write-only
+var errMongoNoPassword = errors.New("the password is not available, password_wo can only be read during create and update")
+
+func mongoPassword(d *schema.ResourceData) string {
+	if password := d.Get("password").(string); password != "" {
+		return password
+	}
+
+	// the raw configuration is only set during create and update
+	password, _ := meta.GetRawConfigForKey(d, "password_wo", cty.String)
+	if password == nil {
+		return ""
+	}
+
+	return password.(string)
+}
+
+// mongoConnect connects to an instance through its public endpoint unless host is set. The driver discovers the
+// members of the replica set and sends the commands to the primary.
+func mongoConnect(ctx context.Context, d *schema.ResourceData, api *mongodb.API, instance *mongodb.Instance) (*mongo.Client, error) {
+	password := mongoPassword(d)
+	if password == "" {
+		return nil, errMongoNoPassword
+	}
+
+	var (
+		host string
+		port int
+	)
+
+	if endpoint := mongoInstanceEndpoint(instance.Endpoints); endpoint != nil {
+		host = endpoint.DNSRecord
+		port = int(endpoint.Port)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if overriddenHost := d.Get("host").(string); overriddenHost != "" {
+		// the certificate is issued for the DNS record of the endpoint, not for the host used instead
+		tlsConfig.ServerName = host
+		host = overriddenHost
+	}
+
+	if overriddenPort := d.Get("port").(int); overriddenPort != 0 {
+		port = overriddenPort
+	}
+
+	if host == "" {
+		return nil, fmt.Errorf("instance %s has no endpoint, set host to connect to it", instance.ID)
+	}
+
+	if port == 0 {
+		port = 27017
+	}
+
+	cert, err := api.GetInstanceCertificate(&mongodb.GetInstanceCertificateRequest{
+		Region:     instance.Region,
+		InstanceID: instance.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	certContent, err := io.ReadAll(cert.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig.RootCAs = x509.NewCertPool()
+	if !tlsConfig.RootCAs.AppendCertsFromPEM(certContent) {
+		return nil, fmt.Errorf("failed to parse the certificate of instance %s", instance.ID)
+	}
+
+	client, err := mongo.Connect(options.Client().
+		SetHosts([]string{net.JoinHostPort(host, strconv.Itoa(port))}).
+		SetAuth(options.Credential{
+			AuthSource: "admin",
+			Username:   d.Get("user_name").(string),
+			Password:   password,
+		}).
+		SetTLSConfig(tlsConfig).
+		SetConnectTimeout(mongoConnectTimeout).
+		SetServerSelectionTimeout(mongoConnectTimeout).
+		SetAppName("terraform-provider-scaleway"))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to instance %s: %w", instance.ID, err)
+	}
+
+	// the driver connects lazily, the credentials are checked here rather than by the first command
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		mongoDisconnect(client)
+
+		return nil, fmt.Errorf("connecting to instance %s: %w", instance.ID, err)
+	}
+
+	return client, nil
+}
+
+// mongoDisconnect closes the connections of a client
+func mongoDisconnect(client *mongo.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), mongoConnectTimeout)
+	defer cancel()
+
+	_ = client.Disconnect(ctx)
+}
+
+// mongoCreateCollection creates a collection, the database is created along with its first collection
+func mongoCreateCollection(ctx context.Context, client *mongo.Client, database string, collection string) error {
+	err := client.Database(database).CreateCollection(ctx, collection)
+
+	serverErr := mongo.ServerError(nil)
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(mongoCodeNamespaceExists) {
+		return nil
+	}
+
+	return err
+}
+
+// mongoListCollectionNames returns the names of the collections of a database the user is allowed to list
+func mongoListCollectionNames(ctx context.Context, client *mongo.Client, database string) ([]string, error) {
+	return client.Database(database).ListCollectionNames(ctx, bson.D{}, options.ListCollections().SetAuthorizedCollections(true))
+}
+
+// mongoInstanceEndpoint returns the public endpoint of an instance, or its first endpoint
+func mongoInstanceEndpoint(endpoints []*mongodb.Endpoint) *mongodb.Endpoint {
+	for _, endpoint := range endpoints {
+		if endpoint.PublicNetwork != nil {
+			return endpoint
+		}
+	}
+
+	if len(endpoints) > 0 {
+		return endpoints[0]
+	}
+
+	return nil
+}
+
+// mongoDeleteWarning warns that an object cannot be removed with a write-only password
+func mongoDeleteWarning(object string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  object + " left in the instance",
+		Detail:   "The password is write-only and is not available on destroy, " + object + " was removed from the state but not from the instance.",
+	}}
+}
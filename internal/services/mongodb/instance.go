@@ -17,6 +17,7 @@ import (
 	ipamAPI "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
 	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
@@ -85,6 +86,7 @@ func instanceSchema() map[string]*schema.Schema {
 			Description: "MongoDB version of the instance",
 			ConflictsWith: []string{
 				"snapshot_id",
+				"restore_from_snapshot",
 			},
 		},
 		"node_number": {
@@ -105,6 +107,7 @@ func instanceSchema() map[string]*schema.Schema {
 			Description: "Name of the user created when the cluster is created",
 			ConflictsWith: []string{
 				"snapshot_id",
+				"restore_from_snapshot",
 			},
 		},
 		"password": {
@@ -114,6 +117,7 @@ func instanceSchema() map[string]*schema.Schema {
 			Description: "Password of the user. Only one of `password` or `password_wo` should be specified.",
 			ConflictsWith: []string{
 				"snapshot_id",
+				"restore_from_snapshot",
 				"password_wo",
 			},
 		},
@@ -124,6 +128,7 @@ func instanceSchema() map[string]*schema.Schema {
 			WriteOnly:   true,
 			ConflictsWith: []string{
 				"snapshot_id",
+				"restore_from_snapshot",
 				"password",
 			},
 			RequiredWith: []string{
@@ -153,14 +158,43 @@ func instanceSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.IntDivisibleBy(5),
 		},
 		"snapshot_id": {
-			Type:        schema.TypeString,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+			Description:      "Snapshot ID to restore the MongoDB instance from, the users and databases of the snapshot are kept. The tags, volume size, endpoints and snapshot schedule of the configuration are applied once the instance is ready",
+			ConflictsWith: []string{
+				"user_name",
+				"password",
+				"password_wo",
+				"version",
+				"restore_from_snapshot",
+			},
+		},
+		"restore_from_snapshot": {
+			Type:        schema.TypeList,
 			Optional:    true,
 			ForceNew:    true,
-			Description: "Snapshot ID to restore the MongoDB instance from",
+			MaxItems:    1,
+			Description: "Restore the instance from a snapshot, the users and databases of the snapshot are kept. The tags, volume size, endpoints and snapshot schedule of the configuration are applied once the instance is ready",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"snapshot_id": {
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+						DiffSuppressFunc: dsf.Locality,
+						Description:      "ID of the snapshot to restore",
+					},
+				},
+			},
 			ConflictsWith: []string{
 				"user_name",
 				"password",
+				"password_wo",
 				"version",
+				"snapshot_id",
 			},
 		},
 		"private_network": {
@@ -258,6 +292,12 @@ func instanceSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		"ignore_external_endpoints": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Ignore the endpoints that are not configured on the instance, e.g. the ones managed by `scaleway_mongodb_endpoint`",
+		},
 		"tags": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -310,6 +350,7 @@ func instanceSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "PEM-encoded TLS certificate for MongoDB",
 		},
+		"connection": connection.Schema(),
 	}
 }
 
@@ -322,20 +363,14 @@ func ResourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m any)
 	nodeNumber := new(uint32(d.Get("node_number").(int)))
 
 	snapshotID, exist := d.GetOk("snapshot_id")
+	if restore, ok := d.GetOk("restore_from_snapshot.0.snapshot_id"); ok {
+		snapshotID, exist = restore, true
+	}
 
 	var res *mongodb.Instance
 
 	if exist {
-		restoreSnapshotRequest := &mongodb.RestoreSnapshotRequest{
-			Region:       region,
-			SnapshotID:   regional.ExpandID(snapshotID.(string)).ID,
-			InstanceName: types.ExpandOrGenerateString(d.Get("name"), "mongodb"),
-			NodeAmount:   *nodeNumber,
-			NodeType:     d.Get("node_type").(string),
-			VolumeType:   mongodb.VolumeType(d.Get("volume_type").(string)),
-		}
-
-		res, err = mongodbAPI.RestoreSnapshot(restoreSnapshotRequest, scw.WithContext(ctx))
+		res, err = restoreInstance(ctx, d, mongodbAPI, region, snapshotID.(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -439,6 +474,75 @@ func ResourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m any)
 	return ResourceInstanceRead(ctx, d, m)
 }
 
+// restoreInstance restores a snapshot to a new instance then applies the configuration the restore does not take
+func restoreInstance(ctx context.Context, d *schema.ResourceData, mongodbAPI *mongodb.API, region scw.Region, snapshotID string) (*mongodb.Instance, error) {
+	res, err := mongodbAPI.RestoreSnapshot(&mongodb.RestoreSnapshotRequest{
+		Region:       region,
+		SnapshotID:   regional.ExpandID(snapshotID).ID,
+		InstanceName: types.ExpandOrGenerateString(d.Get("name"), "mongodb"),
+		NodeAmount:   uint32(d.Get("node_number").(int)),
+		NodeType:     d.Get("node_type").(string),
+		VolumeType:   mongodb.VolumeType(d.Get("volume_type").(string)),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// the ID is saved right away so that a failure below taints the instance instead of leaking it
+	if err := identity.SetRegionalIdentity(d, res.Region, res.ID); err != nil {
+		return nil, err
+	}
+
+	instance, err := waitForInstance(ctx, mongodbAPI, res.Region, res.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return nil, err
+	}
+
+	if volumeSize, ok := d.GetOk("volume_size_in_gb"); ok && instance.Volume != nil && scw.Size(uint64(volumeSize.(int))*uint64(scw.GB)) > instance.Volume.SizeBytes {
+		_, err = mongodbAPI.UpgradeInstance(&mongodb.UpgradeInstanceRequest{
+			Region:          res.Region,
+			InstanceID:      res.ID,
+			VolumeSizeBytes: new(scw.Size(uint64(volumeSize.(int)) * uint64(scw.GB))),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = waitForInstance(ctx, mongodbAPI, res.Region, res.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tags, ok := d.GetOk("tags"); ok {
+		_, err = mongodbAPI.UpdateInstance(&mongodb.UpdateInstanceRequest{
+			Region:     res.Region,
+			InstanceID: res.ID,
+			Tags:       types.ExpandStringsPtr(tags),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if privateNetworkID, ok := d.GetOk("private_network.0.pn_id"); ok {
+		_, err = mongodbAPI.CreateEndpoint(&mongodb.CreateEndpointRequest{
+			Region:     res.Region,
+			InstanceID: res.ID,
+			Endpoint: &mongodb.EndpointSpec{
+				PrivateNetwork: &mongodb.EndpointSpecPrivateNetworkDetails{
+					PrivateNetworkID: locality.ExpandID(privateNetworkID),
+				},
+			},
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
 func setInstanceState(ctx context.Context, d *schema.ResourceData, m any, mongodbAPI *mongodb.API, region scw.Region, instance *mongodb.Instance) diag.Diagnostics {
 	_ = d.Set("name", instance.Name)
 	_ = d.Set("version", instance.Version)
@@ -460,7 +564,12 @@ func setInstanceState(ctx context.Context, d *schema.ResourceData, m any, mongod
 		_ = d.Set("volume_size_in_gb", int(instance.Volume.SizeBytes/scw.GB))
 	}
 
-	publicNetworkEndpoint, publicNetworkExists := flattenPublicNetwork(instance.Endpoints)
+	endpoints := instance.Endpoints
+	if d.Get("ignore_external_endpoints").(bool) {
+		endpoints = filterManagedEndpoints(d, endpoints)
+	}
+
+	publicNetworkEndpoint, publicNetworkExists := flattenPublicNetwork(endpoints)
 	if publicNetworkExists {
 		_ = d.Set("public_network", publicNetworkEndpoint)
 	}
@@ -469,12 +578,12 @@ func setInstanceState(ctx context.Context, d *schema.ResourceData, m any, mongod
 	privateIPs := []map[string]any(nil)
 	authorized := true
 
-	privateNetworkEndpoint, privateNetworkExists := flattenPrivateNetwork(instance.Endpoints)
+	privateNetworkEndpoint, privateNetworkExists := flattenPrivateNetwork(endpoints)
 
 	if privateNetworkExists {
 		_ = d.Set("private_network", privateNetworkEndpoint)
 
-		for _, endpoint := range instance.Endpoints {
+		for _, endpoint := range endpoints {
 			if endpoint.PrivateNetwork == nil {
 				continue
 			}
@@ -526,10 +635,13 @@ func setInstanceState(ctx context.Context, d *schema.ResourceData, m any, mongod
 		InstanceID: instance.ID,
 	}, scw.WithContext(ctx))
 
+	caCert := ""
+
 	if err == nil && cert != nil {
 		certBytes, readErr := io.ReadAll(cert.Content)
 		if readErr == nil {
-			_ = d.Set("tls_certificate", string(certBytes))
+			caCert = string(certBytes)
+			_ = d.Set("tls_certificate", caCert)
 		} else {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
@@ -539,6 +651,8 @@ func setInstanceState(ctx context.Context, d *schema.ResourceData, m any, mongod
 		}
 	}
 
+	_ = d.Set("connection", flattenConnection(instance.Endpoints, caCert))
+
 	return diags
 }
 
@@ -712,8 +826,11 @@ func ResourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m any)
 			return diag.FromErr(err)
 		}
 
+		oldEndpointID, _ := d.GetChange("private_network.0.id")
+		ignoreExternal := d.Get("ignore_external_endpoints").(bool)
+
 		for _, e := range res.Endpoints {
-			if e.PrivateNetwork != nil {
+			if e.PrivateNetwork != nil && (!ignoreExternal || e.ID == oldEndpointID.(string)) {
 				err := mongodbAPI.DeleteEndpoint(
 					&mongodb.DeleteEndpointRequest{
 						EndpointID: e.ID, Region: region,
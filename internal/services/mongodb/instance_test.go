@@ -255,6 +255,55 @@ func TestAccMongoDBInstance_FromSnapshot(t *testing.T) {
 	})
 }
 
+func TestAccMongoDBInstance_RestoreFromSnapshot(t *testing.T) {
+	t.Skip("TestAccMongoDBInstance_RestoreFromSnapshot skipped: waiting for stability fix from database team.")
+
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_mongodb_instance" "main" {
+						name        = "test-mongodb-restore-from-snapshot"
+						version     = "7.0.12"
+						node_type   = "MGDB-PLAY2-NANO"
+						node_number = 1
+						user_name   = "my_initial_user"
+						password    = "thiZ_is_v&ry_s3cret"
+					}
+
+					resource "scaleway_mongodb_snapshot" "main_snapshot" {
+						instance_id = scaleway_mongodb_instance.main.id
+						name        = "test-snapshot"
+					}
+
+					resource "scaleway_mongodb_instance" "restored_instance" {
+						name              = "restored-mongodb-from-snapshot"
+						node_type         = "MGDB-PLAY2-NANO"
+						node_number       = 1
+						volume_size_in_gb = 10
+						tags              = ["restored"]
+
+						restore_from_snapshot {
+							snapshot_id = scaleway_mongodb_snapshot.main_snapshot.id
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isMongoDBInstancePresent(tt, "scaleway_mongodb_instance.restored_instance"),
+					resource.TestCheckResourceAttrPair("scaleway_mongodb_instance.restored_instance", "restore_from_snapshot.0.snapshot_id", "scaleway_mongodb_snapshot.main_snapshot", "id"),
+					resource.TestCheckResourceAttr("scaleway_mongodb_instance.restored_instance", "volume_size_in_gb", "10"),
+					resource.TestCheckResourceAttr("scaleway_mongodb_instance.restored_instance", "tags.0", "restored"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccMongoDBInstance_WithPrivateNetwork(t *testing.T) {
 	tt := acctest.NewTestTools(t)
 	defer tt.Cleanup()
@@ -1,9 +1,13 @@
 package mongodb
 
 import (
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	mongodb "github.com/scaleway/scaleway-sdk-go/api/mongodb/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 )
 
 func flattenPublicNetwork(endpoints []*mongodb.Endpoint) (any, bool) {
@@ -47,6 +51,27 @@ func flattenPrivateNetwork(endpoints []*mongodb.Endpoint) (any, bool) {
 	return privateFlat, len(privateFlat) != 0
 }
 
+// flattenConnection returns the connection block of an instance, all its endpoints are considered
+// including the ones managed by scaleway_mongodb_endpoint
+func flattenConnection(endpoints []*mongodb.Endpoint, caCert string) []map[string]any {
+	connectionEndpoints := make([]connection.Endpoint, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		kind := connection.KindPublicNetwork
+		if endpoint.PrivateNetwork != nil {
+			kind = connection.KindPrivateNetwork
+		}
+
+		connectionEndpoints = append(connectionEndpoints, connection.Endpoint{
+			Kind: kind,
+			Host: endpoint.DNSRecord,
+			Port: int(endpoint.Port),
+		})
+	}
+
+	return connection.Flatten(connection.EngineMongoDB, connectionEndpoints, caCert, true)
+}
+
 func NormalizeMongoDBVersion(version string) string {
 	parts := strings.Split(version, ".")
 	if len(parts) >= 2 {
@@ -55,3 +80,18 @@ func NormalizeMongoDBVersion(version string) string {
 
 	return version
 }
+
+// filterManagedEndpoints keeps the endpoints of the kinds configured on the instance,
+// the private endpoint is matched on its private network
+func filterManagedEndpoints(d *schema.ResourceData, endpoints []*mongodb.Endpoint) []*mongodb.Endpoint {
+	privateNetworkID := locality.ExpandID(d.Get("private_network.0.pn_id").(string))
+	_, publicNetworkConfigured := d.GetOk("public_network")
+
+	return slices.DeleteFunc(slices.Clone(endpoints), func(endpoint *mongodb.Endpoint) bool {
+		if endpoint.PrivateNetwork != nil {
+			return privateNetworkID == "" || endpoint.PrivateNetwork.PrivateNetworkID != privateNetworkID
+		}
+
+		return endpoint.PublicNetwork != nil && !publicNetworkConfigured
+	})
+}
@@ -124,14 +124,15 @@ func userSchema() map[string]*schema.Schema {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"role": {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "Role name (read, read_write, db_admin, sync)",
+						Type:             schema.TypeString,
+						Required:         true,
+						ValidateDiagFunc: verify.ValidateEnum[mongodb.UserRoleRole](),
+						Description:      "Role name (read, read_write, db_admin, sync)",
 					},
 					"database_name": {
 						Type:        schema.TypeString,
 						Optional:    true,
-						Description: "Database name for the role",
+						Description: "Database the role is scoped to, e.g. the name of a `scaleway_mongodb_database`",
 					},
 					"any_database": {
 						Type:        schema.TypeBool,
@@ -267,10 +268,8 @@ func ResourceUserRead(ctx context.Context, d *schema.ResourceData, m any) diag.D
 		_ = d.Set("password", d.Get("password"))
 	}
 
-	// Set user roles
-	if len(user.Roles) > 0 {
-		_ = d.Set("roles", flattenUserRoles(user.Roles))
-	}
+	// roles removed outside of Terraform must show up as a diff
+	_ = d.Set("roles", flattenUserRoles(user.Roles))
 
 	return nil
 }
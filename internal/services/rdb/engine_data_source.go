@@ -0,0 +1,266 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+func DataSourceEngine() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceRDBEngineRead,
+		SchemaFunc:  engineSchema,
+	}
+}
+
+func engineSettingSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the setting",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the setting",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the setting value (boolean, int, float or string)",
+			},
+			"unit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unit of the setting value",
+			},
+			"default_value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Default value of the setting",
+			},
+			"min": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Minimum value of a numeric setting, empty when unbounded",
+			},
+			"max": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Maximum value of a numeric setting, empty when unbounded",
+			},
+			"string_constraint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Regular expression the value of a string setting must match",
+			},
+			"restart_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether changing the setting restarts the database instance",
+			},
+		},
+	}
+}
+
+func engineSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the database engine (e.g., 'PostgreSQL', 'MySQL')",
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Version of the engine (e.g., '16'), defaults to the latest enabled version",
+		},
+		"region": regional.Schema(),
+		// Computed
+		"version_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Engine version name to use as the engine of a database instance (e.g., 'PostgreSQL-16')",
+		},
+		"end_of_life": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "End of life date of the version (RFC 3339 format)",
+		},
+		"upgradable_to": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Engine version names a database instance running this version can be upgraded to",
+		},
+		"versions": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Versions of the engine, from the newest to the oldest",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Engine version name (e.g., 'PostgreSQL-16')",
+					},
+					"version": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Version of the engine (e.g., '16')",
+					},
+					"end_of_life": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "End of life date of the version (RFC 3339 format)",
+					},
+					"disabled": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether new database instances can no longer use this version",
+					},
+					"beta": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the version is in beta",
+					},
+				},
+			},
+		},
+		"settings": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Settings that can be set on a running database instance of this version",
+			Elem:        engineSettingSchema(),
+		},
+		"init_settings": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Settings that can be set at the initialisation of a database instance of this version",
+			Elem:        engineSettingSchema(),
+		},
+	}
+}
+
+func flattenEngineSettings(settings []*rdb.EngineSetting) []map[string]any {
+	res := make([]map[string]any, 0, len(settings))
+
+	for _, setting := range settings {
+		minValue, maxValue := "", ""
+
+		switch setting.PropertyType {
+		case rdb.EngineSettingPropertyTypeInt:
+			if setting.IntMin != nil {
+				minValue = strconv.FormatInt(int64(*setting.IntMin), 10)
+			}
+
+			if setting.IntMax != nil {
+				maxValue = strconv.FormatInt(int64(*setting.IntMax), 10)
+			}
+		case rdb.EngineSettingPropertyTypeFloat:
+			if setting.FloatMin != nil {
+				minValue = strconv.FormatFloat(float64(*setting.FloatMin), 'g', -1, 32)
+			}
+
+			if setting.FloatMax != nil {
+				maxValue = strconv.FormatFloat(float64(*setting.FloatMax), 'g', -1, 32)
+			}
+		}
+
+		res = append(res, map[string]any{
+			"name":              setting.Name,
+			"description":       setting.Description,
+			"type":              setting.PropertyType.String(),
+			"unit":              types.FlattenStringPtr(setting.Unit),
+			"default_value":     setting.DefaultValue,
+			"min":               minValue,
+			"max":               maxValue,
+			"string_constraint": types.FlattenStringPtr(setting.StringConstraint),
+			"restart_required":  !setting.HotConfigurable,
+		})
+	}
+
+	return res
+}
+
+func DataSourceRDBEngineRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	rdbAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	engines, err := listDatabaseEngines(ctx, rdbAPI, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	engineName := d.Get("name").(string)
+
+	var engine *rdb.DatabaseEngine
+
+	for _, candidate := range engines {
+		if strings.EqualFold(candidate.Name, engineName) {
+			engine = candidate
+
+			break
+		}
+	}
+
+	if engine == nil {
+		return diag.FromErr(fmt.Errorf("no database engine found with the name %s in region %s", engineName, region))
+	}
+
+	var selected *rdb.EngineVersion
+
+	versionName, versionSet := d.GetOk("version")
+
+	versions := make([]map[string]any, 0, len(engine.Versions))
+	for _, version := range engine.Versions {
+		versions = append(versions, map[string]any{
+			"name":        version.Name,
+			"version":     version.Version,
+			"end_of_life": types.FlattenTime(version.EndOfLife),
+			"disabled":    version.Disabled,
+			"beta":        version.Beta,
+		})
+
+		if selected != nil {
+			continue
+		}
+
+		if (versionSet && version.Version == versionName.(string)) || (!versionSet && !version.Disabled) {
+			selected = version
+		}
+	}
+
+	if selected == nil {
+		if versionSet {
+			return diag.FromErr(fmt.Errorf("no version %s found for database engine %s", versionName, engine.Name))
+		}
+
+		return diag.FromErr(fmt.Errorf("no enabled version found for database engine %s", engine.Name))
+	}
+
+	d.SetId(regional.NewIDString(region, selected.Name))
+	_ = d.Set("name", engine.Name)
+	_ = d.Set("version", selected.Version)
+	_ = d.Set("region", region.String())
+	_ = d.Set("version_name", selected.Name)
+	_ = d.Set("end_of_life", types.FlattenTime(selected.EndOfLife))
+	_ = d.Set("upgradable_to", EngineUpgradePaths(engine.Versions, selected.Version))
+	_ = d.Set("versions", versions)
+	_ = d.Set("settings", flattenEngineSettings(selected.AvailableSettings))
+	_ = d.Set("init_settings", flattenEngineSettings(selected.AvailableInitSettings))
+
+	return nil
+}
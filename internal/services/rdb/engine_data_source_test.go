@@ -0,0 +1,52 @@
+package rdb_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccDataSourceEngine_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "scaleway_rdb_engine" "latest" {
+						name = "PostgreSQL"
+					}
+
+					data "scaleway_rdb_engine" "pinned" {
+						name    = "PostgreSQL"
+						version = data.scaleway_rdb_engine.latest.version
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.scaleway_rdb_engine.latest", "version"),
+					resource.TestMatchResourceAttr("data.scaleway_rdb_engine.latest", "version_name", regexp.MustCompile(`^PostgreSQL-\d+$`)),
+					resource.TestCheckResourceAttrSet("data.scaleway_rdb_engine.latest", "versions.#"),
+					resource.TestCheckResourceAttrSet("data.scaleway_rdb_engine.latest", "settings.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.scaleway_rdb_engine.latest", "settings.*", map[string]string{
+						"name": "work_mem",
+					}),
+					resource.TestCheckResourceAttrPair("data.scaleway_rdb_engine.pinned", "version_name", "data.scaleway_rdb_engine.latest", "version_name"),
+					resource.TestCheckResourceAttrPair("data.scaleway_rdb_engine.pinned", "settings.#", "data.scaleway_rdb_engine.latest", "settings.#"),
+				),
+			},
+			{
+				Config: `
+					data "scaleway_rdb_engine" "unknown" {
+						name    = "PostgreSQL"
+						version = "1"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`no version 1 found for database engine PostgreSQL`),
+			},
+		},
+	})
+}
@@ -0,0 +1,244 @@
+package rdb
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/pgsql"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+func ResourceGrant() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRdbGrantCreate,
+		ReadContext:   ResourceRdbGrantRead,
+		UpdateContext: ResourceRdbGrantUpdate,
+		DeleteContext: ResourceRdbGrantDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Read:    schema.DefaultTimeout(defaultInstanceTimeout),
+			Update:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importGrantState,
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    grantSchema,
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("instance_id", "serverless_database_id"),
+			customizeDiffGrant,
+			customizeDiffPgsqlWriteOnlyRefresh(grantFingerprint),
+		),
+	}
+}
+
+func grantSchema() map[string]*schema.Schema {
+	var privileges []string
+	for _, objectPrivileges := range pgsql.Privileges {
+		privileges = append(privileges, objectPrivileges...)
+	}
+
+	slices.Sort(privileges)
+
+	s := pgsqlConnectionSchema()
+
+	s["role"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The role the privileges are granted to, `public` for every role",
+	}
+	s["object_type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(pgsql.ObjectTypes, false),
+		Description:  "The type of the objects: database, schema, table, sequence or function",
+	}
+	s["schema"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The schema of the grant, required unless object_type is database",
+	}
+	s["objects"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		ForceNew:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The tables, sequences or functions of the schema, every object of this type in the schema when empty",
+	}
+	s["privileges"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(slices.Compact(privileges), false),
+		},
+		Description: "The privileges to grant, for instance SELECT or USAGE",
+	}
+	s["with_grant_option"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Allow the role to grant the privileges to other roles",
+	}
+	s["default_privileges_for"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Also grant the privileges on the objects this role creates in the schema later on",
+	}
+
+	return s
+}
+
+func customizeDiffGrant(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	for _, key := range []string{"object_type", "schema", "objects", "privileges", "default_privileges_for"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	return pgsql.ValidateGrant(expandGrant(diff))
+}
+
+type grantResourceData interface {
+	Get(key string) any
+}
+
+func expandGrant(d grantResourceData) *pgsql.Grant {
+	return &pgsql.Grant{
+		Role:                 d.Get("role").(string),
+		ObjectType:           d.Get("object_type").(string),
+		Database:             d.Get("database_name").(string),
+		Schema:               d.Get("schema").(string),
+		Objects:              types.ExpandStrings(d.Get("objects").(*schema.Set).List()),
+		Privileges:           types.ExpandStrings(d.Get("privileges").(*schema.Set).List()),
+		WithGrantOption:      d.Get("with_grant_option").(bool),
+		DefaultPrivilegesFor: d.Get("default_privileges_for").(string),
+	}
+}
+
+func ResourceRdbGrantCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	region, err := meta.ExtractRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	conn, err := pgsqlConnect(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer conn.Close()
+
+	grant := expandGrant(d)
+
+	err = conn.Exec(ctx, pgsql.GrantSQL(grant))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(pgsqlObjectID(region, pgsqlTargetID(d), grant.Database, grant.Role, grant.ObjectType, grant.Schema))
+
+	return readGrant(ctx, d, conn)
+}
+
+func ResourceRdbGrantRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, diags := pgsqlReadConnect(ctx, d, m)
+	if conn == nil {
+		return diags
+	}
+	defer conn.Close()
+
+	return readGrant(ctx, d, conn)
+}
+
+func readGrant(ctx context.Context, d *schema.ResourceData, conn *pgsql.Conn) diag.Diagnostics {
+	grant := expandGrant(d)
+
+	state, err := pgsql.ReadGrant(ctx, conn, grant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if state.ObjectCount == 0 {
+		// a grant on every object of an empty schema has nothing to compare
+		if grant.ObjectType == pgsql.ObjectTypeDatabase || grant.ObjectType == pgsql.ObjectTypeSchema || len(grant.Objects) > 0 {
+			d.SetId("")
+		}
+
+		return nil
+	}
+
+	_ = d.Set("privileges", state.Privileges)
+	_ = d.Set("with_grant_option", state.WithGrantOption)
+	_ = d.Set("fingerprint", pgsqlFingerprint(state.Privileges, state.WithGrantOption))
+
+	return nil
+}
+
+func grantFingerprint(ctx context.Context, conn *pgsql.Conn, d pgsqlResourceData) (string, error) {
+	state, err := pgsql.ReadGrant(ctx, conn, expandGrant(d))
+	if err != nil || state.ObjectCount == 0 {
+		return "", err
+	}
+
+	return pgsqlFingerprint(state.Privileges, state.WithGrantOption), nil
+}
+
+// importGrantState imports IDs such as {region}/{target}/{database_name}/{role}/{object_type}/{schema}, followed by
+// /{objects} with the comma separated objects of a grant restricted to some objects
+func importGrantState(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	if parts := strings.Split(d.Id(), "/"); len(parts) == 7 {
+		_ = d.Set("objects", strings.Split(parts[6], ","))
+		d.SetId(strings.Join(parts[:6], "/"))
+	}
+
+	return pgsqlImportState("role", "object_type", "schema")(ctx, d, m)
+}
+
+func ResourceRdbGrantUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, err := pgsqlConnect(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer conn.Close()
+
+	// With password_wo, the update may come from a change made outside of Terraform that the plan detected
+	if d.HasChanges("privileges", "with_grant_option", "fingerprint") || pgsqlWriteOnly(d) {
+		grant := expandGrant(d)
+
+		// statements sent together run in a single transaction, the role never loses the privileges it keeps
+		err = conn.Exec(ctx, pgsql.RevokeSQL(grant)+"; "+pgsql.GrantSQL(grant))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return readGrant(ctx, d, conn)
+}
+
+func ResourceRdbGrantDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, diags := pgsqlDeleteConnect(ctx, d, m, "grant to "+d.Get("role").(string))
+	if conn == nil {
+		return diags
+	}
+	defer conn.Close()
+
+	err := conn.Exec(ctx, pgsql.RevokeSQL(expandGrant(d)))
+	if err != nil && !isPgsqlObjectNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
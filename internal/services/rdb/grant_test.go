@@ -0,0 +1,135 @@
+package rdb_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	rdbchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb/testfuncs"
+)
+
+func TestAccGrant_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+	config := pgsqlObjectsConfig("test-rdb-grant-basic", latestEngineVersion) + `
+		resource "scaleway_rdb_schema" "app" {
+		  instance_id   = scaleway_rdb_instance.main.id
+		  database_name = scaleway_rdb_database.main.name
+		  user_name     = scaleway_rdb_instance.main.user_name
+		  password      = scaleway_rdb_instance.main.password
+
+		  name = "app"
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+					resource "scaleway_rdb_grant" "app" {
+					  instance_id   = scaleway_rdb_instance.main.id
+					  database_name = scaleway_rdb_database.main.name
+					  user_name     = scaleway_rdb_instance.main.user_name
+					  password      = scaleway_rdb_instance.main.password
+
+					  role        = scaleway_rdb_user.app.name
+					  object_type = "schema"
+					  schema      = scaleway_rdb_schema.app.name
+					  privileges  = ["USAGE"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_grant.app", "privileges.#", "1"),
+					resource.TestCheckTypeSetElemAttr("scaleway_rdb_grant.app", "privileges.*", "USAGE"),
+					resource.TestCheckResourceAttr("scaleway_rdb_grant.app", "with_grant_option", "false"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_grant.app", "fingerprint"),
+				),
+			},
+			{
+				Config: config + `
+					resource "scaleway_rdb_grant" "app" {
+					  instance_id   = scaleway_rdb_instance.main.id
+					  database_name = scaleway_rdb_database.main.name
+					  user_name     = scaleway_rdb_instance.main.user_name
+					  password      = scaleway_rdb_instance.main.password
+
+					  role        = scaleway_rdb_user.app.name
+					  object_type = "schema"
+					  schema      = scaleway_rdb_schema.app.name
+					  privileges  = ["USAGE", "CREATE"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_grant.app", "privileges.#", "2"),
+					resource.TestCheckTypeSetElemAttr("scaleway_rdb_grant.app", "privileges.*", "CREATE"),
+				),
+			},
+			{
+				ResourceName:            "scaleway_rdb_grant.app",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: append(pgsqlObjectsImportStateVerifyIgnore, "privileges", "with_grant_option"),
+			},
+		},
+	})
+}
+
+func TestAccGrant_WriteOnlyPasswordDestroy(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+	config := pgsqlObjectsConfig("test-rdb-grant-write-only", latestEngineVersion)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+					resource "scaleway_rdb_grant" "app" {
+					  instance_id         = scaleway_rdb_instance.main.id
+					  database_name       = scaleway_rdb_database.main.name
+					  user_name           = scaleway_rdb_instance.main.user_name
+					  password_wo         = "thiZ_is_v&ry_s3cret"
+					  password_wo_version = 1
+
+					  role        = scaleway_rdb_user.app.name
+					  object_type = "database"
+					  privileges  = ["CONNECT"]
+					}
+				`,
+				Check: resource.TestCheckResourceAttrSet("scaleway_rdb_grant.app", "fingerprint"),
+			},
+			{
+				// The write-only password is not available on destroy and the grant would be left in the database
+				Config:      config,
+				ExpectError: regexp.MustCompile("cannot remove grant to app"),
+			},
+			{
+				Config: config + `
+					resource "scaleway_rdb_grant" "app" {
+					  instance_id         = scaleway_rdb_instance.main.id
+					  database_name       = scaleway_rdb_database.main.name
+					  user_name           = scaleway_rdb_instance.main.user_name
+					  password_wo         = "thiZ_is_v&ry_s3cret"
+					  password_wo_version = 1
+					  keep_on_destroy     = true
+
+					  role        = scaleway_rdb_user.app.name
+					  object_type = "database"
+					  privileges  = ["CONNECT"]
+					}
+				`,
+			},
+			{
+				Config: config,
+			},
+		},
+	})
+}
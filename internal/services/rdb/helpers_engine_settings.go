@@ -0,0 +1,322 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// engineCatalogue caches the database engines of each region, they only change with Scaleway releases
+var engineCatalogue = struct {
+	sync.Mutex
+	engines map[scw.Region][]*rdb.DatabaseEngine
+}{
+	engines: map[scw.Region][]*rdb.DatabaseEngine{},
+}
+
+// listDatabaseEngines returns the database engines of a region, fetching them once per provider run
+func listDatabaseEngines(ctx context.Context, rdbAPI *rdb.API, region scw.Region) ([]*rdb.DatabaseEngine, error) {
+	engineCatalogue.Lock()
+	defer engineCatalogue.Unlock()
+
+	if engines, ok := engineCatalogue.engines[region]; ok {
+		return engines, nil
+	}
+
+	res, err := rdbAPI.ListDatabaseEngines(&rdb.ListDatabaseEnginesRequest{
+		Region: region,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	engineCatalogue.engines[region] = res.Engines
+
+	return res.Engines, nil
+}
+
+// FindEngineVersion returns the version matching an engine version name such as "PostgreSQL-16", case insensitive
+func FindEngineVersion(engines []*rdb.DatabaseEngine, versionName string) (*rdb.DatabaseEngine, *rdb.EngineVersion) {
+	for _, engine := range engines {
+		for _, version := range engine.Versions {
+			if strings.EqualFold(version.Name, versionName) {
+				return engine, version
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// EngineUpgradePaths returns the names of the enabled versions newer than currentVersion.
+// The API lists the versions of an engine from the newest to the oldest.
+func EngineUpgradePaths(versions []*rdb.EngineVersion, currentVersion string) []string {
+	paths := []string(nil)
+
+	for _, version := range versions {
+		if version.Version == currentVersion {
+			return paths
+		}
+
+		if !version.Disabled {
+			paths = append(paths, version.Name)
+		}
+	}
+
+	return nil
+}
+
+// ValidateEngineSetting checks a setting value against its definition in the engine catalogue
+func ValidateEngineSetting(setting *rdb.EngineSetting, value string) error {
+	switch setting.PropertyType {
+	case rdb.EngineSettingPropertyTypeBoolean:
+		switch strings.ToLower(value) {
+		case "true", "false", "on", "off", "1", "0":
+			return nil
+		}
+
+		return fmt.Errorf("setting %q must be a boolean, got %q", setting.Name, value)
+	case rdb.EngineSettingPropertyTypeInt:
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("setting %q must be an integer, got %q", setting.Name, value)
+		}
+
+		if setting.IntMin != nil && intValue < int64(*setting.IntMin) {
+			return fmt.Errorf("setting %q must be at least %d%s, got %s", setting.Name, *setting.IntMin, settingUnit(setting), value)
+		}
+
+		if setting.IntMax != nil && intValue > int64(*setting.IntMax) {
+			return fmt.Errorf("setting %q must be at most %d%s, got %s", setting.Name, *setting.IntMax, settingUnit(setting), value)
+		}
+	case rdb.EngineSettingPropertyTypeFloat:
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("setting %q must be a number, got %q", setting.Name, value)
+		}
+
+		if setting.FloatMin != nil && floatValue < float64(*setting.FloatMin) {
+			return fmt.Errorf("setting %q must be at least %g%s, got %s", setting.Name, *setting.FloatMin, settingUnit(setting), value)
+		}
+
+		if setting.FloatMax != nil && floatValue > float64(*setting.FloatMax) {
+			return fmt.Errorf("setting %q must be at most %g%s, got %s", setting.Name, *setting.FloatMax, settingUnit(setting), value)
+		}
+	case rdb.EngineSettingPropertyTypeString:
+		if setting.StringConstraint == nil || *setting.StringConstraint == "" {
+			return nil
+		}
+
+		// A constraint that is not a Go regular expression is left to the API
+		constraint, err := regexp.Compile(*setting.StringConstraint)
+		if err == nil && !constraint.MatchString(value) {
+			return fmt.Errorf("setting %q must match %q, got %q", setting.Name, *setting.StringConstraint, value)
+		}
+	}
+
+	return nil
+}
+
+func settingUnit(setting *rdb.EngineSetting) string {
+	if setting.Unit == nil || *setting.Unit == "" {
+		return ""
+	}
+
+	return " " + *setting.Unit
+}
+
+// ValidateEngineSettings checks settings against the settings available for an engine version.
+// It returns the validation errors and the names of the valid settings that need an instance restart to apply.
+func ValidateEngineSettings(settings map[string]string, available []*rdb.EngineSetting) ([]error, []string) {
+	definitions := make(map[string]*rdb.EngineSetting, len(available))
+	for _, setting := range available {
+		definitions[setting.Name] = setting
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var (
+		errs    []error
+		restart []string
+	)
+
+	for _, name := range names {
+		definition, ok := definitions[name]
+		if !ok {
+			errs = append(errs, unknownSettingError(name, definitions))
+
+			continue
+		}
+
+		if err := ValidateEngineSetting(definition, settings[name]); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if !definition.HotConfigurable {
+			restart = append(restart, name)
+		}
+	}
+
+	return errs, restart
+}
+
+// unknownSettingError suggests the closest available setting, "max_connection" is a common mistake for "max_connections"
+func unknownSettingError(name string, definitions map[string]*rdb.EngineSetting) error {
+	if best := verify.ClosestMatch(name, slices.Collect(maps.Keys(definitions))); best != "" {
+		return fmt.Errorf("unknown setting %q, did you mean %q?", name, best)
+	}
+
+	return fmt.Errorf("unknown setting %q, see the scaleway_rdb_engine data source for the available settings", name)
+}
+
+// changedSettings returns the settings of newValue that are not set to the same value in oldValue
+func changedSettings(oldValue, newValue any) map[string]string {
+	oldSettings, _ := oldValue.(map[string]any)
+	newSettings, _ := newValue.(map[string]any)
+	changed := make(map[string]string, len(newSettings))
+
+	for name, value := range newSettings {
+		if oldSettings[name] != value {
+			changed[name] = value.(string)
+		}
+	}
+
+	return changed
+}
+
+// customizeDiffEngineSettings validates settings, and init_settings when withInitSettings is set, against the engine catalogue at plan time.
+// The validation is best effort: it is skipped when the engine is unknown or the catalogue cannot be fetched.
+func customizeDiffEngineSettings(withInitSettings bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+		settingsChanged := diff.HasChange("settings")
+		initSettingsChanged := withInitSettings && diff.HasChange("init_settings")
+
+		if !settingsChanged && !initSettingsChanged {
+			return nil
+		}
+
+		if !diff.NewValueKnown("engine") || !diff.NewValueKnown("settings") ||
+			(withInitSettings && !diff.NewValueKnown("init_settings")) {
+			return nil
+		}
+
+		engineName := diff.Get("engine").(string)
+		if engineName == "" {
+			return nil
+		}
+
+		region, _ := meta.ExtractRegion(diff, m)
+
+		engines, err := listDatabaseEngines(ctx, newAPI(m), region)
+		if err != nil {
+			tflog.Warn(ctx, "could not fetch the engine catalogue, settings will be validated by the API", map[string]any{"error": err.Error()})
+
+			return nil
+		}
+
+		_, version := FindEngineVersion(engines, engineName)
+		if version == nil {
+			return nil
+		}
+
+		var errs []error
+
+		if settingsChanged {
+			// Settings restarting the instance are planned by customizeDiffPendingRestartSettings
+			settingsErrs, _ := ValidateEngineSettings(changedSettings(diff.GetChange("settings")), version.AvailableSettings)
+			errs = append(errs, settingsErrs...)
+		}
+
+		if initSettingsChanged {
+			initSettingsErrs, _ := ValidateEngineSettings(changedSettings(diff.GetChange("init_settings")), version.AvailableInitSettings)
+			errs = append(errs, initSettingsErrs...)
+		}
+
+		if len(errs) == 0 {
+			return nil
+		}
+
+		messages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+
+		return fmt.Errorf("invalid settings for engine %s:\n  - %s", version.Name, strings.Join(messages, "\n  - "))
+	}
+}
+
+// customizeDiffPendingRestartSettings plans the changed settings which are not hot configurable in
+// pending_restart_settings, so the restart of the instance is shown in the plan. The update writes the same list.
+func customizeDiffPendingRestartSettings(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if diff.Id() == "" || !diff.HasChange("settings") {
+		return nil
+	}
+
+	if !diff.NewValueKnown("engine") || !diff.NewValueKnown("settings") {
+		return diff.SetNewComputed("pending_restart_settings")
+	}
+
+	region, _ := meta.ExtractRegion(diff, m)
+
+	engines, err := listDatabaseEngines(ctx, newAPI(m), region)
+	if err != nil {
+		return diff.SetNewComputed("pending_restart_settings")
+	}
+
+	_, version := FindEngineVersion(engines, diff.Get("engine").(string))
+	if version == nil {
+		return diff.SetNewComputed("pending_restart_settings")
+	}
+
+	_, restart := ValidateEngineSettings(changedSettings(diff.GetChange("settings")), version.AvailableSettings)
+
+	return diff.SetNew("pending_restart_settings", restart)
+}
+
+// settingsRequiringRestart returns the names of the changed settings that are not hot configurable
+func settingsRequiringRestart(ctx context.Context, rdbAPI *rdb.API, region scw.Region, engineName string, oldValue, newValue any) []string {
+	engines, err := listDatabaseEngines(ctx, rdbAPI, region)
+	if err != nil {
+		return nil
+	}
+
+	_, version := FindEngineVersion(engines, engineName)
+	if version == nil {
+		return nil
+	}
+
+	restart := []string(nil)
+
+	for name := range changedSettings(oldValue, newValue) {
+		idx := slices.IndexFunc(version.AvailableSettings, func(setting *rdb.EngineSetting) bool {
+			return setting.Name == name
+		})
+		if idx >= 0 && !version.AvailableSettings[idx].HotConfigurable {
+			restart = append(restart, name)
+		}
+	}
+
+	sort.Strings(restart)
+
+	return restart
+}
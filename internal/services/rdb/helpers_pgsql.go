@@ -0,0 +1,519 @@
+package rdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	sdbSDK "github.com/scaleway/scaleway-sdk-go/api/serverless_sqldb/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/pgsql"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+const (
+	sslModeDisable    = "disable"
+	sslModeRequire    = "require"
+	sslModeVerifyCA   = "verify-ca"
+	sslModeVerifyFull = "verify-full"
+
+	pgsqlDefaultPort = 5432
+)
+
+// errPgsqlNoPassword is returned outside of plan, create and update when the password is write-only
+var errPgsqlNoPassword = errors.New("the password is not available, password_wo can only be read during plan, create and update")
+
+// pgsqlResourceData is implemented by schema.ResourceData and schema.ResourceDiff: objects managed with a write-only
+// password are refreshed during the plan, the only time besides create and update the password is available
+type pgsqlResourceData interface {
+	HasChange(key string) bool
+	GetOk(key string) (any, bool)
+	Get(key string) any
+	Id() string
+	GetRawConfig() cty.Value
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
+}
+
+// pgsqlFingerprintFunc reads an object from the database and returns its fingerprint, empty when the object is gone
+type pgsqlFingerprintFunc func(ctx context.Context, conn *pgsql.Conn, d pgsqlResourceData) (string, error)
+
+// pgsqlConnectionSchema returns the arguments shared by the resources managing objects inside a PostgreSQL database.
+// The server is an RDB instance, a Serverless SQL database or any host reachable by Terraform.
+func pgsqlConnectionSchema() map[string]*schema.Schema {
+	targets := []string{"instance_id", "serverless_database_id", "host"}
+
+	return map[string]*schema.Schema{
+		"instance_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			ConflictsWith:    []string{"serverless_database_id"},
+			AtLeastOneOf:     targets,
+			Description:      "The RDB instance to connect to, through its load balancer endpoint unless `host` is set",
+		},
+		"serverless_database_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			ConflictsWith:    []string{"instance_id"},
+			AtLeastOneOf:     targets,
+			Description:      "The Serverless SQL database to connect to",
+		},
+		"host": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: targets,
+			Description:  "The host to connect to, overrides the endpoint of the instance, for instance with a private network IP",
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "The port to connect to, overrides the port of the endpoint. Defaults to 5432 with `host`",
+		},
+		"sslmode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      sslModeVerifyCA,
+			ValidateFunc: validation.StringInSlice([]string{sslModeDisable, sslModeRequire, sslModeVerifyCA, sslModeVerifyFull}, false),
+			Description:  "TLS mode of the connection: disable, require, verify-ca (certificate of the instance or trusted CA) or verify-full (verify-ca and host name)",
+		},
+		"database_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The database in which the object is managed",
+		},
+		"user_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The user to connect as, for instance a `scaleway_rdb_user`. Serverless SQL databases expect an IAM principal ID",
+		},
+		"password": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ExactlyOneOf: []string{"password", "password_wo"},
+			Description:  "The password of the user. Serverless SQL databases expect an IAM secret key",
+		},
+		"password_wo": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			WriteOnly:    true,
+			ExactlyOneOf: []string{"password", "password_wo"},
+			RequiredWith: []string{"password_wo_version"},
+			Description:  "The password of the user in [write-only](https://registry.terraform.io/providers/scaleway/scaleway/latest/docs/guides/using-write-only-arguments) mode. The password is only available during plan, create and update: the object is compared with the database during the plan instead of the refresh, and cannot be removed on destroy unless `keep_on_destroy` is set.",
+		},
+		"password_wo_version": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			RequiredWith: []string{"password_wo"},
+			Description:  "The version of the [write-only](https://registry.terraform.io/providers/scaleway/scaleway/latest/docs/guides/using-write-only-arguments) password.",
+		},
+		"keep_on_destroy": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Only remove the object from the state on destroy. Required to destroy an object managed with `password_wo`, whose value is not available on destroy",
+		},
+		"fingerprint": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Digest of the object as last read from the database, the object is updated when the database no longer matches it",
+		},
+		"region": regional.Schema(),
+	}
+}
+
+// pgsqlTargetID returns the part of the resource ID identifying the server
+func pgsqlTargetID(d *schema.ResourceData) string {
+	switch {
+	case d.Get("instance_id").(string) != "":
+		return locality.ExpandID(d.Get("instance_id"))
+	case d.Get("serverless_database_id").(string) != "":
+		return locality.ExpandID(d.Get("serverless_database_id"))
+	default:
+		return d.Get("host").(string)
+	}
+}
+
+func pgsqlPassword(d pgsqlResourceData) string {
+	if password := d.Get("password").(string); password != "" {
+		return password
+	}
+
+	// the raw configuration is only set during create and update
+	password, _ := meta.GetRawConfigForKey(d, "password_wo", cty.String)
+	if password == nil {
+		return ""
+	}
+
+	return password.(string)
+}
+
+// pgsqlConnect opens a connection to the database of the resource
+func pgsqlConnect(ctx context.Context, d pgsqlResourceData, m any) (*pgsql.Conn, error) {
+	password := pgsqlPassword(d)
+	if password == "" {
+		return nil, errPgsqlNoPassword
+	}
+
+	config, roots, err := pgsqlEndpoint(ctx, d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if host := d.Get("host").(string); host != "" {
+		config.Host = host
+	}
+
+	if port := d.Get("port").(int); port != 0 {
+		config.Port = port
+	} else if config.Port == 0 {
+		config.Port = pgsqlDefaultPort
+	}
+
+	config.User = d.Get("user_name").(string)
+	config.Password = password
+	config.Database = d.Get("database_name").(string)
+	config.TLS = pgsqlTLSConfig(d.Get("sslmode").(string), config.Host, roots)
+
+	conn, err := pgsql.Connect(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", net.JoinHostPort(config.Host, strconv.Itoa(config.Port)), err)
+	}
+
+	return conn, nil
+}
+
+// pgsqlEndpoint returns the address of the server and, for RDB instances, the certificate authorities to trust
+func pgsqlEndpoint(ctx context.Context, d pgsqlResourceData, m any) (*pgsql.Config, *x509.CertPool, error) {
+	region, err := meta.ExtractRegion(d, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if databaseID := d.Get("serverless_database_id").(string); databaseID != "" {
+		database, err := sdbSDK.NewAPI(meta.ExtractScwClient(m)).GetDatabase(&sdbSDK.GetDatabaseRequest{
+			Region:     region,
+			DatabaseID: locality.ExpandID(databaseID),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		config, err := pgsql.ParseURL(database.Endpoint)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid endpoint of serverless database %s: %w", database.ID, err)
+		}
+
+		return config, nil, nil
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	if instanceID == "" {
+		return &pgsql.Config{}, nil, nil
+	}
+
+	api := newAPI(m)
+
+	instance, err := api.GetInstance(&rdb.GetInstanceRequest{
+		Region:     region,
+		InstanceID: locality.ExpandID(instanceID),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &pgsql.Config{}
+
+	if endpoint := pgsqlInstanceEndpoint(instance.Endpoints); endpoint != nil {
+		if endpoint.IP != nil {
+			config.Host = endpoint.IP.String()
+		} else if endpoint.Hostname != nil {
+			config.Host = *endpoint.Hostname
+		}
+
+		config.Port = int(endpoint.Port)
+	}
+
+	if config.Host == "" && d.Get("host").(string) == "" {
+		return nil, nil, fmt.Errorf("instance %s has no endpoint, set host to connect to it", instance.ID)
+	}
+
+	if d.Get("sslmode").(string) != sslModeVerifyCA && d.Get("sslmode").(string) != sslModeVerifyFull {
+		return config, nil, nil
+	}
+
+	cert, err := api.GetInstanceCertificate(&rdb.GetInstanceCertificateRequest{
+		Region:     region,
+		InstanceID: instance.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certContent, err := io.ReadAll(cert.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(certContent) {
+		return nil, nil, fmt.Errorf("failed to parse the certificate of instance %s", instance.ID)
+	}
+
+	return config, roots, nil
+}
+
+// pgsqlInstanceEndpoint returns the load balancer endpoint of an instance, or its first endpoint
+func pgsqlInstanceEndpoint(endpoints []*rdb.Endpoint) *rdb.Endpoint {
+	for _, endpoint := range endpoints {
+		if endpoint.LoadBalancer != nil {
+			return endpoint
+		}
+	}
+
+	if len(endpoints) > 0 {
+		return endpoints[0]
+	}
+
+	return nil
+}
+
+// pgsqlTLSConfig returns the TLS configuration of a sslmode, roots defaults to the system certificate authorities
+func pgsqlTLSConfig(sslMode string, host string, roots *x509.CertPool) *tls.Config {
+	switch sslMode {
+	case sslModeDisable:
+		return nil
+	case sslModeRequire:
+		return &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: true, //nolint:gosec // sslmode=require does not verify the server, as with libpq
+		}
+	case sslModeVerifyFull:
+		return &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: host,
+			RootCAs:    roots,
+		}
+	}
+
+	// verify-ca checks the certificate chain but not the host name, endpoints are often IPs missing from the certificate
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, //nolint:gosec // the chain is verified by VerifyConnection
+		VerifyConnection: func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("the server did not present a certificate")
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, cert := range state.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+
+			_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: intermediates,
+			})
+
+			return err
+		},
+	}
+}
+
+// pgsqlReadConnect opens a connection in read, it returns a nil connection when the password is write-only
+// and the object cannot be refreshed.
+func pgsqlReadConnect(ctx context.Context, d *schema.ResourceData, m any) (*pgsql.Conn, diag.Diagnostics) {
+	conn, err := pgsqlConnect(ctx, d, m)
+	if errors.Is(err, errPgsqlNoPassword) {
+		return nil, nil
+	}
+
+	if err != nil {
+		if isPgsqlTargetNotFound(err) {
+			d.SetId("")
+
+			return nil, nil
+		}
+
+		return nil, diag.FromErr(err)
+	}
+
+	return conn, nil
+}
+
+// pgsqlDeleteConnect opens a connection in delete, it returns a nil connection when the object must be left in place.
+// A write-only password is not available on destroy, the object can only be left in place with keep_on_destroy.
+func pgsqlDeleteConnect(ctx context.Context, d *schema.ResourceData, m any, object string) (*pgsql.Conn, diag.Diagnostics) {
+	if d.Get("keep_on_destroy").(bool) {
+		return nil, nil
+	}
+
+	conn, err := pgsqlConnect(ctx, d, m)
+	if errors.Is(err, errPgsqlNoPassword) {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "cannot remove " + object,
+			Detail:   "The password is write-only and is not available on destroy. Set keep_on_destroy to only remove " + object + " from the state, or use password.",
+		}}
+	}
+
+	if err != nil {
+		if isPgsqlTargetNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, diag.FromErr(err)
+	}
+
+	return conn, nil
+}
+
+// pgsqlWriteOnly returns true when the object is managed with password_wo
+func pgsqlWriteOnly(d pgsqlResourceData) bool {
+	return d.Get("password").(string) == ""
+}
+
+// pgsqlFingerprint returns the digest of the values read from the database
+func pgsqlFingerprint(values ...any) string {
+	sum := sha256.Sum256(fmt.Append(nil, values...))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// customizeDiffPgsqlWriteOnlyRefresh compares an object managed with password_wo with the database. Read cannot connect
+// without the password, the plan can as write-only values are part of the configuration: the object is updated when
+// it changed outside of Terraform.
+func customizeDiffPgsqlWriteOnlyRefresh(fingerprint pgsqlFingerprintFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+		if diff.Id() == "" || !pgsqlWriteOnly(diff) || pgsqlPassword(diff) == "" {
+			return nil
+		}
+
+		conn, err := pgsqlConnect(ctx, diff, m)
+		if err != nil {
+			if isPgsqlTargetNotFound(err) {
+				return diff.SetNewComputed("fingerprint")
+			}
+
+			return fmt.Errorf("refreshing the object with password_wo: %w", err)
+		}
+		defer conn.Close()
+
+		current, err := fingerprint(ctx, conn, diff)
+		if err != nil {
+			return fmt.Errorf("refreshing the object with password_wo: %w", err)
+		}
+
+		if current != diff.Get("fingerprint").(string) {
+			return diff.SetNewComputed("fingerprint")
+		}
+
+		return nil
+	}
+}
+
+// pgsqlImportState returns an importer of IDs such as {region}/{target}/{database_name}/{field}..., the target is an
+// RDB instance or a Serverless SQL database when it is a UUID, a host otherwise
+func pgsqlImportState(fields ...string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+		parts := strings.Split(d.Id(), "/")
+		if len(parts) != 3+len(fields) {
+			return nil, fmt.Errorf("invalid ID %q, expected {region}/{instance_id, serverless_database_id or host}/{database_name}/{%s}", d.Id(), strings.Join(fields, "}/{"))
+		}
+
+		region, err := scw.ParseRegion(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		targetKey, err := pgsqlImportTarget(ctx, m, region, parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		_ = d.Set("region", region.String())
+		_ = d.Set(targetKey, parts[1])
+		_ = d.Set("database_name", parts[2])
+
+		for i, field := range fields {
+			_ = d.Set(field, parts[3+i])
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// pgsqlImportTarget returns the argument identifying the server of an imported object
+func pgsqlImportTarget(ctx context.Context, m any, region scw.Region, target string) (string, error) {
+	if uuid.Validate(target) != nil {
+		return "host", nil
+	}
+
+	_, err := newAPI(m).GetInstance(&rdb.GetInstanceRequest{
+		Region:     region,
+		InstanceID: target,
+	}, scw.WithContext(ctx))
+	if err == nil {
+		return "instance_id", nil
+	}
+
+	if !httperrors.Is404(err) {
+		return "", err
+	}
+
+	return "serverless_database_id", nil
+}
+
+// isPgsqlTargetNotFound returns true when the instance, the serverless database or the database is gone
+func isPgsqlTargetNotFound(err error) bool {
+	return httperrors.Is404(err) || pgsql.IsErrorCode(err, pgsqlInvalidCatalogName)
+}
+
+// SQLSTATE codes of the statements on databases, roles or objects that do not exist
+const (
+	pgsqlInvalidCatalogName = "3D000"
+	pgsqlDuplicateSchema    = "42P06"
+	pgsqlInvalidSchemaName  = "3F000"
+	pgsqlUndefinedObject    = "42704"
+	pgsqlUndefinedTable     = "42P01"
+	pgsqlUndefinedFunction  = "42883"
+)
+
+// isPgsqlObjectNotFound returns true when a statement failed because an object it refers to is gone
+func isPgsqlObjectNotFound(err error) bool {
+	for _, code := range []string{pgsqlInvalidSchemaName, pgsqlUndefinedObject, pgsqlUndefinedTable, pgsqlUndefinedFunction} {
+		if pgsql.IsErrorCode(err, code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pgsqlObjectID joins the parts of the ID of a database object, the separator is not allowed in the parts
+func pgsqlObjectID(region scw.Region, parts ...string) string {
+	return regional.NewIDString(region, strings.Join(parts, "/"))
+}
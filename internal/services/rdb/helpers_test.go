@@ -4,7 +4,9 @@ import (
 	"reflect"
 	"testing"
 
+	rdbSDK "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestPrivilegeV1SchemaUpgradeFunc(t *testing.T) {
@@ -30,3 +32,124 @@ func TestPrivilegeV1SchemaUpgradeFunc(t *testing.T) {
 		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", v1Schema, actual)
 	}
 }
+
+func TestValidateEngineSettings(t *testing.T) {
+	available := []*rdbSDK.EngineSetting{
+		{
+			Name:            "max_connections",
+			PropertyType:    rdbSDK.EngineSettingPropertyTypeInt,
+			IntMin:          new(int32(10)),
+			IntMax:          new(int32(10000)),
+			HotConfigurable: false,
+		},
+		{
+			Name:            "work_mem",
+			PropertyType:    rdbSDK.EngineSettingPropertyTypeInt,
+			IntMin:          new(int32(1)),
+			IntMax:          new(int32(2048)),
+			Unit:            new("MB"),
+			HotConfigurable: true,
+		},
+		{
+			Name:            "random_page_cost",
+			PropertyType:    rdbSDK.EngineSettingPropertyTypeFloat,
+			FloatMin:        new(float32(0)),
+			FloatMax:        new(float32(10)),
+			HotConfigurable: true,
+		},
+		{
+			Name:             "timezone",
+			PropertyType:     rdbSDK.EngineSettingPropertyTypeString,
+			StringConstraint: new("^[A-Za-z_/]+$"),
+			HotConfigurable:  true,
+		},
+		{
+			Name:            "autocommit",
+			PropertyType:    rdbSDK.EngineSettingPropertyTypeBoolean,
+			HotConfigurable: true,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		settings        map[string]string
+		expectedErrors  []string
+		expectedRestart []string
+	}{
+		{
+			name: "valid settings",
+			settings: map[string]string{
+				"max_connections":  "200",
+				"work_mem":         "64",
+				"random_page_cost": "1.5",
+				"timezone":         "Europe/Paris",
+				"autocommit":       "on",
+			},
+			expectedRestart: []string{"max_connections"},
+		},
+		{
+			name:           "typo",
+			settings:       map[string]string{"max_connection": "200"},
+			expectedErrors: []string{`unknown setting "max_connection", did you mean "max_connections"?`},
+		},
+		{
+			name:           "unknown setting",
+			settings:       map[string]string{"shared_buffers": "128"},
+			expectedErrors: []string{`unknown setting "shared_buffers", see the scaleway_rdb_engine data source for the available settings`},
+		},
+		{
+			name: "out of range",
+			settings: map[string]string{
+				"max_connections":  "5",
+				"work_mem":         "4096",
+				"random_page_cost": "11",
+			},
+			expectedErrors: []string{
+				`setting "max_connections" must be at least 10, got 5`,
+				`setting "random_page_cost" must be at most 10, got 11`,
+				`setting "work_mem" must be at most 2048 MB, got 4096`,
+			},
+		},
+		{
+			name: "invalid types",
+			settings: map[string]string{
+				"autocommit":      "maybe",
+				"max_connections": "many",
+				"timezone":        "Europe Paris",
+			},
+			expectedErrors: []string{
+				`setting "autocommit" must be a boolean, got "maybe"`,
+				`setting "max_connections" must be an integer, got "many"`,
+				`setting "timezone" must match "^[A-Za-z_/]+$", got "Europe Paris"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs, restart := rdb.ValidateEngineSettings(tt.settings, available)
+
+			messages := []string(nil)
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+
+			assert.Equal(t, tt.expectedErrors, messages)
+			assert.Equal(t, tt.expectedRestart, restart)
+		})
+	}
+}
+
+func TestEngineUpgradePaths(t *testing.T) {
+	versions := []*rdbSDK.EngineVersion{
+		{Name: "PostgreSQL-17", Version: "17"},
+		{Name: "PostgreSQL-16", Version: "16"},
+		{Name: "PostgreSQL-15", Version: "15", Disabled: true},
+		{Name: "PostgreSQL-14", Version: "14"},
+	}
+
+	assert.Equal(t, []string{"PostgreSQL-17", "PostgreSQL-16"}, rdb.EngineUpgradePaths(versions, "14"))
+	assert.Equal(t, []string{"PostgreSQL-17"}, rdb.EngineUpgradePaths(versions, "16"))
+	assert.Nil(t, rdb.EngineUpgradePaths(versions, "17"))
+	assert.Nil(t, rdb.EngineUpgradePaths(versions, "9"))
+}
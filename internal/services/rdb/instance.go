@@ -6,16 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	ipamAPI "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
@@ -47,9 +50,13 @@ func ResourceInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		SchemaVersion:    0,
-		SchemaFunc:       instanceSchema,
-		CustomizeDiff:    cdf.LocalityCheck("private_network.#.pn_id"),
+		SchemaVersion: 0,
+		SchemaFunc:    instanceSchema,
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("private_network.#.pn_id"),
+			customizeDiffEngineSettings(true),
+			customizeDiffPendingRestartSettings,
+		),
 		Identity:         identity.DefaultRegional(),
 		ResourceBehavior: schema.ResourceBehavior{MutableIdentity: true},
 	}
@@ -151,16 +158,22 @@ func instanceSchema() map[string]*schema.Schema {
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
-			Description: "Map of engine settings to be set on a running instance.",
+			Description: "Map of engine settings to be set on a running instance. Settings are validated against the engine catalogue at plan time. Changing a setting whose restart_required is true in the scaleway_rdb_engine data source restarts the instance.",
 			Computed:    true,
 			Optional:    true,
 		},
+		"pending_restart_settings": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Computed:    true,
+			Description: "The settings of the last settings change which are not hot configurable, applying them restarts the instance. They are shown in the plan",
+		},
 		"init_settings": {
 			Type: schema.TypeMap,
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
-			Description: "Map of engine settings to be set at database initialisation.",
+			Description: "Map of engine settings to be set at database initialisation. Settings are validated against the engine catalogue at plan time.",
 			ForceNew:    true,
 			Optional:    true,
 		},
@@ -287,6 +300,7 @@ func instanceSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Certificate of the database instance",
 		},
+		"connection": connection.Schema(),
 		"load_balancer": {
 			Type:             schema.TypeList,
 			Optional:         true,
@@ -780,6 +794,7 @@ func setInstanceState(ctx context.Context, d *schema.ResourceData, m any, rdbAPI
 	}
 
 	_ = d.Set("certificate", string(certContent))
+	_ = d.Set("connection", flattenConnection(res, string(certContent)))
 
 	// set settings
 	_ = d.Set("settings", flattenInstanceSettings(res.Settings))
@@ -1188,12 +1203,27 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m an
 	////////////////////
 	// Change settings
 	////////////////////
+	var diags diag.Diagnostics
+
 	if d.HasChange("settings") {
 		_, err = waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil && !httperrors.Is404(err) {
 			return diag.FromErr(err)
 		}
 
+		oldSettings, newSettings := d.GetChange("settings")
+
+		restart := settingsRequiringRestart(ctx, rdbAPI, region, d.Get("engine").(string), oldSettings, newSettings)
+		if len(restart) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Database instance restarted",
+				Detail:   fmt.Sprintf("settings %s are not hot configurable, applying them restarted the database instance", strings.Join(restart, ", ")),
+			})
+		}
+
+		_ = d.Set("pending_restart_settings", restart)
+
 		_, err := rdbAPI.SetInstanceSettings(&rdb.SetInstanceSettingsRequest{
 			InstanceID: ID,
 			Region:     region,
@@ -1333,7 +1363,7 @@ func ResourceRdbInstanceUpdate(ctx context.Context, d *schema.ResourceData, m an
 		}
 	}
 
-	return ResourceRdbInstanceRead(ctx, d, m)
+	return append(diags, ResourceRdbInstanceRead(ctx, d, m)...)
 }
 
 func ResourceRdbInstanceDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
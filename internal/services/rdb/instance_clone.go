@@ -0,0 +1,715 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+// cloneSnapshotExpiration bounds the life of the intermediate snapshot of a clone, in case it could not be deleted
+const cloneSnapshotExpiration = 24 * time.Hour
+
+const (
+	// InstanceCloneSourceTagPrefix tags a clone with the ID of its source instance, which the API does not keep
+	InstanceCloneSourceTagPrefix = "rdb-clone-source="
+
+	cloneFromNewSnapshot    = "new_snapshot"
+	cloneFromLatestSnapshot = "latest_snapshot"
+	cloneFromDatabaseBackup = "database_backup"
+)
+
+func ResourceInstanceClone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRdbInstanceCloneCreate,
+		ReadContext:   ResourceRdbInstanceCloneRead,
+		UpdateContext: ResourceRdbInstanceCloneUpdate,
+		DeleteContext: ResourceRdbInstanceCloneDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Read:    schema.DefaultTimeout(defaultInstanceTimeout),
+			Update:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    instanceCloneSchema,
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("source_instance_id", "snapshot_id", "database_backup_id", "private_network.#.pn_id"),
+			customizeDiffInstanceCloneSource,
+			customizeDiffEngineSettings(false),
+		),
+		Identity: identity.DefaultRegional(),
+	}
+}
+
+func instanceCloneSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"source_instance_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+			Description:      "The ID of the database instance to clone",
+		},
+		"snapshot_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+			Description:      "The ID of a snapshot of the source instance to clone. Only used when clone_from is new_snapshot, a new snapshot of the source instance is taken when not set",
+		},
+		"clone_from": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  cloneFromNewSnapshot,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+				cloneFromNewSnapshot,
+				cloneFromLatestSnapshot,
+				cloneFromDatabaseBackup,
+			}, false)),
+			Description: "What the source instance is cloned from: new_snapshot takes a snapshot of the source instance unless snapshot_id is set, latest_snapshot uses the most recent ready snapshot of the source instance and database_backup restores database_backup_id in a new instance",
+		},
+		"database_backup_id": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+			Description:      "The ID of the database backup to restore when clone_from is database_backup",
+		},
+		"user_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Identifier of the initial user of the instance created when clone_from is database_backup",
+		},
+		"password": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			Sensitive:     true,
+			Description:   "Password of the initial user of the instance created when clone_from is database_backup. Only one of `password` or `password_wo` should be specified.",
+			ConflictsWith: []string{"password_wo"},
+		},
+		"password_wo": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "Password of the initial user of the instance created when clone_from is database_backup in [write-only](https://registry.terraform.io/providers/scaleway/scaleway/latest/docs/guides/using-write-only-arguments) mode. Only one of `password` or `password_wo` should be specified. `password_wo` will not be set in the Terraform state. To change the `password_wo`, you must also update the `password_wo_version`, which re-creates the clone.",
+			WriteOnly:     true,
+			ConflictsWith: []string{"password"},
+			RequiredWith:  []string{"password_wo_version"},
+		},
+		"password_wo_version": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			Description:  "The version of the [write-only](https://registry.terraform.io/providers/scaleway/scaleway/latest/docs/guides/using-write-only-arguments) password. Changing it re-creates the clone.",
+			RequiredWith: []string{"password_wo"},
+		},
+		"refresh_trigger": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Arbitrary value that re-clones the source instance when it changes",
+		},
+		"keep_snapshot": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Keep the snapshot taken of the source instance once the clone is ready",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Name of the cloned database instance",
+		},
+		"node_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			DiffSuppressFunc: dsf.IgnoreCase,
+			Description:      "The type of the cloned database instance, defaults to the node type of the source instance",
+		},
+		"is_ha_cluster": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Enable high availability for the cloned database instance, defaults to the setting of the source instance",
+		},
+		"settings": {
+			Type: schema.TypeMap,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Computed:    true,
+			Description: "Map of engine settings to be set on the cloned database instance. Changing a setting whose restart_required is true in the scaleway_rdb_engine data source restarts the instance.",
+		},
+		"tags": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional:    true,
+			Description: "List of tags [\"tag1\", \"tag2\", ...] attached to the cloned database instance",
+		},
+		"private_network": instanceSchema()["private_network"],
+		// Computed
+		"source_snapshot_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the snapshot the database instance was cloned from, intermediate snapshots are deleted unless keep_snapshot is set",
+		},
+		"engine": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Database's engine version name of the cloned database instance",
+		},
+		"load_balancer": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Load balancer of the cloned database instance",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"endpoint_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The endpoint ID",
+					},
+					"ip": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The IP of your load balancer service",
+					},
+					"port": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The port of your load balancer service",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of your load balancer service",
+					},
+					"hostname": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The hostname of your endpoint",
+					},
+				},
+			},
+		},
+		"certificate": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Certificate of the cloned database instance",
+		},
+		// Common
+		"region": regional.Schema(),
+		"project_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The project ID of the cloned database instance",
+		},
+		"organization_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The organization ID of the cloned database instance",
+		},
+	}
+}
+
+// InstanceCloneSourceTag returns the tag recording the source instance of a clone
+func InstanceCloneSourceTag(sourceInstanceID string) string {
+	return InstanceCloneSourceTagPrefix + sourceInstanceID
+}
+
+// SplitInstanceCloneTags separates the tags set by the user from the source instance recorded on a clone
+func SplitInstanceCloneTags(tags []string) ([]string, string) {
+	userTags := []string(nil)
+	sourceInstanceID := ""
+
+	for _, tag := range tags {
+		if id, ok := strings.CutPrefix(tag, InstanceCloneSourceTagPrefix); ok {
+			sourceInstanceID = id
+
+			continue
+		}
+
+		userTags = append(userTags, tag)
+	}
+
+	return userTags, sourceInstanceID
+}
+
+func customizeDiffInstanceCloneSource(_ context.Context, diff *schema.ResourceDiff, _ any) error {
+	cloneFrom := diff.Get("clone_from").(string)
+
+	if cloneFrom != cloneFromNewSnapshot && diff.Get("snapshot_id").(string) != "" {
+		return fmt.Errorf("snapshot_id can only be set when clone_from is %s", cloneFromNewSnapshot)
+	}
+
+	if cloneFrom != cloneFromDatabaseBackup {
+		if diff.Get("database_backup_id").(string) != "" {
+			return fmt.Errorf("database_backup_id can only be set when clone_from is %s", cloneFromDatabaseBackup)
+		}
+
+		return nil
+	}
+
+	// Values from other resources are not known yet
+	for _, key := range []string{"database_backup_id", "user_name"} {
+		if diff.NewValueKnown(key) && diff.Get(key).(string) == "" {
+			return fmt.Errorf("%s is required when clone_from is %s", key, cloneFromDatabaseBackup)
+		}
+	}
+
+	// password_wo is not part of the diff, password_wo_version stands for it
+	if diff.NewValueKnown("password") && diff.Get("password").(string) == "" && diff.Get("password_wo_version").(int) == 0 {
+		return fmt.Errorf("password or password_wo is required when clone_from is %s", cloneFromDatabaseBackup)
+	}
+
+	return nil
+}
+
+// latestCloneSnapshot returns the most recent ready snapshot of the source instance
+func latestCloneSnapshot(ctx context.Context, rdbAPI *rdb.API, region scw.Region, sourceInstanceID string) (*rdb.Snapshot, error) {
+	res, err := rdbAPI.ListSnapshots(&rdb.ListSnapshotsRequest{
+		Region:     region,
+		InstanceID: &sourceInstanceID,
+		OrderBy:    rdb.ListSnapshotsRequestOrderByCreatedAtDesc,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range res.Snapshots {
+		if snapshot.Status == rdb.SnapshotStatusReady {
+			return snapshot, nil
+		}
+	}
+
+	return nil, fmt.Errorf("instance %s has no ready snapshot to clone", sourceInstanceID)
+}
+
+// createCloneFromDatabaseBackup creates an instance with the engine, node type and volume of the source instance and
+// restores a database backup in it
+func createCloneFromDatabaseBackup(ctx context.Context, d *schema.ResourceData, rdbAPI *rdb.API, region scw.Region, source *rdb.Instance, nodeType string, isHaCluster bool) (*rdb.Instance, error) {
+	backupID := locality.ExpandID(d.Get("database_backup_id"))
+
+	backup, err := waitForRDBDatabaseBackup(ctx, rdbAPI, region, backupID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return nil, err
+	}
+
+	if backup.InstanceID != source.ID {
+		return nil, fmt.Errorf("database backup %s belongs to instance %s, not to the source instance %s", backupID, backup.InstanceID, source.ID)
+	}
+
+	var password string
+	if _, ok := d.GetOk("password_wo_version"); ok {
+		password = d.GetRawConfig().GetAttr("password_wo").AsString()
+	} else {
+		password = d.Get("password").(string)
+	}
+
+	createReq := &rdb.CreateInstanceRequest{
+		Region:        region,
+		ProjectID:     &source.ProjectID,
+		Name:          types.ExpandOrGenerateString(d.Get("name"), source.Name+"-clone"),
+		Engine:        source.Engine,
+		UserName:      d.Get("user_name").(string),
+		Password:      password,
+		NodeType:      nodeType,
+		IsHaCluster:   isHaCluster,
+		DisableBackup: true,
+	}
+
+	if source.Volume != nil {
+		createReq.VolumeType = source.Volume.Type
+		createReq.VolumeSize = source.Volume.Size
+	}
+
+	res, err := rdbAPI.CreateInstance(createReq, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = waitForRDBInstance(ctx, rdbAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return res, err
+	}
+
+	_, err = rdbAPI.RestoreDatabaseBackup(&rdb.RestoreDatabaseBackupRequest{
+		Region:           region,
+		DatabaseBackupID: backup.ID,
+		InstanceID:       res.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return res, fmt.Errorf("restoring database backup %s: %w", backup.ID, err)
+	}
+
+	return res, nil
+}
+
+// createCloneSnapshot takes a snapshot of the source instance and waits for it to be ready
+func createCloneSnapshot(ctx context.Context, rdbAPI *rdb.API, region scw.Region, sourceInstanceID string, timeout time.Duration) (*rdb.Snapshot, error) {
+	_, err := waitForRDBInstance(ctx, rdbAPI, region, sourceInstanceID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := rdbAPI.CreateSnapshot(&rdb.CreateSnapshotRequest{
+		Region:     region,
+		InstanceID: sourceInstanceID,
+		Name:       types.NewRandomName("rdb-clone"),
+		ExpiresAt:  new(time.Now().Add(cloneSnapshotExpiration)),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotID := snapshot.ID
+
+	snapshot, err = waitForRDBSnapshot(ctx, rdbAPI, region, snapshotID, timeout)
+	if err != nil {
+		deleteCloneSnapshot(ctx, rdbAPI, region, snapshotID, timeout)
+
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// deleteCloneSnapshot removes an intermediate snapshot. Failures are only logged, the snapshot expires anyway.
+func deleteCloneSnapshot(ctx context.Context, rdbAPI *rdb.API, region scw.Region, snapshotID string, timeout time.Duration) {
+	_, err := waitForRDBSnapshot(ctx, rdbAPI, region, snapshotID, timeout)
+	if err == nil {
+		_, err = rdbAPI.DeleteSnapshot(&rdb.DeleteSnapshotRequest{
+			Region:     region,
+			SnapshotID: snapshotID,
+		}, scw.WithContext(ctx))
+	}
+
+	if err != nil && !httperrors.Is404(err) {
+		tflog.Warn(ctx, fmt.Sprintf("could not delete intermediate snapshot %s, it will expire on its own: %s", snapshotID, err))
+	}
+}
+
+func ResourceRdbInstanceCloneCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	rdbAPI, region, err := newAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The clone is created in the region of the source instance
+	sourceInstanceID := locality.ExpandID(d.Get("source_instance_id"))
+	if sourceRegion, id, err := regional.ParseID(d.Get("source_instance_id").(string)); err == nil {
+		region, sourceInstanceID = sourceRegion, id
+	}
+
+	source, err := rdbAPI.GetInstance(&rdb.GetInstanceRequest{
+		Region:     region,
+		InstanceID: sourceInstanceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeType := source.NodeType
+	if rawNodeType, ok := d.GetOk("node_type"); ok {
+		nodeType = rawNodeType.(string)
+	}
+
+	isHaCluster := source.IsHaCluster
+	if rawIsHaCluster, ok := meta.GetRawConfigForKey(d, "is_ha_cluster", cty.Bool); ok {
+		isHaCluster = rawIsHaCluster.(bool)
+	}
+
+	var res *rdb.Instance
+
+	switch d.Get("clone_from").(string) {
+	case cloneFromDatabaseBackup:
+		res, err = createCloneFromDatabaseBackup(ctx, d, rdbAPI, region, source, nodeType, isHaCluster)
+		if res != nil {
+			if err := identity.SetRegionalIdentity(d, region, res.ID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	default:
+		snapshotID := locality.ExpandID(d.Get("snapshot_id"))
+
+		switch {
+		case d.Get("clone_from").(string) == cloneFromLatestSnapshot:
+			snapshot, err := latestCloneSnapshot(ctx, rdbAPI, region, source.ID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			snapshotID = snapshot.ID
+		case snapshotID == "":
+			snapshot, err := createCloneSnapshot(ctx, rdbAPI, region, source.ID, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("taking a snapshot of instance %s: %w", source.ID, err))
+			}
+
+			snapshotID = snapshot.ID
+
+			if !d.Get("keep_snapshot").(bool) {
+				defer deleteCloneSnapshot(ctx, rdbAPI, region, snapshotID, d.Timeout(schema.TimeoutCreate))
+			}
+		}
+
+		res, err = rdbAPI.CreateInstanceFromSnapshot(&rdb.CreateInstanceFromSnapshotRequest{
+			SnapshotID:   snapshotID,
+			Region:       region,
+			InstanceName: types.ExpandOrGenerateString(d.Get("name"), source.Name+"-clone"),
+			IsHaCluster:  &isHaCluster,
+			NodeType:     &nodeType,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := identity.SetRegionalIdentity(d, region, res.ID); err != nil {
+			return diag.FromErr(err)
+		}
+
+		_ = d.Set("source_snapshot_id", regional.NewIDString(region, snapshotID))
+	}
+
+	_, err = waitForRDBInstance(ctx, rdbAPI, region, res.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = rdbAPI.UpdateInstance(&rdb.UpdateInstanceRequest{
+		Region:     region,
+		InstanceID: res.ID,
+		Tags:       new(append(types.ExpandStrings(d.Get("tags")), InstanceCloneSourceTag(regional.NewIDString(region, source.ID)))),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, wantPrivateNetwork := d.GetOk("private_network"); wantPrivateNetwork {
+		if diags := createPrivateNetworkEndpoints(ctx, rdbAPI, region, res.ID, d); diags.HasError() {
+			return diags
+		}
+
+		if _, err := waitForRDBInstance(ctx, rdbAPI, region, res.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if settings, ok := d.GetOk("settings"); ok {
+		_, err = rdbAPI.SetInstanceSettings(&rdb.SetInstanceSettingsRequest{
+			InstanceID: res.ID,
+			Region:     region,
+			Settings:   expandInstanceSettings(settings),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if _, err := waitForRDBInstance(ctx, rdbAPI, region, res.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceRdbInstanceCloneRead(ctx, d, m)
+}
+
+func ResourceRdbInstanceCloneRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	rdbAPI, region, ID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("name", res.Name)
+	_ = d.Set("node_type", res.NodeType)
+	_ = d.Set("is_ha_cluster", res.IsHaCluster)
+	_ = d.Set("engine", res.Engine)
+	userTags, sourceInstanceID := SplitInstanceCloneTags(res.Tags)
+	_ = d.Set("tags", types.FlattenSliceString(userTags))
+
+	if sourceInstanceID != "" {
+		_ = d.Set("source_instance_id", sourceInstanceID)
+	}
+
+	_ = d.Set("settings", flattenInstanceSettings(res.Settings))
+	_ = d.Set("region", string(region))
+	_ = d.Set("project_id", res.ProjectID)
+	_ = d.Set("organization_id", res.OrganizationID)
+
+	pnI, _ := flattenPrivateNetwork(res.Endpoints)
+	_ = d.Set("private_network", pnI)
+
+	lbI, _ := flattenLoadBalancer(res.Endpoints)
+	_ = d.Set("load_balancer", lbI)
+
+	cert, err := rdbAPI.GetInstanceCertificate(&rdb.GetInstanceCertificateRequest{
+		Region:     region,
+		InstanceID: res.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	certContent, err := io.ReadAll(cert.Content)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("certificate", string(certContent))
+
+	if err := identity.SetRegionalIdentity(d, region, res.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func ResourceRdbInstanceCloneUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	rdbAPI, region, ID, err := NewAPIWithRegionAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "tags") {
+		_, err = waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = rdbAPI.UpdateInstance(&rdb.UpdateInstanceRequest{
+			Region:     region,
+			InstanceID: ID,
+			Name:       types.ExpandStringPtr(d.Get("name")),
+			Tags:       new(append(types.ExpandStrings(d.Get("tags")), InstanceCloneSourceTag(regional.NewIDString(region, locality.ExpandID(d.Get("source_instance_id")))))),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("node_type") {
+		_, err = waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = rdbAPI.UpgradeInstance(&rdb.UpgradeInstanceRequest{
+			Region:     region,
+			InstanceID: ID,
+			NodeType:   types.ExpandStringPtr(d.Get("node_type")),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var diags diag.Diagnostics
+
+	if d.HasChange("settings") {
+		_, err = waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		oldSettings, newSettings := d.GetChange("settings")
+		if restart := settingsRequiringRestart(ctx, rdbAPI, region, d.Get("engine").(string), oldSettings, newSettings); len(restart) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Database instance restarted",
+				Detail:   fmt.Sprintf("settings %s are not hot configurable, applying them restarted the database instance", strings.Join(restart, ", ")),
+			})
+		}
+
+		_, err = rdbAPI.SetInstanceSettings(&rdb.SetInstanceSettingsRequest{
+			InstanceID: ID,
+			Region:     region,
+			Settings:   expandInstanceSettings(newSettings),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("private_network") {
+		res, err := waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, e := range res.Endpoints {
+			if e.PrivateNetwork != nil {
+				err := rdbAPI.DeleteEndpoint(&rdb.DeleteEndpointRequest{
+					EndpointID: e.ID,
+					Region:     region,
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+		}
+
+		_, err = waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if diags := createPrivateNetworkEndpoints(ctx, rdbAPI, region, ID, d); diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, ResourceRdbInstanceCloneRead(ctx, d, m)...)
+}
+
+func ResourceRdbInstanceCloneDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	return ResourceRdbInstanceDelete(ctx, d, m)
+}
@@ -0,0 +1,135 @@
+package rdb_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb"
+	rdbchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb/testfuncs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitInstanceCloneTags(t *testing.T) {
+	tags := []string{"prod", rdb.InstanceCloneSourceTag("fr-par/11111111-1111-1111-1111-111111111111"), "clone"}
+
+	userTags, sourceInstanceID := rdb.SplitInstanceCloneTags(tags)
+	assert.Equal(t, []string{"prod", "clone"}, userTags)
+	assert.Equal(t, "fr-par/11111111-1111-1111-1111-111111111111", sourceInstanceID)
+
+	userTags, sourceInstanceID = rdb.SplitInstanceCloneTags([]string{"prod"})
+	assert.Equal(t, []string{"prod"}, userTags)
+	assert.Empty(t, sourceInstanceID)
+}
+
+func instanceCloneSourceConfig(engine string) string {
+	return fmt.Sprintf(`
+		resource scaleway_rdb_instance source {
+			name = "test-rdb-clone-source"
+			node_type = "db-dev-s"
+			engine = %q
+			is_ha_cluster = false
+			disable_backup = true
+			user_name = "my_initial_user"
+			password = "thiZ_is_v&ry_s3cret"
+		}
+	`, engine)
+}
+
+func TestAccInstanceClone_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: instanceCloneSourceConfig(latestEngineVersion) + `
+					resource scaleway_rdb_instance_clone main {
+						source_instance_id = scaleway_rdb_instance.source.id
+						name = "test-rdb-clone"
+						tags = [ "terraform-test", "clone" ]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isInstancePresent(tt, "scaleway_rdb_instance_clone.main"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_instance_clone.main", "source_instance_id", "scaleway_rdb_instance.source", "id"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_instance_clone.main", "engine", "scaleway_rdb_instance.source", "engine"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance_clone.main", "name", "test-rdb-clone"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance_clone.main", "node_type", "db-dev-s"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance_clone.main", "clone_from", "new_snapshot"),
+					resource.TestCheckResourceAttr("scaleway_rdb_instance_clone.main", "tags.#", "2"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_instance_clone.main", "source_snapshot_id"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_instance_clone.main", "certificate"),
+				),
+			},
+			{
+				ResourceName:            "scaleway_rdb_instance_clone.main",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"clone_from", "keep_snapshot", "source_snapshot_id"},
+			},
+		},
+	})
+}
+
+func TestAccInstanceClone_LatestSnapshot(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: instanceCloneSourceConfig(latestEngineVersion) + `
+					resource "scaleway_rdb_snapshot" "latest" {
+						name        = "test-rdb-clone-latest"
+						instance_id = scaleway_rdb_instance.source.id
+					}
+
+					resource scaleway_rdb_instance_clone main {
+						source_instance_id = scaleway_rdb_instance.source.id
+						clone_from = "latest_snapshot"
+						name = "test-rdb-clone-latest"
+						depends_on = [scaleway_rdb_snapshot.latest]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					isInstancePresent(tt, "scaleway_rdb_instance_clone.main"),
+					resource.TestCheckResourceAttrPair("scaleway_rdb_instance_clone.main", "source_snapshot_id", "scaleway_rdb_snapshot.latest", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceClone_InvalidSource(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource scaleway_rdb_instance_clone main {
+						source_instance_id = "fr-par/11111111-1111-1111-1111-111111111111"
+						clone_from = "database_backup"
+						user_name = "my_initial_user"
+						password = "thiZ_is_v&ry_s3cret"
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("database_backup_id is required when clone_from is database_backup"),
+			},
+		},
+	})
+}
@@ -0,0 +1,189 @@
+package rdb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/pgsql"
+)
+
+func ResourcePostgreSQLExtension() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRdbPostgreSQLExtensionCreate,
+		ReadContext:   ResourceRdbPostgreSQLExtensionRead,
+		UpdateContext: ResourceRdbPostgreSQLExtensionUpdate,
+		DeleteContext: ResourceRdbPostgreSQLExtensionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Read:    schema.DefaultTimeout(defaultInstanceTimeout),
+			Update:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: pgsqlImportState("name"),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    postgreSQLExtensionSchema,
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("instance_id", "serverless_database_id"),
+			customizeDiffPgsqlWriteOnlyRefresh(postgreSQLExtensionFingerprint),
+		),
+	}
+}
+
+func postgreSQLExtensionSchema() map[string]*schema.Schema {
+	s := pgsqlConnectionSchema()
+
+	s["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The name of the extension, for instance postgis",
+	}
+	s["schema"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The schema in which the objects of the extension are created",
+	}
+	s["version"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The version of the extension, defaults to the default version of the server",
+	}
+	s["cascade"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Install the extensions this extension depends on, and remove the objects depending on it on destroy",
+	}
+
+	return s
+}
+
+func ResourceRdbPostgreSQLExtensionCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	region, err := meta.ExtractRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	conn, err := pgsqlConnect(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer conn.Close()
+
+	extension := &pgsql.Extension{
+		Name:    d.Get("name").(string),
+		Schema:  d.Get("schema").(string),
+		Version: d.Get("version").(string),
+	}
+
+	err = conn.Exec(ctx, pgsql.CreateExtensionSQL(extension, d.Get("cascade").(bool)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(pgsqlObjectID(region, pgsqlTargetID(d), d.Get("database_name").(string), extension.Name))
+
+	return readPostgreSQLExtension(ctx, d, conn)
+}
+
+func ResourceRdbPostgreSQLExtensionRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, diags := pgsqlReadConnect(ctx, d, m)
+	if conn == nil {
+		return diags
+	}
+	defer conn.Close()
+
+	return readPostgreSQLExtension(ctx, d, conn)
+}
+
+func readPostgreSQLExtension(ctx context.Context, d *schema.ResourceData, conn *pgsql.Conn) diag.Diagnostics {
+	extension, err := pgsql.ReadExtension(ctx, conn, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if extension == nil {
+		d.SetId("")
+
+		return nil
+	}
+
+	_ = d.Set("schema", extension.Schema)
+	_ = d.Set("version", extension.Version)
+	_ = d.Set("fingerprint", pgsqlFingerprint(extension.Schema, extension.Version))
+
+	return nil
+}
+
+func postgreSQLExtensionFingerprint(ctx context.Context, conn *pgsql.Conn, d pgsqlResourceData) (string, error) {
+	extension, err := pgsql.ReadExtension(ctx, conn, d.Get("name").(string))
+	if err != nil || extension == nil {
+		return "", err
+	}
+
+	return pgsqlFingerprint(extension.Schema, extension.Version), nil
+}
+
+func ResourceRdbPostgreSQLExtensionUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, err := pgsqlConnect(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer conn.Close()
+
+	extension := &pgsql.Extension{Name: d.Get("name").(string)}
+
+	// With password_wo, the update may come from a change made outside of Terraform that the plan detected
+	reapply := d.HasChange("fingerprint") || pgsqlWriteOnly(d)
+	if reapply {
+		err = conn.Exec(ctx, pgsql.CreateExtensionSQL(&pgsql.Extension{
+			Name:    extension.Name,
+			Schema:  d.Get("schema").(string),
+			Version: d.Get("version").(string),
+		}, d.Get("cascade").(bool)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("schema") || reapply {
+		extension.Schema = d.Get("schema").(string)
+	}
+
+	if d.HasChange("version") || reapply {
+		extension.Version = d.Get("version").(string)
+	}
+
+	if extension.Schema != "" || extension.Version != "" {
+		err = conn.Exec(ctx, pgsql.UpdateExtensionSQL(extension))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return readPostgreSQLExtension(ctx, d, conn)
+}
+
+func ResourceRdbPostgreSQLExtensionDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, diags := pgsqlDeleteConnect(ctx, d, m, "extension "+d.Get("name").(string))
+	if conn == nil {
+		return diags
+	}
+	defer conn.Close()
+
+	err := conn.Exec(ctx, pgsql.DropExtensionSQL(d.Get("name").(string), d.Get("cascade").(bool)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
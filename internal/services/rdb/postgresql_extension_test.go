@@ -0,0 +1,61 @@
+package rdb_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	rdbchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb/testfuncs"
+)
+
+func TestAccPostgreSQLExtension_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+	config := pgsqlObjectsConfig("test-rdb-postgresql-extension-basic", latestEngineVersion)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+					resource "scaleway_rdb_postgresql_extension" "pgcrypto" {
+					  instance_id   = scaleway_rdb_instance.main.id
+					  database_name = scaleway_rdb_database.main.name
+					  user_name     = scaleway_rdb_instance.main.user_name
+					  password      = scaleway_rdb_instance.main.password
+
+					  name = "pgcrypto"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_postgresql_extension.pgcrypto", "name", "pgcrypto"),
+					resource.TestCheckResourceAttr("scaleway_rdb_postgresql_extension.pgcrypto", "schema", "public"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_postgresql_extension.pgcrypto", "version"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_postgresql_extension.pgcrypto", "fingerprint"),
+				),
+			},
+			{
+				ResourceName:            "scaleway_rdb_postgresql_extension.pgcrypto",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: append(pgsqlObjectsImportStateVerifyIgnore, "schema", "version", "cascade"),
+			},
+			{
+				Config: config + `
+					resource "scaleway_rdb_postgresql_extension" "pgcrypto" {
+					  instance_id   = scaleway_rdb_instance.main.id
+					  database_name = scaleway_rdb_database.main.name
+					  user_name     = scaleway_rdb_instance.main.user_name
+					  password      = scaleway_rdb_instance.main.password
+
+					  name = "pgcrypto"
+					}
+				`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
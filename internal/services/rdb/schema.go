@@ -0,0 +1,163 @@
+package rdb
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/pgsql"
+)
+
+func ResourceSchema() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceRdbSchemaCreate,
+		ReadContext:   ResourceRdbSchemaRead,
+		UpdateContext: ResourceRdbSchemaUpdate,
+		DeleteContext: ResourceRdbSchemaDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Read:    schema.DefaultTimeout(defaultInstanceTimeout),
+			Update:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: pgsqlImportState("name"),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    schemaSchema,
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("instance_id", "serverless_database_id"),
+			customizeDiffPgsqlWriteOnlyRefresh(schemaFingerprint),
+		),
+	}
+}
+
+func schemaSchema() map[string]*schema.Schema {
+	s := pgsqlConnectionSchema()
+
+	s["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The name of the schema",
+	}
+	s["owner"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The role owning the schema, defaults to the user of the connection",
+	}
+	s["drop_cascade"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Remove the objects of the schema on destroy, otherwise the schema must be empty to be removed",
+	}
+
+	return s
+}
+
+func ResourceRdbSchemaCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	region, err := meta.ExtractRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	conn, err := pgsqlConnect(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer conn.Close()
+
+	name := d.Get("name").(string)
+
+	err = conn.Exec(ctx, pgsql.CreateSchemaSQL(name, d.Get("owner").(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(pgsqlObjectID(region, pgsqlTargetID(d), d.Get("database_name").(string), name))
+
+	return readSchema(ctx, d, conn)
+}
+
+func ResourceRdbSchemaRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, diags := pgsqlReadConnect(ctx, d, m)
+	if conn == nil {
+		return diags
+	}
+	defer conn.Close()
+
+	return readSchema(ctx, d, conn)
+}
+
+func readSchema(ctx context.Context, d *schema.ResourceData, conn *pgsql.Conn) diag.Diagnostics {
+	owner, err := pgsql.ReadSchemaOwner(ctx, conn, d.Get("name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if owner == nil {
+		d.SetId("")
+
+		return nil
+	}
+
+	_ = d.Set("owner", *owner)
+	_ = d.Set("fingerprint", pgsqlFingerprint(*owner))
+
+	return nil
+}
+
+func schemaFingerprint(ctx context.Context, conn *pgsql.Conn, d pgsqlResourceData) (string, error) {
+	owner, err := pgsql.ReadSchemaOwner(ctx, conn, d.Get("name").(string))
+	if err != nil || owner == nil {
+		return "", err
+	}
+
+	return pgsqlFingerprint(*owner), nil
+}
+
+func ResourceRdbSchemaUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, err := pgsqlConnect(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer conn.Close()
+
+	// With password_wo, the update may come from a change made outside of Terraform that the plan detected
+	if d.HasChange("fingerprint") || pgsqlWriteOnly(d) {
+		err = conn.Exec(ctx, pgsql.CreateSchemaSQL(d.Get("name").(string), d.Get("owner").(string)))
+		if err != nil && !pgsql.IsErrorCode(err, pgsqlDuplicateSchema) {
+			return diag.FromErr(err)
+		}
+	}
+
+	if (d.HasChange("owner") || pgsqlWriteOnly(d)) && d.Get("owner").(string) != "" {
+		err = conn.Exec(ctx, pgsql.AlterSchemaOwnerSQL(d.Get("name").(string), d.Get("owner").(string)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return readSchema(ctx, d, conn)
+}
+
+func ResourceRdbSchemaDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	conn, diags := pgsqlDeleteConnect(ctx, d, m, "schema "+d.Get("name").(string))
+	if conn == nil {
+		return diags
+	}
+	defer conn.Close()
+
+	err := conn.Exec(ctx, pgsql.DropSchemaSQL(d.Get("name").(string), d.Get("drop_cascade").(bool)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
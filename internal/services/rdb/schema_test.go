@@ -0,0 +1,134 @@
+package rdb_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	rdbchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb/testfuncs"
+)
+
+// pgsqlObjectsConfig returns an instance with a database and a user, the objects connect with the initial user of the instance
+func pgsqlObjectsConfig(name string, engine string) string {
+	return fmt.Sprintf(`
+		resource "scaleway_rdb_instance" "main" {
+		  name          = %[1]q
+		  node_type     = "db-dev-s"
+		  engine        = %[2]q
+		  is_ha_cluster = false
+		  user_name     = "my_initial_user"
+		  password      = "thiZ_is_v&ry_s3cret"
+		  tags          = ["terraform-test", "scaleway_rdb_pgsql_objects"]
+		}
+
+		resource "scaleway_rdb_database" "main" {
+		  instance_id = scaleway_rdb_instance.main.id
+		  name        = "app"
+		}
+
+		resource "scaleway_rdb_user" "app" {
+		  instance_id = scaleway_rdb_instance.main.id
+		  name        = "app"
+		  password    = "R34lP4sSw#Rd"
+		  is_admin    = false
+		}
+	`, name, engine)
+}
+
+// pgsqlObjectsImportStateVerifyIgnore lists the arguments an import cannot read, as the credentials are not available
+var pgsqlObjectsImportStateVerifyIgnore = []string{"user_name", "password", "sslmode", "keep_on_destroy", "fingerprint"}
+
+func TestAccSchema_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+	config := pgsqlObjectsConfig("test-rdb-schema-basic", latestEngineVersion)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config + `
+					resource "scaleway_rdb_schema" "app" {
+					  instance_id   = scaleway_rdb_instance.main.id
+					  database_name = scaleway_rdb_database.main.name
+					  user_name     = scaleway_rdb_instance.main.user_name
+					  password      = scaleway_rdb_instance.main.password
+
+					  name = "app"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_schema.app", "name", "app"),
+					resource.TestCheckResourceAttr("scaleway_rdb_schema.app", "owner", "my_initial_user"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_schema.app", "fingerprint"),
+				),
+			},
+			{
+				Config: config + `
+					resource "scaleway_rdb_schema" "app" {
+					  instance_id   = scaleway_rdb_instance.main.id
+					  database_name = scaleway_rdb_database.main.name
+					  user_name     = scaleway_rdb_instance.main.user_name
+					  password      = scaleway_rdb_instance.main.password
+
+					  name  = "app"
+					  owner = scaleway_rdb_user.app.name
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_schema.app", "owner", "app"),
+				),
+			},
+			{
+				ResourceName:            "scaleway_rdb_schema.app",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: append(pgsqlObjectsImportStateVerifyIgnore, "owner"),
+			},
+		},
+	})
+}
+
+func TestAccSchema_WriteOnlyPassword(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestEngineVersion := rdbchecks.GetLatestEngineVersion(tt, postgreSQLEngineName)
+	config := pgsqlObjectsConfig("test-rdb-schema-write-only", latestEngineVersion) + `
+		resource "scaleway_rdb_schema" "app" {
+		  instance_id         = scaleway_rdb_instance.main.id
+		  database_name       = scaleway_rdb_database.main.name
+		  user_name           = scaleway_rdb_instance.main.user_name
+		  password_wo         = "thiZ_is_v&ry_s3cret"
+		  password_wo_version = 1
+		  keep_on_destroy     = true
+
+		  name  = "app"
+		  owner = scaleway_rdb_user.app.name
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             rdbchecks.IsInstanceDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_rdb_schema.app", "owner", "app"),
+					resource.TestCheckNoResourceAttr("scaleway_rdb_schema.app", "password_wo"),
+					resource.TestCheckResourceAttrSet("scaleway_rdb_schema.app", "fingerprint"),
+				),
+			},
+			{
+				// The plan compares the schema with the database and finds no change
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
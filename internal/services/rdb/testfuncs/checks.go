@@ -39,7 +39,7 @@ func IsInstanceDestroyed(tt *acctest.TestTools) resource.TestCheckFunc {
 
 		return retry.RetryContext(ctx, DestroyWaitTimeout, func() *retry.RetryError {
 			for _, rs := range state.RootModule().Resources {
-				if rs.Type != "scaleway_rdb_instance" {
+				if rs.Type != "scaleway_rdb_instance" && rs.Type != "scaleway_rdb_instance_clone" {
 					continue
 				}
 
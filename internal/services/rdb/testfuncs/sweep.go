@@ -2,18 +2,78 @@ package rdbtestfuncs
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	rdbSDK "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/logging"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/rdb"
 )
 
 func AddTestSweepers() {
+	resource.AddTestSweepers("scaleway_rdb_instance_clone", &resource.Sweeper{
+		Name: "scaleway_rdb_instance_clone",
+		F:    testSweepInstanceClone,
+	})
 	resource.AddTestSweepers("scaleway_rdb_instance", &resource.Sweeper{
-		Name: "scaleway_rdb_instance",
-		F:    testSweepInstance,
+		Name:         "scaleway_rdb_instance",
+		F:            testSweepInstance,
+		Dependencies: []string{"scaleway_rdb_instance_clone"},
+	})
+}
+
+func testSweepInstanceClone(_ string) error {
+	return acctest.SweepRegions(scw.AllRegions, func(scwClient *scw.Client, region scw.Region) error {
+		rdbAPI := rdbSDK.NewAPI(scwClient)
+
+		logging.L.Debugf("sweeper: destroying the rdb instance clones in (%s)", region)
+
+		listInstances, err := rdbAPI.ListInstances(&rdbSDK.ListInstancesRequest{
+			Region: region,
+		}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing rdb instances in (%s) in sweeper: %w", region, err)
+		}
+
+		for _, instance := range listInstances.Instances {
+			if _, sourceInstanceID := rdb.SplitInstanceCloneTags(instance.Tags); sourceInstanceID == "" {
+				continue
+			}
+
+			_, err := rdbAPI.DeleteInstance(&rdbSDK.DeleteInstanceRequest{
+				Region:     region,
+				InstanceID: instance.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting rdb instance clone in sweeper: %w", err)
+			}
+		}
+
+		// Snapshots kept with keep_snapshot outlive the clone
+		listSnapshots, err := rdbAPI.ListSnapshots(&rdbSDK.ListSnapshotsRequest{
+			Region: region,
+		}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing rdb snapshots in (%s) in sweeper: %w", region, err)
+		}
+
+		for _, snapshot := range listSnapshots.Snapshots {
+			if !strings.HasPrefix(snapshot.Name, "tf-rdb-clone") {
+				continue
+			}
+
+			_, err := rdbAPI.DeleteSnapshot(&rdbSDK.DeleteSnapshotRequest{
+				Region:     region,
+				SnapshotID: snapshot.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting rdb clone snapshot in sweeper: %w", err)
+			}
+		}
+
+		return nil
 	})
 }
 
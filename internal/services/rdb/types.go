@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -277,3 +278,30 @@ func flattenInstanceLogsPolicy(policy *rdb.LogsPolicy) any {
 
 	return p
 }
+
+// flattenConnection returns the connection block of an instance, its private network endpoint is preferred over the load balancer
+func flattenConnection(instance *rdb.Instance, caCert string) []map[string]any {
+	endpoints := make([]connection.Endpoint, 0, len(instance.Endpoints))
+
+	for _, endpoint := range instance.Endpoints {
+		kind := connection.KindPublicNetwork
+		if endpoint.PrivateNetwork != nil {
+			kind = connection.KindPrivateNetwork
+		} else if endpoint.LoadBalancer == nil {
+			continue
+		}
+
+		host := types.FlattenStringPtr(endpoint.Hostname).(string)
+		if endpoint.IP != nil {
+			host = endpoint.IP.String()
+		}
+
+		endpoints = append(endpoints, connection.Endpoint{
+			Kind: kind,
+			Host: host,
+			Port: int(endpoint.Port),
+		})
+	}
+
+	return connection.Flatten(connection.EngineFromRDB(instance.Engine), endpoints, caCert, true)
+}
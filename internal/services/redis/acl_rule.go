@@ -0,0 +1,205 @@
+package redis
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/acl_rule.md
+var aclRuleDescription string
+
+func redisACLRuleIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"zone":       identity.DefaultZoneAttribute(),
+		"cluster_id": {Type: schema.TypeString, Description: "The Redis cluster ID", RequiredForImport: true},
+		"acl_id":     {Type: schema.TypeString, Description: "The ACL rule ID", RequiredForImport: true},
+	})
+}
+
+func ResourceACLRule() *schema.Resource {
+	return &schema.Resource{
+		Description:   aclRuleDescription,
+		CreateContext: ResourceACLRuleCreate,
+		ReadContext:   ResourceACLRuleRead,
+		DeleteContext: ResourceACLRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Read:    schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Delete:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Default: schema.DefaultTimeout(defaultRedisClusterTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    aclRuleSchema,
+		Identity:      redisACLRuleIdentity(),
+		CustomizeDiff: cdf.LocalityCheck("cluster_id"),
+	}
+}
+
+func aclRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cluster_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			Description:      "Cluster the rule is added to",
+		},
+		"ip": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsIPv4CIDR(),
+			Description:      "IPv4 network address of the rule in CIDR notation (IPv6 is not supported by the Scaleway API)",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Description of the rule",
+		},
+		// Common
+		"zone": zonal.Schema(),
+	}
+}
+
+func ResourceACLRuleCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	redisAPI := newAPI(m)
+
+	zone, clusterID, err := zonal.ParseID(d.Get("cluster_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ip, err := types.ExpandIPNet(d.Get("ip").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := redisAPI.AddACLRules(&redis.AddACLRulesRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+		ACLRules: []*redis.ACLRuleSpec{{
+			IPCidr:      ip,
+			Description: d.Get("description").(string),
+		}},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var rule *redis.ACLRule
+
+	for _, aclRule := range res.ACLRules {
+		if aclRule.IPCidr.String() == ip.String() {
+			rule = aclRule
+		}
+	}
+
+	if rule == nil {
+		return diag.FromErr(fmt.Errorf("rule %s not found in the rules of cluster %s", ip.String(), clusterID))
+	}
+
+	if err := identity.SetMultiPartIdentity(d, map[string]string{
+		"zone":       string(zone),
+		"cluster_id": clusterID,
+		"acl_id":     rule.ID,
+	}, "zone", "cluster_id", "acl_id"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceACLRuleRead(ctx, d, m)
+}
+
+func ResourceACLRuleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	redisAPI := newAPI(m)
+
+	idParts := identity.ParseMultiPartID(d.Id(), "zone", "cluster_id", "acl_id")
+	zone := scw.Zone(idParts["zone"])
+
+	rule, err := redisAPI.GetACLRule(&redis.GetACLRuleRequest{
+		Zone:  zone,
+		ACLID: idParts["acl_id"],
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err := identity.SetMultiPartIdentity(d, map[string]string{
+		"zone":       string(zone),
+		"cluster_id": idParts["cluster_id"],
+		"acl_id":     rule.ID,
+	}, "zone", "cluster_id", "acl_id"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("cluster_id", zonal.NewIDString(zone, idParts["cluster_id"]))
+	_ = d.Set("ip", rule.IPCidr.String())
+	_ = d.Set("description", types.FlattenStringPtr(rule.Description))
+	_ = d.Set("zone", string(zone))
+
+	return nil
+}
+
+func ResourceACLRuleDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	redisAPI := newAPI(m)
+
+	idParts := identity.ParseMultiPartID(d.Id(), "zone", "cluster_id", "acl_id")
+	zone := scw.Zone(idParts["zone"])
+	clusterID := idParts["cluster_id"]
+
+	_, err := waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_, err = redisAPI.DeleteACLRule(&redis.DeleteACLRuleRequest{
+		Zone:  zone,
+		ACLID: idParts["acl_id"],
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
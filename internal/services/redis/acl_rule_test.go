@@ -0,0 +1,64 @@
+package redis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccACLRule_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestRedisVersion := getLatestVersion(tt)
+	config := fmt.Sprintf(`
+		resource "scaleway_redis_cluster" "main" {
+		  name                      = "test_redis_acl_rule"
+		  version                   = "%s"
+		  node_type                 = "RED1-XS"
+		  user_name                 = "my_initial_user"
+		  password                  = "thiZ_is_v&ry_s3cret"
+		  cluster_size              = 1
+		  ignore_external_acl_rules = true
+
+		  acl {
+		    ip          = "0.0.0.0/0"
+		    description = "managed by the cluster"
+		  }
+		}
+
+		resource "scaleway_redis_acl_rule" "office" {
+		  cluster_id  = scaleway_redis_cluster.main.id
+		  ip          = "192.0.2.0/24"
+		  description = "office"
+		}
+	`, latestRedisVersion)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isClusterDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_redis_acl_rule.office", "cluster_id", "scaleway_redis_cluster.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_redis_acl_rule.office", "ip", "192.0.2.0/24"),
+					resource.TestCheckResourceAttr("scaleway_redis_acl_rule.office", "description", "office"),
+					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "acl.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_redis_acl_rule.office",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// The rule of scaleway_redis_acl_rule does not show up in the acl blocks of the cluster
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -5,6 +5,8 @@ import (
 	_ "embed"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,11 +18,13 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/account"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/ipam"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -39,6 +43,7 @@ func ResourceCluster() *schema.Resource {
 		DeleteContext: ResourceClusterDelete,
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Read:    schema.DefaultTimeout(defaultRedisClusterTimeout),
 			Update:  schema.DefaultTimeout(defaultRedisClusterTimeout),
 			Delete:  schema.DefaultTimeout(defaultRedisClusterTimeout),
 			Default: schema.DefaultTimeout(defaultRedisClusterTimeout),
@@ -52,6 +57,8 @@ func ResourceCluster() *schema.Resource {
 		CustomizeDiff: customdiff.All(
 			cdf.LocalityCheck("private_network.#.id"),
 			customizeDiffMigrateClusterSize(),
+			customizeDiffClusterMigration,
+			customizeDiffClusterSettings,
 		),
 	}
 }
@@ -150,12 +157,24 @@ func clusterSchema() map[string]*schema.Schema {
 		},
 		"settings": {
 			Type:        schema.TypeMap,
-			Description: "Map of settings to define for the cluster.",
+			Description: "Map of settings to define for the cluster. Setting names are validated against the node type at plan time.",
 			Optional:    true,
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
 		},
+		"ignore_external_acl_rules": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Only manage the acl rules of the configuration, the other rules, such as the ones of scaleway_redis_acl_rule, are left untouched",
+		},
+		"ignore_external_settings": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Only manage the settings of the configuration, the other settings, such as the ones of scaleway_redis_setting, are left untouched",
+		},
 		"private_network": {
 			Type:          schema.TypeSet,
 			Optional:      true,
@@ -269,12 +288,18 @@ func clusterSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "public TLS certificate used by redis cluster, empty if tls is disabled",
 		},
+		"connection": connection.Schema(),
 		"connection_string": {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Sensitive:   true,
 			Description: "Redis connection URI for the first reachable endpoint (public is preferred over private). Uses scheme `rediss` when TLS is enabled. Database index is always `0`. When a password is available in state, userinfo includes `user_name` and the password (Redis ACL). When `password_wo` is used, the password is omitted because it is not stored in state.",
 		},
+		"migration_estimate": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Estimated migrations, duration and downtime of the last change of `cluster_size`, `version` or `node_type`, shown in the plan and kept once the migrations are applied",
+		},
 		"created_at": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -297,7 +322,7 @@ func customizeDiffMigrateClusterSize() schema.CustomizeDiffFunc {
 		oldSize, _ := oldSizeRaw.(int)
 		newSize, _ := newSizeRaw.(int)
 
-		if oldSize == 1 && newSize != 1 || newSize < oldSize {
+		if clusterSizeRequiresRecreate(oldSize, newSize) {
 			return diff.ForceNew("cluster_size")
 		}
 
@@ -433,8 +458,18 @@ func setClusterState(ctx context.Context, d *schema.ResourceData, redisAPI *redi
 	_ = d.Set("cluster_size", int(cluster.ClusterSize))
 	_ = d.Set("created_at", cluster.CreatedAt.Format(time.RFC3339))
 	_ = d.Set("updated_at", cluster.UpdatedAt.Format(time.RFC3339))
-	_ = d.Set("acl", flattenACLs(cluster.ACLRules))
-	_ = d.Set("settings", flattenSettings(cluster.ClusterSettings))
+	aclRules := cluster.ACLRules
+	if d.Get("ignore_external_acl_rules").(bool) {
+		aclRules = filterManagedACLRules(d.Get("acl").(*schema.Set), aclRules)
+	}
+
+	settings := cluster.ClusterSettings
+	if d.Get("ignore_external_settings").(bool) {
+		settings = filterManagedSettings(d.Get("settings").(map[string]any), settings)
+	}
+
+	_ = d.Set("acl", flattenACLs(aclRules))
+	_ = d.Set("settings", flattenSettings(settings))
 
 	if len(cluster.Tags) > 0 {
 		_ = d.Set("tags", cluster.Tags)
@@ -536,6 +571,7 @@ func setClusterState(ctx context.Context, d *schema.ResourceData, redisAPI *redi
 	}
 
 	_ = d.Set("connection_string", redisConnectionString(cluster.Endpoints, userName, connPassword, cluster.TLSEnabled))
+	_ = d.Set("connection", flattenConnection(cluster.Endpoints, d.Get("certificate").(string), cluster.TLSEnabled))
 
 	return diags
 }
@@ -628,12 +664,15 @@ func ResourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 	}
 
 	migrateClusterRequests := []redis.MigrateClusterRequest(nil)
+	migrations := []string(nil)
+
 	if d.HasChange("cluster_size") {
 		migrateClusterRequests = append(migrateClusterRequests, redis.MigrateClusterRequest{
 			Zone:        zone,
 			ClusterID:   ID,
 			ClusterSize: new(uint32(d.Get("cluster_size").(int))),
 		})
+		migrations = append(migrations, "cluster_size")
 	}
 
 	if d.HasChange("version") {
@@ -642,6 +681,7 @@ func ResourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 			ClusterID: ID,
 			Version:   types.ExpandStringPtr(d.Get("version")),
 		})
+		migrations = append(migrations, "version")
 	}
 
 	if d.HasChange("node_type") {
@@ -650,6 +690,7 @@ func ResourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 			ClusterID: ID,
 			NodeType:  types.ExpandStringPtr(d.Get("node_type")),
 		})
+		migrations = append(migrations, "node_type")
 	}
 
 	for i := range migrateClusterRequests {
@@ -663,12 +704,19 @@ func ResourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 			return diag.FromErr(err)
 		}
 
-		_, err = waitForCluster(ctx, redisAPI, zone, ID, d.Timeout(schema.TimeoutUpdate))
+		oldValue, newValue := d.GetChange(migrations[i])
+		migration := fmt.Sprintf("%s %v -> %v (%d/%d)", migrations[i], oldValue, newValue, i+1, len(migrateClusterRequests))
+
+		_, err = waitForClusterMigration(ctx, redisAPI, zone, ID, migration, d.Timeout(schema.TimeoutUpdate))
 		if err != nil && !httperrors.Is404(err) {
 			return diag.FromErr(err)
 		}
 	}
 
+	if estimate := EstimateClusterMigration(clusterMigrationShapes(d.GetChange)); len(estimate.Steps) > 0 {
+		_ = d.Set("migration_estimate", estimate.String())
+	}
+
 	if d.HasChanges("private_network") {
 		diagnostics := ResourceClusterUpdateEndpoints(ctx, d, redisAPI, zone, ID)
 		if diagnostics != nil {
@@ -685,6 +733,10 @@ func ResourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 }
 
 func updateACL(ctx context.Context, d *schema.ResourceData, redisAPI *redis.API, zone scw.Zone, clusterID string) diag.Diagnostics {
+	if d.Get("ignore_external_acl_rules").(bool) {
+		return updateManagedACL(ctx, d, redisAPI, zone, clusterID)
+	}
+
 	rules, err := expandACLSpecs(d.Get("acl"))
 	if err != nil {
 		return diag.FromErr(err)
@@ -703,6 +755,10 @@ func updateACL(ctx context.Context, d *schema.ResourceData, redisAPI *redis.API,
 }
 
 func updateSettings(ctx context.Context, d *schema.ResourceData, redisAPI *redis.API, zone scw.Zone, clusterID string) diag.Diagnostics {
+	if d.Get("ignore_external_settings").(bool) {
+		return updateManagedSettings(ctx, d, redisAPI, zone, clusterID)
+	}
+
 	settings := expandSettings(d.Get("settings"))
 
 	_, err := redisAPI.SetClusterSettings(&redis.SetClusterSettingsRequest{
@@ -717,6 +773,102 @@ func updateSettings(ctx context.Context, d *schema.ResourceData, redisAPI *redis
 	return nil
 }
 
+// updateManagedACL only adds and deletes the rules that changed in the configuration
+func updateManagedACL(ctx context.Context, d *schema.ResourceData, redisAPI *redis.API, zone scw.Zone, clusterID string) diag.Diagnostics {
+	oldRules, newRules := d.GetChange("acl")
+	added, removed := diffACLRules(oldRules.(*schema.Set), newRules.(*schema.Set))
+
+	for _, ruleID := range removed {
+		_, err := redisAPI.DeleteACLRule(&redis.DeleteACLRuleRequest{
+			Zone:  zone,
+			ACLID: ruleID,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	rules, err := expandACLSpecs(schema.NewSet(newRules.(*schema.Set).F, added))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = redisAPI.AddACLRules(&redis.AddACLRulesRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+		ACLRules:  rules,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// updateManagedSettings only sets and deletes the settings that changed in the configuration
+func updateManagedSettings(ctx context.Context, d *schema.ResourceData, redisAPI *redis.API, zone scw.Zone, clusterID string) diag.Diagnostics {
+	oldSettings, newSettings := d.GetChange("settings")
+
+	for name := range oldSettings.(map[string]any) {
+		if _, kept := newSettings.(map[string]any)[name]; kept {
+			continue
+		}
+
+		_, err := redisAPI.DeleteClusterSetting(&redis.DeleteClusterSettingRequest{
+			Zone:        zone,
+			ClusterID:   clusterID,
+			SettingName: name,
+		}, scw.WithContext(ctx))
+		if err != nil && !httperrors.Is404(err) {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	changed := changedSettings(oldSettings.(map[string]any), newSettings.(map[string]any))
+	if len(changed) == 0 {
+		return nil
+	}
+
+	_, err := redisAPI.AddClusterSettings(&redis.AddClusterSettingsRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+		Settings:  expandSettings(changed),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// customizeDiffClusterSettings validates the names of the changed settings against the node type
+func customizeDiffClusterSettings(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if !diff.HasChange("settings") || !diff.NewValueKnown("settings") || !diff.NewValueKnown("node_type") {
+		return nil
+	}
+
+	oldSettings, newSettings := diff.GetChange("settings")
+	names := slices.Sorted(maps.Keys(changedSettings(oldSettings.(map[string]any), newSettings.(map[string]any))))
+
+	zone, _ := meta.ExtractZone(diff, m)
+
+	return validateClusterSettingsForNodeType(ctx, m, zone, diff.Get("node_type").(string), names)
+}
+
 func ResourceClusterUpdateEndpoints(ctx context.Context, d *schema.ResourceData, redisAPI *redis.API, zone scw.Zone, clusterID string) diag.Diagnostics {
 	// retrieve state
 	cluster, err := waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutUpdate))
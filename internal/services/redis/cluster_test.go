@@ -151,6 +151,7 @@ func TestAccCluster_Migrate(t *testing.T) {
 					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "tags.0", "test1"),
 					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "cluster_size", "1"),
 					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "tls_enabled", "true"),
+					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "migration_estimate", "node_type RED1-XS -> RED1-S (about 5m0s, downtime while the node is replaced)"),
 				),
 			},
 		},
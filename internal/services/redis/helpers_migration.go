@@ -0,0 +1,340 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/transport"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+const (
+	// migrationMinutesPerNode is a rough duration of the replacement of a node during a migration
+	migrationMinutesPerNode = 5
+	// migrationProgressInterval is the interval between two progress logs when the status does not change
+	migrationProgressInterval = time.Minute
+)
+
+// clusterModeUnsupportedNodeTypes cannot run in cluster mode, the API only reports it once the cluster is created
+var clusterModeUnsupportedNodeTypes = []string{"RED1-MICRO"}
+
+// nodeTypeCatalogue caches the node types of each zone, they only change with Scaleway releases
+var nodeTypeCatalogue = struct {
+	sync.Mutex
+	nodeTypes map[scw.Zone][]*redis.NodeType
+}{
+	nodeTypes: map[scw.Zone][]*redis.NodeType{},
+}
+
+// listNodeTypes returns the node types of a zone, fetching them once per provider run
+func listNodeTypes(ctx context.Context, redisAPI *redis.API, zone scw.Zone) ([]*redis.NodeType, error) {
+	nodeTypeCatalogue.Lock()
+	defer nodeTypeCatalogue.Unlock()
+
+	if nodeTypes, ok := nodeTypeCatalogue.nodeTypes[zone]; ok {
+		return nodeTypes, nil
+	}
+
+	res, err := redisAPI.ListNodeTypes(&redis.ListNodeTypesRequest{
+		Zone:                 zone,
+		IncludeDisabledTypes: true,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	nodeTypeCatalogue.nodeTypes[zone] = res.NodeTypes
+
+	return res.NodeTypes, nil
+}
+
+func findNodeType(nodeTypes []*redis.NodeType, name string) *redis.NodeType {
+	for _, nodeType := range nodeTypes {
+		if strings.EqualFold(nodeType.Name, name) {
+			return nodeType
+		}
+	}
+
+	return nil
+}
+
+// ClusterShape holds the attributes of a cluster that are changed through a migration
+type ClusterShape struct {
+	Version  string
+	NodeType string
+	Size     int
+}
+
+// clusterSizeRequiresRecreate returns true when the API cannot migrate a cluster between two sizes:
+// a standalone cluster cannot become a cluster and a cluster cannot shrink
+func clusterSizeRequiresRecreate(oldSize, newSize int) bool {
+	return oldSize == 1 && newSize != 1 || newSize < oldSize
+}
+
+// ValidateClusterTransition rejects the changes the API cannot apply. from is empty when the cluster is created or recreated.
+// nodeTypes is used to detect node type downgrades, they are not checked when it is nil.
+func ValidateClusterTransition(from, to ClusterShape, nodeTypes []*redis.NodeType) error {
+	if to.Size >= 3 && slices.ContainsFunc(clusterModeUnsupportedNodeTypes, func(nodeType string) bool { return strings.EqualFold(nodeType, to.NodeType) }) {
+		return fmt.Errorf("node type %s does not support cluster mode (cluster_size >= 3), use a larger node type or a cluster_size of 1 or 2", to.NodeType)
+	}
+
+	if from == (ClusterShape{}) {
+		return nil
+	}
+
+	if compareVersions(to.Version, from.Version) < 0 {
+		return fmt.Errorf("version cannot be downgraded from %s to %s", from.Version, to.Version)
+	}
+
+	if !strings.EqualFold(from.NodeType, to.NodeType) {
+		fromNodeType, toNodeType := findNodeType(nodeTypes, from.NodeType), findNodeType(nodeTypes, to.NodeType)
+		if fromNodeType != nil && toNodeType != nil && toNodeType.Memory < fromNodeType.Memory {
+			return fmt.Errorf("node_type cannot be downgraded from %s to %s, the cluster must be recreated to use a smaller node type", from.NodeType, to.NodeType)
+		}
+	}
+
+	return nil
+}
+
+// compareVersions compares dotted versions such as 7.2.5 numerically
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := range max(len(aParts), len(bParts)) {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart, _ = strconv.Atoi(aParts[i])
+		}
+
+		if i < len(bParts) {
+			bPart, _ = strconv.Atoi(bParts[i])
+		}
+
+		if aPart != bPart {
+			return aPart - bPart
+		}
+	}
+
+	return 0
+}
+
+// ClusterMigrationEstimate is a rough impact of the migrations of an update
+type ClusterMigrationEstimate struct {
+	// Steps are the migrations in the order they are applied
+	Steps []string
+	// Duration assumes every node is replaced once per step
+	Duration time.Duration
+	// Downtime is true when the cluster has no replica to serve the requests while its node is replaced
+	Downtime bool
+}
+
+// String describes the estimate as shown in the plan
+func (e ClusterMigrationEstimate) String() string {
+	if len(e.Steps) == 0 {
+		return ""
+	}
+
+	impact := "no downtime"
+	if e.Downtime {
+		impact = "downtime while the node is replaced"
+	}
+
+	return fmt.Sprintf("%s (about %s, %s)", strings.Join(e.Steps, ", then "), e.Duration, impact)
+}
+
+// EstimateClusterMigration returns the migrations needed to go from one shape to another, in the order ResourceClusterUpdate applies them
+func EstimateClusterMigration(from, to ClusterShape) ClusterMigrationEstimate {
+	estimate := ClusterMigrationEstimate{}
+
+	if from.Size != to.Size {
+		estimate.Steps = append(estimate.Steps, fmt.Sprintf("cluster_size %d -> %d", from.Size, to.Size))
+	}
+
+	if from.Version != to.Version {
+		estimate.Steps = append(estimate.Steps, fmt.Sprintf("version %s -> %s", from.Version, to.Version))
+	}
+
+	if !strings.EqualFold(from.NodeType, to.NodeType) {
+		estimate.Steps = append(estimate.Steps, fmt.Sprintf("node_type %s -> %s", from.NodeType, to.NodeType))
+	}
+
+	if len(estimate.Steps) == 0 {
+		return estimate
+	}
+
+	estimate.Duration = time.Duration(len(estimate.Steps)*max(from.Size, to.Size)*migrationMinutesPerNode) * time.Minute
+	estimate.Downtime = from.Size == 1
+
+	return estimate
+}
+
+// clusterMigrationShapes returns the shapes of a cluster before and after a change of its version, node type or size
+func clusterMigrationShapes(getChange func(string) (any, any)) (ClusterShape, ClusterShape) {
+	oldVersion, newVersion := getChange("version")
+	oldNodeType, newNodeType := getChange("node_type")
+	oldSize, newSize := getChange("cluster_size")
+
+	from := ClusterShape{Version: oldVersion.(string), NodeType: oldNodeType.(string), Size: oldSize.(int)}
+	to := ClusterShape{Version: newVersion.(string), NodeType: newNodeType.(string), Size: newSize.(int)}
+
+	// the size is computed when not set, the state value is kept
+	if to.Size == 0 {
+		to.Size = from.Size
+	}
+
+	return from, to
+}
+
+// customizeDiffClusterMigration rejects illegal migrations at plan time and shows their estimated impact in the plan.
+// The node type catalogue is fetched on a best effort basis, downgrades are left to the API when it cannot be fetched.
+func customizeDiffClusterMigration(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	for _, key := range []string{"version", "node_type", "cluster_size"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	from, to := clusterMigrationShapes(diff.GetChange)
+
+	if diff.Id() == "" || clusterSizeRequiresRecreate(from.Size, to.Size) {
+		return ValidateClusterTransition(ClusterShape{}, to, nil)
+	}
+
+	var nodeTypes []*redis.NodeType
+
+	if diff.HasChange("node_type") {
+		zone, _ := meta.ExtractZone(diff, m)
+
+		var err error
+
+		nodeTypes, err = listNodeTypes(ctx, newAPI(m), zone)
+		if err != nil {
+			tflog.Warn(ctx, "could not fetch the node types, downgrades will be rejected by the API", map[string]any{"error": err.Error()})
+		}
+	}
+
+	err := ValidateClusterTransition(from, to, nodeTypes)
+	if err != nil {
+		return err
+	}
+
+	// the update writes the same estimate once the migrations are applied
+	if estimate := EstimateClusterMigration(from, to); len(estimate.Steps) > 0 {
+		return diff.SetNew("migration_estimate", estimate.String())
+	}
+
+	return nil
+}
+
+// waitForClusterMigration waits for a cluster to be ready after a migration and logs its progress
+func waitForClusterMigration(ctx context.Context, api *redis.API, zone scw.Zone, id string, migration string, timeout time.Duration) (*redis.Cluster, error) {
+	retryInterval := defaultWaitRedisClusterRetryInterval
+	if transport.DefaultWaitRetryInterval != nil {
+		retryInterval = *transport.DefaultWaitRetryInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	lastStatus := redis.ClusterStatus("")
+	lastLog := time.Time{}
+
+	for {
+		cluster, err := api.GetCluster(&redis.GetClusterRequest{
+			Zone:      zone,
+			ClusterID: id,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, migrationTimeoutError(id, migration, timeout, lastStatus)
+			}
+
+			return nil, err
+		}
+
+		if cluster.Status != lastStatus || time.Since(lastLog) >= migrationProgressInterval {
+			tflog.Info(ctx, "redis cluster migration in progress", map[string]any{
+				"cluster_id": id,
+				"migration":  migration,
+				"status":     cluster.Status.String(),
+				"elapsed":    time.Since(start).Round(time.Second).String(),
+			})
+
+			lastStatus, lastLog = cluster.Status, time.Now()
+		}
+
+		switch cluster.Status {
+		case redis.ClusterStatusReady:
+			return cluster, nil
+		case redis.ClusterStatusError, redis.ClusterStatusLocked, redis.ClusterStatusSuspended:
+			return nil, fmt.Errorf("migration %s of cluster %s stopped with status %s", migration, id, cluster.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, migrationTimeoutError(id, migration, timeout, lastStatus)
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+func migrationTimeoutError(id string, migration string, timeout time.Duration, status redis.ClusterStatus) error {
+	return fmt.Errorf("migration %s of cluster %s did not complete within %s (status %s), it goes on in the background: raise the update timeout then refresh", migration, id, timeout, status)
+}
+
+// validateClusterSettings checks setting names against the settings available for a node type
+func validateClusterSettings(settings []string, nodeType *redis.NodeType) error {
+	available := make([]string, 0, len(nodeType.AvailableClusterSettings))
+	for _, setting := range nodeType.AvailableClusterSettings {
+		available = append(available, setting.Name)
+	}
+
+	var errs []error
+
+	for _, name := range settings {
+		if slices.Contains(available, name) {
+			continue
+		}
+
+		if best := verify.ClosestMatch(name, available); best != "" {
+			errs = append(errs, fmt.Errorf("unknown setting %q for node type %s, did you mean %q?", name, nodeType.Name, best))
+		} else {
+			errs = append(errs, fmt.Errorf("unknown setting %q for node type %s, available settings: %s", name, nodeType.Name, strings.Join(available, ", ")))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateClusterSettingsForNodeType validates setting names against the catalogue on a best effort basis
+func validateClusterSettingsForNodeType(ctx context.Context, m any, zone scw.Zone, nodeTypeName string, settings []string) error {
+	if len(settings) == 0 || nodeTypeName == "" {
+		return nil
+	}
+
+	nodeTypes, err := listNodeTypes(ctx, newAPI(m), zone)
+	if err != nil {
+		tflog.Warn(ctx, "could not fetch the node types, settings will be validated by the API", map[string]any{"error": err.Error()})
+
+		return nil
+	}
+
+	nodeType := findNodeType(nodeTypes, nodeTypeName)
+	if nodeType == nil || len(nodeType.AvailableClusterSettings) == 0 {
+		return nil
+	}
+
+	return validateClusterSettings(settings, nodeType)
+}
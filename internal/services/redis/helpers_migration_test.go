@@ -0,0 +1,178 @@
+//nolint:testpackage // Tests need access to unexported migration helpers.
+package redis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func TestValidateClusterTransition(t *testing.T) {
+	t.Parallel()
+
+	nodeTypes := []*redis.NodeType{
+		{Name: "RED1-XS", Memory: 1 * scw.GB},
+		{Name: "RED1-S", Memory: 2 * scw.GB},
+		{Name: "RED1-M", Memory: 4 * scw.GB},
+	}
+
+	tests := []struct {
+		name    string
+		from    ClusterShape
+		to      ClusterShape
+		wantErr string
+	}{
+		{
+			name: "create standalone micro",
+			to:   ClusterShape{Version: "7.2.5", NodeType: "RED1-MICRO", Size: 1},
+		},
+		{
+			name:    "create cluster micro",
+			to:      ClusterShape{Version: "7.2.5", NodeType: "red1-micro", Size: 3},
+			wantErr: "does not support cluster mode",
+		},
+		{
+			name: "upgrade node type",
+			from: ClusterShape{Version: "7.2.5", NodeType: "RED1-S", Size: 3},
+			to:   ClusterShape{Version: "7.2.5", NodeType: "RED1-M", Size: 3},
+		},
+		{
+			name:    "downgrade node type",
+			from:    ClusterShape{Version: "7.2.5", NodeType: "RED1-M", Size: 3},
+			to:      ClusterShape{Version: "7.2.5", NodeType: "RED1-XS", Size: 3},
+			wantErr: "node_type cannot be downgraded",
+		},
+		{
+			name:    "downgrade version",
+			from:    ClusterShape{Version: "7.2.5", NodeType: "RED1-S", Size: 1},
+			to:      ClusterShape{Version: "6.2.14", NodeType: "RED1-S", Size: 1},
+			wantErr: "version cannot be downgraded",
+		},
+		{
+			name: "upgrade version",
+			from: ClusterShape{Version: "7.2.5", NodeType: "RED1-S", Size: 1},
+			to:   ClusterShape{Version: "7.2.11", NodeType: "RED1-S", Size: 1},
+		},
+		{
+			name: "unknown node types are left to the API",
+			from: ClusterShape{Version: "7.2.5", NodeType: "RED1-L", Size: 3},
+			to:   ClusterShape{Version: "7.2.5", NodeType: "RED1-XS", Size: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateClusterTransition(tt.from, tt.to, nodeTypes)
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEstimateClusterMigration(t *testing.T) {
+	t.Parallel()
+
+	estimate := EstimateClusterMigration(
+		ClusterShape{Version: "7.2.5", NodeType: "RED1-S", Size: 3},
+		ClusterShape{Version: "7.2.11", NodeType: "RED1-M", Size: 5},
+	)
+
+	wantSteps := []string{"cluster_size 3 -> 5", "version 7.2.5 -> 7.2.11", "node_type RED1-S -> RED1-M"}
+	if strings.Join(estimate.Steps, ",") != strings.Join(wantSteps, ",") {
+		t.Fatalf("steps = %v, want %v", estimate.Steps, wantSteps)
+	}
+
+	if estimate.Duration != 75*time.Minute {
+		t.Fatalf("duration = %s, want 1h15m", estimate.Duration)
+	}
+
+	if estimate.Downtime {
+		t.Fatal("a cluster with replicas should not have a downtime")
+	}
+
+	wantString := "cluster_size 3 -> 5, then version 7.2.5 -> 7.2.11, then node_type RED1-S -> RED1-M (about 1h15m0s, no downtime)"
+	if estimate.String() != wantString {
+		t.Fatalf("string = %q, want %q", estimate.String(), wantString)
+	}
+
+	standalone := EstimateClusterMigration(
+		ClusterShape{Version: "7.2.5", NodeType: "RED1-S", Size: 1},
+		ClusterShape{Version: "7.2.5", NodeType: "RED1-M", Size: 1},
+	)
+	if !standalone.Downtime || standalone.Duration != 5*time.Minute {
+		t.Fatalf("unexpected standalone estimate %+v", standalone)
+	}
+
+	if unchanged := EstimateClusterMigration(ClusterShape{Size: 1}, ClusterShape{Size: 1}); len(unchanged.Steps) != 0 || unchanged.String() != "" {
+		t.Fatalf("unexpected steps %v", unchanged.Steps)
+	}
+}
+
+func TestDiffACLRules(t *testing.T) {
+	t.Parallel()
+
+	hash := schema.HashResource(clusterSchema()["acl"].Elem.(*schema.Resource))
+
+	oldRules := schema.NewSet(hash, []any{
+		map[string]any{"id": "kept", "ip": "10.0.0.0/24", "description": "office"},
+		map[string]any{"id": "removed", "ip": "192.168.0.1/32", "description": "laptop"},
+		map[string]any{"id": "renamed", "ip": "172.16.0.0/16", "description": "old"},
+	})
+	newRules := schema.NewSet(hash, []any{
+		map[string]any{"id": "", "ip": "10.0.0.5/24", "description": ""},
+		map[string]any{"id": "", "ip": "172.16.0.0/16", "description": "new"},
+		map[string]any{"id": "", "ip": "1.2.3.4/32", "description": "added"},
+	})
+
+	added, removed := diffACLRules(oldRules, newRules)
+
+	addedIPs := []string(nil)
+	for _, rule := range added {
+		addedIPs = append(addedIPs, rule.(map[string]any)["ip"].(string))
+	}
+
+	if strings.Join(addedIPs, ",") != "1.2.3.4/32,172.16.0.0/16" && strings.Join(addedIPs, ",") != "172.16.0.0/16,1.2.3.4/32" {
+		t.Fatalf("added = %v", addedIPs)
+	}
+
+	if strings.Join(removed, ",") != "removed,renamed" && strings.Join(removed, ",") != "renamed,removed" {
+		t.Fatalf("removed = %v", removed)
+	}
+}
+
+func TestValidateClusterSettings(t *testing.T) {
+	t.Parallel()
+
+	nodeType := &redis.NodeType{
+		Name: "RED1-S",
+		AvailableClusterSettings: []*redis.AvailableClusterSetting{
+			{Name: "maxclients"},
+			{Name: "tcp-keepalive"},
+		},
+	}
+
+	if err := validateClusterSettings([]string{"maxclients", "tcp-keepalive"}, nodeType); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err := validateClusterSettings([]string{"maxclient"}, nodeType)
+	if err == nil || !strings.Contains(err.Error(), `did you mean "maxclients"`) {
+		t.Fatalf("expected a suggestion, got %v", err)
+	}
+
+	err = validateClusterSettings([]string{"appendonly"}, nodeType)
+	if err == nil || !strings.Contains(err.Error(), "available settings: maxclients, tcp-keepalive") {
+		t.Fatalf("expected the available settings, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package redis
+
+import (
+	"context"
+	_ "embed"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/setting.md
+var settingDescription string
+
+func redisSettingIdentity() *schema.ResourceIdentity {
+	return identity.WrapSchemaMap(map[string]*schema.Schema{
+		"zone":       identity.DefaultZoneAttribute(),
+		"cluster_id": {Type: schema.TypeString, Description: "The Redis cluster ID", RequiredForImport: true},
+		"name":       {Type: schema.TypeString, Description: "The setting name", RequiredForImport: true},
+	})
+}
+
+func ResourceSetting() *schema.Resource {
+	return &schema.Resource{
+		Description:   settingDescription,
+		CreateContext: ResourceSettingCreate,
+		ReadContext:   ResourceSettingRead,
+		UpdateContext: ResourceSettingUpdate,
+		DeleteContext: ResourceSettingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Read:    schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Update:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Delete:  schema.DefaultTimeout(defaultRedisClusterTimeout),
+			Default: schema.DefaultTimeout(defaultRedisClusterTimeout),
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    settingSchema,
+		Identity:      redisSettingIdentity(),
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("cluster_id"),
+			customizeDiffSetting,
+		),
+	}
+}
+
+func settingSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cluster_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			Description:      "Cluster the setting is defined on",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the setting, e.g. maxclients. It is validated against the node type of the cluster at plan time",
+		},
+		"value": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Value of the setting",
+		},
+		// Common
+		"zone": zonal.Schema(),
+	}
+}
+
+// customizeDiffSetting validates the setting name when the cluster already exists
+func customizeDiffSetting(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if !diff.HasChange("name") || !diff.NewValueKnown("cluster_id") || !diff.NewValueKnown("name") {
+		return nil
+	}
+
+	zone, clusterID, err := zonal.ParseID(diff.Get("cluster_id").(string))
+	if err != nil {
+		return nil //nolint:nilerr // the locality check reports invalid IDs
+	}
+
+	cluster, err := newAPI(m).GetCluster(&redis.GetClusterRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil //nolint:nilerr // the setting is validated by the API when the cluster cannot be fetched
+	}
+
+	return validateClusterSettingsForNodeType(ctx, m, zone, cluster.NodeType, []string{diff.Get("name").(string)})
+}
+
+func ResourceSettingCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	redisAPI := newAPI(m)
+
+	zone, clusterID, err := zonal.ParseID(d.Get("cluster_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+
+	diags := setClusterSetting(ctx, d, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := identity.SetMultiPartIdentity(d, map[string]string{
+		"zone":       string(zone),
+		"cluster_id": clusterID,
+		"name":       name,
+	}, "zone", "cluster_id", "name"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceSettingRead(ctx, d, m)
+}
+
+func ResourceSettingRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	redisAPI := newAPI(m)
+
+	idParts := identity.ParseMultiPartID(d.Id(), "zone", "cluster_id", "name")
+	zone := scw.Zone(idParts["zone"])
+
+	cluster, err := redisAPI.GetCluster(&redis.GetClusterRequest{
+		Zone:      zone,
+		ClusterID: idParts["cluster_id"],
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	var setting *redis.ClusterSetting
+
+	for _, clusterSetting := range cluster.ClusterSettings {
+		if clusterSetting.Name == idParts["name"] {
+			setting = clusterSetting
+		}
+	}
+
+	if setting == nil {
+		d.SetId("")
+
+		return nil
+	}
+
+	if err := identity.SetMultiPartIdentity(d, map[string]string{
+		"zone":       string(zone),
+		"cluster_id": cluster.ID,
+		"name":       setting.Name,
+	}, "zone", "cluster_id", "name"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("cluster_id", zonal.NewIDString(zone, cluster.ID))
+	_ = d.Set("name", setting.Name)
+	_ = d.Set("value", setting.Value)
+	_ = d.Set("zone", string(zone))
+
+	return nil
+}
+
+func ResourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	idParts := identity.ParseMultiPartID(d.Id(), "zone", "cluster_id", "name")
+
+	if d.HasChange("value") {
+		diags := setClusterSetting(ctx, d, newAPI(m), scw.Zone(idParts["zone"]), idParts["cluster_id"], d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return ResourceSettingRead(ctx, d, m)
+}
+
+func ResourceSettingDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	redisAPI := newAPI(m)
+
+	idParts := identity.ParseMultiPartID(d.Id(), "zone", "cluster_id", "name")
+	zone := scw.Zone(idParts["zone"])
+	clusterID := idParts["cluster_id"]
+
+	_, err := waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_, err = redisAPI.DeleteClusterSetting(&redis.DeleteClusterSettingRequest{
+		Zone:        zone,
+		ClusterID:   clusterID,
+		SettingName: idParts["name"],
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// setClusterSetting adds or updates a single setting, the other settings of the cluster are kept
+func setClusterSetting(ctx context.Context, d *schema.ResourceData, redisAPI *redis.API, zone scw.Zone, clusterID string, timeout time.Duration) diag.Diagnostics {
+	_, err := waitForCluster(ctx, redisAPI, zone, clusterID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = redisAPI.AddClusterSettings(&redis.AddClusterSettingsRequest{
+		Zone:      zone,
+		ClusterID: clusterID,
+		Settings: []*redis.ClusterSetting{{
+			Name:  d.Get("name").(string),
+			Value: d.Get("value").(string),
+		}},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForCluster(ctx, redisAPI, zone, clusterID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package redis_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func settingConfig(version string, setting string) string {
+	return fmt.Sprintf(`
+		resource "scaleway_redis_cluster" "main" {
+		  name                     = "test_redis_setting"
+		  version                  = "%s"
+		  node_type                = "RED1-XS"
+		  user_name                = "my_initial_user"
+		  password                 = "thiZ_is_v&ry_s3cret"
+		  cluster_size             = 1
+		  ignore_external_settings = true
+
+		  settings = {
+		    "tcp-keepalive" = "150"
+		  }
+		}
+
+		%s
+	`, version, setting)
+}
+
+func TestAccSetting_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	latestRedisVersion := getLatestVersion(tt)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isClusterDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: settingConfig(latestRedisVersion, `
+					resource "scaleway_redis_setting" "maxclients" {
+					  cluster_id = scaleway_redis_cluster.main.id
+					  name       = "maxclients"
+					  value      = "5000"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_redis_setting.maxclients", "cluster_id", "scaleway_redis_cluster.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_redis_setting.maxclients", "name", "maxclients"),
+					resource.TestCheckResourceAttr("scaleway_redis_setting.maxclients", "value", "5000"),
+					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "settings.%", "1"),
+				),
+			},
+			{
+				Config: settingConfig(latestRedisVersion, `
+					resource "scaleway_redis_setting" "maxclients" {
+					  cluster_id = scaleway_redis_cluster.main.id
+					  name       = "maxclients"
+					  value      = "6000"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_redis_setting.maxclients", "value", "6000"),
+					resource.TestCheckResourceAttr("scaleway_redis_cluster.main", "settings.%", "1"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_redis_setting.maxclients",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: settingConfig(latestRedisVersion, `
+					resource "scaleway_redis_setting" "unknown" {
+					  cluster_id = scaleway_redis_cluster.main.id
+					  name       = "not-a-setting"
+					  value      = "1"
+					}
+				`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("not-a-setting"),
+			},
+		},
+	})
+}
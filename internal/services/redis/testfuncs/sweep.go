@@ -12,9 +12,80 @@ import (
 )
 
 func AddTestSweepers() {
+	resource.AddTestSweepers("scaleway_redis_acl_rule", &resource.Sweeper{
+		Name: "scaleway_redis_acl_rule",
+		F:    testSweepRedisACLRule,
+	})
+	resource.AddTestSweepers("scaleway_redis_setting", &resource.Sweeper{
+		Name: "scaleway_redis_setting",
+		F:    testSweepRedisSetting,
+	})
 	resource.AddTestSweepers("scaleway_redis_cluster", &resource.Sweeper{
 		Name: "scaleway_redis_cluster",
 		F:    testSweepRedisCluster,
+		Dependencies: []string{
+			"scaleway_redis_acl_rule",
+			"scaleway_redis_setting",
+		},
+	})
+}
+
+func testSweepRedisACLRule(_ string) error {
+	return acctest.SweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+		redisAPI := redisSDK.NewAPI(scwClient)
+
+		logging.L.Debugf("sweeper: destroying the redis acl rules in (%s)", zone)
+
+		listClusters, err := redisAPI.ListClusters(&redisSDK.ListClustersRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing redis clusters in (%s) in sweeper: %w", zone, err)
+		}
+
+		for _, cluster := range listClusters.Clusters {
+			for _, rule := range cluster.ACLRules {
+				_, err := redisAPI.DeleteACLRule(&redisSDK.DeleteACLRuleRequest{
+					Zone:  zone,
+					ACLID: rule.ID,
+				})
+				if err != nil && !httperrors.Is404(err) {
+					logging.L.Warningf("error deleting redis acl rule %s in sweeper: %s", rule.ID, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func testSweepRedisSetting(_ string) error {
+	return acctest.SweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+		redisAPI := redisSDK.NewAPI(scwClient)
+
+		logging.L.Debugf("sweeper: destroying the redis settings in (%s)", zone)
+
+		listClusters, err := redisAPI.ListClusters(&redisSDK.ListClustersRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing redis clusters in (%s) in sweeper: %w", zone, err)
+		}
+
+		for _, cluster := range listClusters.Clusters {
+			for _, setting := range cluster.ClusterSettings {
+				_, err := redisAPI.DeleteClusterSetting(&redisSDK.DeleteClusterSettingRequest{
+					Zone:        zone,
+					ClusterID:   cluster.ID,
+					SettingName: setting.Name,
+				})
+				if err != nil && !httperrors.Is404(err) {
+					logging.L.Warningf("error deleting redis setting %s of cluster %s in sweeper: %s", setting.Name, cluster.ID, err)
+				}
+			}
+		}
+
+		return nil
 	})
 }
 
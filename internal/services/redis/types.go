@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"slices"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/connection"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
@@ -226,3 +228,116 @@ func redisConnectionHostPort(endpoints []*redis.Endpoint) (host string, port uin
 
 	return "", 0
 }
+
+// flattenConnection returns the connection block of a cluster, unlike connection_string the private network is preferred
+func flattenConnection(endpoints []*redis.Endpoint, caCert string, tlsEnabled bool) []map[string]any {
+	connectionEndpoints := make([]connection.Endpoint, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		if endpoint == nil || len(endpoint.IPs) == 0 {
+			continue
+		}
+
+		kind := connection.KindPublicNetwork
+		if endpoint.PrivateNetwork != nil {
+			kind = connection.KindPrivateNetwork
+		}
+
+		connectionEndpoints = append(connectionEndpoints, connection.Endpoint{
+			Kind: kind,
+			Host: endpoint.IPs[0].String(),
+			Port: int(endpoint.Port),
+		})
+	}
+
+	return connection.Flatten(connection.EngineRedis, connectionEndpoints, caCert, tlsEnabled)
+}
+
+// filterManagedACLRules keeps the rules whose IP is in the configured acl set
+func filterManagedACLRules(managed *schema.Set, aclRules []*redis.ACLRule) []*redis.ACLRule {
+	ips := make(map[string]bool, managed.Len())
+	for _, rawRule := range managed.List() {
+		ips[normalizeCIDR(rawRule.(map[string]any)["ip"].(string))] = true
+	}
+
+	filtered := []*redis.ACLRule(nil)
+
+	for _, aclRule := range aclRules {
+		if ips[aclRule.IPCidr.String()] {
+			filtered = append(filtered, aclRule)
+		}
+	}
+
+	return filtered
+}
+
+// filterManagedSettings keeps the settings that are in the configured settings map
+func filterManagedSettings(managed map[string]any, settings []*redis.ClusterSetting) []*redis.ClusterSetting {
+	filtered := []*redis.ClusterSetting(nil)
+
+	for _, setting := range settings {
+		if _, ok := managed[setting.Name]; ok {
+			filtered = append(filtered, setting)
+		}
+	}
+
+	return filtered
+}
+
+// diffACLRules returns the rules to add and the IDs of the rules to delete to go from oldRules to newRules.
+// Rules are matched on their IP and their description when it is set.
+func diffACLRules(oldRules, newRules *schema.Set) ([]any, []string) {
+	matches := func(a, b map[string]any) bool {
+		if normalizeCIDR(a["ip"].(string)) != normalizeCIDR(b["ip"].(string)) {
+			return false
+		}
+
+		aDescription, _ := a["description"].(string)
+		bDescription, _ := b["description"].(string)
+
+		return aDescription == "" || bDescription == "" || aDescription == bDescription
+	}
+
+	added := []any(nil)
+
+	for _, newRule := range newRules.List() {
+		if !slices.ContainsFunc(oldRules.List(), func(oldRule any) bool { return matches(oldRule.(map[string]any), newRule.(map[string]any)) }) {
+			added = append(added, newRule)
+		}
+	}
+
+	removed := []string(nil)
+
+	for _, oldRule := range oldRules.List() {
+		if !slices.ContainsFunc(newRules.List(), func(newRule any) bool { return matches(oldRule.(map[string]any), newRule.(map[string]any)) }) {
+			if id, _ := oldRule.(map[string]any)["id"].(string); id != "" {
+				removed = append(removed, id)
+			}
+		}
+	}
+
+	return added, removed
+}
+
+// changedSettings returns the settings of newSettings that are not set to the same value in oldSettings
+func changedSettings(oldSettings, newSettings map[string]any) map[string]any {
+	changed := make(map[string]any, len(newSettings))
+
+	for name, value := range newSettings {
+		if oldSettings[name] != value {
+			changed[name] = value
+		}
+	}
+
+	return changed
+}
+
+// normalizeCIDR returns the canonical form of a CIDR, "10.0.0.1/24" becomes "10.0.0.0/24"
+func normalizeCIDR(cidr string) string {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return cidr
+	}
+
+	return ipNet.String()
+}
@@ -116,23 +116,12 @@ func ResourceVPCACLCreate(ctx context.Context, d *schema.ResourceData, m any) di
 		return diag.FromErr(err)
 	}
 
-	req := &vpc.SetACLRequest{
-		VpcID:         locality.ExpandID(d.Get("vpc_id").(string)),
-		IsIPv6:        d.Get("is_ipv6").(bool),
-		DefaultPolicy: vpc.Action(d.Get("default_policy").(string)),
-		Region:        region,
-	}
-
 	expandedRules, err := expandACLRules(d.Get("rules"))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if d.Get("rules") != nil {
-		req.Rules = expandedRules
-	}
-
-	_, err = vpcAPI.SetACL(req, scw.WithContext(ctx))
+	err = setACLRules(ctx, vpcAPI, region, locality.ExpandID(d.Get("vpc_id").(string)), d.Get("is_ipv6").(bool), vpc.Action(d.Get("default_policy").(string)), expandedRules)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -189,23 +178,12 @@ func ResourceVPCACLUpdate(ctx context.Context, d *schema.ResourceData, m any) di
 		return diag.FromErr(err)
 	}
 
-	req := &vpc.SetACLRequest{
-		VpcID:         locality.ExpandID(ID),
-		IsIPv6:        d.Get("is_ipv6").(bool),
-		DefaultPolicy: vpc.Action(d.Get("default_policy").(string)),
-		Region:        region,
-	}
-
 	expandedRules, err := expandACLRules(d.Get("rules"))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if d.Get("rules") != nil {
-		req.Rules = expandedRules
-	}
-
-	_, err = vpcAPI.SetACL(req, scw.WithContext(ctx))
+	err = setACLRules(ctx, vpcAPI, region, locality.ExpandID(ID), d.Get("is_ipv6").(bool), vpc.Action(d.Get("default_policy").(string)), expandedRules)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -219,11 +197,7 @@ func ResourceVPCACLDelete(ctx context.Context, d *schema.ResourceData, m any) di
 		return diag.FromErr(err)
 	}
 
-	_, err = vpcAPI.SetACL(&vpc.SetACLRequest{
-		VpcID:         locality.ExpandID(ID),
-		Region:        region,
-		DefaultPolicy: vpc.ActionAccept,
-	}, scw.WithContext(ctx))
+	err = setACLRules(ctx, vpcAPI, region, locality.ExpandID(ID), d.Get("is_ipv6").(bool), vpc.ActionAccept, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,244 @@
+package vpc
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/datasource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/acl_evaluate_data_source.md
+var aclEvaluateDataSourceDescription string
+
+func DataSourceACLEvaluate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceACLEvaluateRead,
+		Description: aclEvaluateDataSourceDescription,
+		SchemaFunc:  aclEvaluateSchema,
+	}
+}
+
+func aclEvaluateSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vpc_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			Description:      "The ID of the VPC whose ACL evaluates the packet",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+		},
+		"source": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPAddress,
+			Description:  "The source IP address of the packet, its version selects the IPv4 or IPv6 ACL",
+		},
+		"destination": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsIPAddress,
+			Description:  "The destination IP address of the packet",
+		},
+		"protocol": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				vpc.ACLRuleProtocolTCP.String(),
+				vpc.ACLRuleProtocolUDP.String(),
+				vpc.ACLRuleProtocolICMP.String(),
+			}, false),
+			Description: "The protocol of the packet (TCP, UDP or ICMP)",
+		},
+		"src_port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "The source port of the packet, only matched by the rules without source port range when unset",
+		},
+		"dst_port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumber,
+			Description:  "The destination port of the packet, only matched by the rules without destination port range when unset",
+		},
+		"region": regional.Schema(),
+		// Computed elements
+		"is_ipv6": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the packet was evaluated against the IPv6 ACL",
+		},
+		"action": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The action applied to the packet, the default policy of the ACL when no rule matches",
+		},
+		"is_default_policy": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether no rule matched the packet and the default policy applies",
+		},
+		"position": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The position of the matching rule in the ACL, -1 when the default policy applies",
+		},
+		"rule": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The matching rule, empty when the default policy applies",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"protocol": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The protocol to which the rule applies",
+					},
+					"source": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Source IP range to which the rule applies",
+					},
+					"src_port_low": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Starting port of the source port range to which the rule applies",
+					},
+					"src_port_high": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Ending port of the source port range to which the rule applies",
+					},
+					"destination": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Destination IP range to which the rule applies",
+					},
+					"dst_port_low": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Starting port of the destination port range to which the rule applies",
+					},
+					"dst_port_high": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Ending port of the destination port range to which the rule applies",
+					},
+					"action": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The policy of the rule",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The rule description",
+					},
+					"scope": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The scope of the rule, client for the rules set by the users",
+					},
+					"priority": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The priority of the rule when it is managed by scaleway_vpc_acl_rule, -1 otherwise",
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceACLEvaluateRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	vpcAPI, region, err := vpcAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcID := locality.ExpandID(d.Get("vpc_id").(string))
+	packet := aclPacket{
+		Protocol:    vpc.ACLRuleProtocol(d.Get("protocol").(string)),
+		Source:      net.ParseIP(d.Get("source").(string)),
+		Destination: net.ParseIP(d.Get("destination").(string)),
+		SrcPort:     uint32(d.Get("src_port").(int)),
+		DstPort:     uint32(d.Get("dst_port").(int)),
+	}
+
+	isIPv6 := packet.Source.To4() == nil
+	if isIPv6 != (packet.Destination.To4() == nil) {
+		return diag.FromErr(fmt.Errorf("source %s and destination %s must be both IPv4 or both IPv6 addresses", packet.Source, packet.Destination))
+	}
+
+	acl, err := getACL(ctx, vpcAPI, region, vpcID, isIPv6)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	position, action := evaluateACL(acl.Rules, acl.DefaultPolicy, packet)
+
+	matchedRule, err := flattenEvaluatedACLRule(acl.Rules, position)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(datasource.NewRegionalID(d.Get("vpc_id"), region))
+
+	_ = d.Set("vpc_id", regional.NewIDString(region, vpcID))
+	_ = d.Set("is_ipv6", isIPv6)
+	_ = d.Set("action", action.String())
+	_ = d.Set("is_default_policy", position < 0)
+	_ = d.Set("position", position)
+	_ = d.Set("rule", matchedRule)
+	_ = d.Set("region", region.String())
+
+	return nil
+}
+
+// flattenEvaluatedACLRule flattens the rule matched by evaluateACL, with its scope and priority
+func flattenEvaluatedACLRule(rules []*vpc.ACLRule, position int) ([]map[string]any, error) {
+	if position < 0 {
+		return []map[string]any{}, nil
+	}
+
+	rule := rules[position]
+	scope, _ := aclRuleScope(rule)
+
+	priority, description, ok := aclRulePriority(rule)
+	if !ok {
+		priority = -1
+	}
+
+	source, err := types.FlattenIPNet(rule.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err := types.FlattenIPNet(rule.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	return []map[string]any{{
+		"protocol":      rule.Protocol.String(),
+		"source":        source,
+		"src_port_low":  int(rule.SrcPortLow),
+		"src_port_high": int(rule.SrcPortHigh),
+		"destination":   destination,
+		"dst_port_low":  int(rule.DstPortLow),
+		"dst_port_high": int(rule.DstPortHigh),
+		"action":        rule.Action.String(),
+		"description":   description,
+		"scope":         scope,
+		"priority":      priority,
+	}}, nil
+}
@@ -0,0 +1,66 @@
+package vpc_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+)
+
+func TestAccDataSourceACLEvaluate_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isACLRuleDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_vpc" "vpc01" {
+					  name = "tf-vpc-acl-evaluate"
+					}
+
+					resource "scaleway_vpc_acl_rule" "ssh" {
+					  vpc_id        = scaleway_vpc.vpc01.id
+					  priority      = 10
+					  protocol      = "TCP"
+					  source        = "0.0.0.0/0"
+					  destination   = "0.0.0.0/0"
+					  dst_port_low  = 22
+					  dst_port_high = 22
+					  action        = "drop"
+					  description   = "Deny SSH traffic"
+					}
+
+					data "scaleway_vpc_acl_evaluate" "ssh" {
+					  vpc_id      = scaleway_vpc_acl_rule.ssh.vpc_id
+					  source      = "203.0.113.10"
+					  destination = "172.16.0.5"
+					  protocol    = "TCP"
+					  dst_port    = 22
+					}
+
+					data "scaleway_vpc_acl_evaluate" "https" {
+					  vpc_id      = scaleway_vpc_acl_rule.ssh.vpc_id
+					  source      = "203.0.113.10"
+					  destination = "172.16.0.5"
+					  protocol    = "TCP"
+					  dst_port    = 443
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.ssh", "action", "drop"),
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.ssh", "is_default_policy", "false"),
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.ssh", "position", "0"),
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.ssh", "rule.0.description", "Deny SSH traffic"),
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.ssh", "rule.0.priority", "10"),
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.https", "action", "accept"),
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.https", "is_default_policy", "true"),
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.https", "position", "-1"),
+					resource.TestCheckResourceAttr("data.scaleway_vpc_acl_evaluate.https", "rule.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,277 @@
+package vpc
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/acl_rule_resource.md
+var aclRuleResourceDescription string
+
+func ResourceACLRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceVPCACLRuleCreate,
+		ReadContext:   ResourceVPCACLRuleRead,
+		UpdateContext: ResourceVPCACLRuleUpdate,
+		DeleteContext: ResourceVPCACLRuleDelete,
+		Description:   aclRuleResourceDescription,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		SchemaVersion: 0,
+		SchemaFunc:    aclRuleSchema,
+		Identity:      identity.DefaultRegional(),
+	}
+}
+
+func aclRuleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vpc_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "The ID of the VPC the ACL rule belongs to",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+		},
+		"is_ipv6": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+			Description: "Whether the rule belongs to the IPv6 ACL of the VPC (false = IPv4)",
+		},
+		"priority": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The priority of the rule, the rules are evaluated by ascending priority and the first matching rule applies",
+		},
+		"protocol": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          vpc.ACLRuleProtocolANY.String(),
+			Description:      "The protocol to which this rule applies. Default value: ANY",
+			ValidateDiagFunc: verify.ValidateEnum[vpc.ACLRuleProtocol](),
+		},
+		"source": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsCIDR,
+			Description:  "Source IP range to which this rule applies (CIDR notation with subnet mask)",
+		},
+		"src_port_low": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumberOrZero,
+			Description:  "Starting port of the source port range to which this rule applies (inclusive)",
+		},
+		"src_port_high": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumberOrZero,
+			Description:  "Ending port of the source port range to which this rule applies (inclusive)",
+		},
+		"destination": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsCIDR,
+			Description:  "Destination IP range to which this rule applies (CIDR notation with subnet mask)",
+		},
+		"dst_port_low": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumberOrZero,
+			Description:  "Starting port of the destination port range to which this rule applies (inclusive)",
+		},
+		"dst_port_high": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IsPortNumberOrZero,
+			Description:  "Ending port of the destination port range to which this rule applies (inclusive)",
+		},
+		"action": {
+			Type:             schema.TypeString,
+			Required:         true,
+			Description:      "The policy to apply to the packet",
+			ValidateDiagFunc: verify.ValidateEnum[vpc.Action](),
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The rule description",
+		},
+		"region": regional.Schema(),
+		// Computed elements
+		"position": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The position of the rule in the ACL, rules are evaluated in order",
+		},
+	}
+}
+
+// aclRuleResourceExpand reuses the rule expansion of scaleway_vpc_acl, the priority is stored in the description
+func aclRuleResourceExpand(d *schema.ResourceData) (*vpc.ACLRule, error) {
+	rules, err := expandACLRules([]any{map[string]any{
+		"protocol":      d.Get("protocol"),
+		"source":        d.Get("source"),
+		"src_port_low":  d.Get("src_port_low"),
+		"src_port_high": d.Get("src_port_high"),
+		"destination":   d.Get("destination"),
+		"dst_port_low":  d.Get("dst_port_low"),
+		"dst_port_high": d.Get("dst_port_high"),
+		"action":        d.Get("action"),
+		"description":   withACLRulePriority(d.Get("priority").(int), d.Get("description").(string)),
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules[0], nil
+}
+
+func ResourceVPCACLRuleCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	vpcAPI, region, err := vpcAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcID := locality.ExpandID(d.Get("vpc_id").(string))
+	isIPv6 := d.Get("is_ipv6").(bool)
+	priority := d.Get("priority").(int)
+
+	rule, err := aclRuleResourceExpand(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = setACLRule(ctx, vpcAPI, region, vpcID, isIPv6, priority, rule, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetRegionalIdentity(d, region, fmt.Sprintf("%s/%s/%d", vpcID, aclRuleIPFamily(isIPv6), priority))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceVPCACLRuleRead(ctx, d, m)
+}
+
+func ResourceVPCACLRuleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	region, vpcID, isIPv6, priority, err := parseACLRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcAPI, err := NewAPI(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	acl, err := vpcAPI.GetACL(&vpc.GetACLRequest{
+		VpcID:  vpcID,
+		Region: region,
+		IsIPv6: isIPv6,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	position := findPrioritizedACLRule(acl.Rules, priority)
+	if position < 0 {
+		d.SetId("")
+
+		return nil
+	}
+
+	rule := *acl.Rules[position]
+	_, description, _ := aclRulePriority(&rule)
+	rule.Description = &description
+
+	flattenedRules, ok := flattenACLRules([]*vpc.ACLRule{&rule}).([]map[string]any)
+	if !ok || len(flattenedRules) != 1 {
+		return diag.FromErr(fmt.Errorf("failed to flatten the rule with priority %d of the ACL of VPC %s", priority, vpcID))
+	}
+
+	for key, value := range flattenedRules[0] {
+		_ = d.Set(key, value)
+	}
+
+	_ = d.Set("vpc_id", regional.NewIDString(region, vpcID))
+	_ = d.Set("is_ipv6", isIPv6)
+	_ = d.Set("priority", priority)
+	_ = d.Set("position", position)
+	_ = d.Set("region", region.String())
+
+	err = identity.SetRegionalIdentity(d, region, fmt.Sprintf("%s/%s/%d", vpcID, aclRuleIPFamily(isIPv6), priority))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func ResourceVPCACLRuleUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	region, vpcID, isIPv6, priority, err := parseACLRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcAPI, err := NewAPI(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err := aclRuleResourceExpand(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = setACLRule(ctx, vpcAPI, region, vpcID, isIPv6, priority, rule, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceVPCACLRuleRead(ctx, d, m)
+}
+
+func ResourceVPCACLRuleDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	region, vpcID, isIPv6, priority, err := parseACLRuleID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcAPI, err := NewAPI(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = setACLRule(ctx, vpcAPI, region, vpcID, isIPv6, priority, nil, false)
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
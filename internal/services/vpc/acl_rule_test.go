@@ -0,0 +1,247 @@
+package vpc_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	vpcSDK "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc"
+)
+
+func TestAccACLRule_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isACLRuleDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_vpc" "vpc01" {
+					  name = "tf-vpc-acl-rule-basic"
+					}
+
+					resource "scaleway_vpc_acl_rule" "https" {
+					  vpc_id        = scaleway_vpc.vpc01.id
+					  priority      = 20
+					  protocol      = "TCP"
+					  source        = "0.0.0.0/0"
+					  destination   = "172.16.0.0/12"
+					  dst_port_low  = 443
+					  dst_port_high = 443
+					  action        = "accept"
+					  description   = "Allow HTTPS traffic"
+					}
+
+					resource "scaleway_vpc_acl_rule" "ssh" {
+					  vpc_id        = scaleway_vpc.vpc01.id
+					  priority      = 10
+					  protocol      = "TCP"
+					  source        = "0.0.0.0/0"
+					  destination   = "0.0.0.0/0"
+					  dst_port_low  = 22
+					  dst_port_high = 22
+					  action        = "drop"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_vpc_acl_rule.https", "vpc_id", "scaleway_vpc.vpc01", "id"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.https", "is_ipv6", "false"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.https", "description", "Allow HTTPS traffic"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.https", "destination", "172.16.0.0/12"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.ssh", "description", ""),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.ssh", "action", "drop"),
+				),
+			},
+			{
+				Config: `
+					resource "scaleway_vpc" "vpc01" {
+					  name = "tf-vpc-acl-rule-basic"
+					}
+
+					resource "scaleway_vpc_acl_rule" "https" {
+					  vpc_id        = scaleway_vpc.vpc01.id
+					  priority      = 20
+					  protocol      = "TCP"
+					  source        = "0.0.0.0/0"
+					  destination   = "172.16.0.0/12"
+					  dst_port_low  = 443
+					  dst_port_high = 443
+					  action        = "drop"
+					  description   = "Deny HTTPS traffic"
+					}
+
+					resource "scaleway_vpc_acl_rule" "ssh" {
+					  vpc_id        = scaleway_vpc.vpc01.id
+					  priority      = 10
+					  protocol      = "TCP"
+					  source        = "0.0.0.0/0"
+					  destination   = "0.0.0.0/0"
+					  dst_port_low  = 22
+					  dst_port_high = 22
+					  action        = "drop"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.https", "action", "drop"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.https", "description", "Deny HTTPS traffic"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.ssh", "position", "0"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.https", "position", "1"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_vpc_acl_rule.https",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccACLRule_WithACL(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	config := `
+		resource "scaleway_vpc" "vpc01" {
+		  name = "tf-vpc-acl-rule-with-acl"
+		}
+
+		resource "scaleway_vpc_acl" "acl01" {
+		  vpc_id         = scaleway_vpc.vpc01.id
+		  is_ipv6        = false
+		  default_policy = "drop"
+
+		  rules {
+			protocol      = "TCP"
+			dst_port_low  = 80
+			dst_port_high = 80
+			source        = "0.0.0.0/0"
+			destination   = "0.0.0.0/0"
+			description   = "Allow HTTP traffic"
+			action        = "accept"
+		  }
+		}
+
+		resource "scaleway_vpc_acl_rule" "ssh" {
+		  vpc_id        = scaleway_vpc.vpc01.id
+		  priority      = 10
+		  protocol      = "TCP"
+		  source        = "10.0.0.0/8"
+		  destination   = "0.0.0.0/0"
+		  dst_port_low  = 22
+		  dst_port_high = 22
+		  action        = "accept"
+
+		  depends_on = [scaleway_vpc_acl.acl01]
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			isACLDestroyed(tt),
+			isACLRuleDestroyed(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_vpc_acl.acl01", "rules.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl_rule.ssh", "position", "1"),
+				),
+			},
+			{
+				// The rule of scaleway_vpc_acl_rule is neither read into scaleway_vpc_acl nor removed by it
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: strings.Replace(config, `description   = "Allow HTTP traffic"`, `description   = "Allow HTTP traffic from anywhere"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_vpc_acl.acl01", "rules.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_vpc_acl.acl01", "rules.0.description", "Allow HTTP traffic from anywhere"),
+					isACLRulePresent(tt, "scaleway_vpc_acl_rule.ssh"),
+				),
+			},
+		},
+	})
+}
+
+// findACLRule returns the rule of a scaleway_vpc_acl_rule resource in the ACL of its VPC, nil when it is not there
+func findACLRule(tt *acctest.TestTools, rs *terraform.ResourceState) (*vpcSDK.ACLRule, error) {
+	vpcAPI, region, vpcID, err := vpc.NewAPIWithRegionAndID(tt.Meta, rs.Primary.Attributes["vpc_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	acl, err := vpcAPI.GetACL(&vpcSDK.GetACLRequest{
+		VpcID:  vpcID,
+		Region: region,
+		IsIPv6: rs.Primary.Attributes["is_ipv6"] == "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	priority := fmt.Sprintf("(Rule priority: %s)", rs.Primary.Attributes["priority"])
+
+	for _, rule := range acl.Rules {
+		if rule.Description != nil && strings.Contains(*rule.Description, priority) {
+			return rule, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func isACLRulePresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		rule, err := findACLRule(tt, rs)
+		if err != nil {
+			return err
+		}
+
+		if rule == nil {
+			return fmt.Errorf("acl rule (%s) not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func isACLRuleDestroyed(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_vpc_acl_rule" {
+				continue
+			}
+
+			rule, err := findACLRule(tt, rs)
+			if err != nil {
+				if httperrors.Is404(err) {
+					continue
+				}
+
+				return err
+			}
+
+			if rule != nil {
+				return fmt.Errorf("acl rule (%s) still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
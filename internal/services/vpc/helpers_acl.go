@@ -0,0 +1,296 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/mutexkv"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+)
+
+const aclRuleScopeClient = "client"
+
+var (
+	// aclRuleScopeRegex matches the scope prefixed by the API to the rule descriptions, only the client rules are set by the users
+	aclRuleScopeRegex = regexp.MustCompile(`^\(Rule scope: ([^)]+)\)\s*`)
+	// aclRulePriorityRegex matches the priority prefixed by scaleway_vpc_acl_rule to the description of its rule
+	aclRulePriorityRegex = regexp.MustCompile(`^\(Rule priority: (\d+)\)\s*`)
+)
+
+// aclMutexKV serializes the updates of an ACL by the scaleway_vpc_acl and scaleway_vpc_acl_rule resources, SetACL replaces the whole rule set
+var aclMutexKV = mutexkv.NewMutexKV()
+
+// aclRuleScope returns the scope of a rule and its description without the scope, a rule without scope is a client rule
+func aclRuleScope(rule *vpc.ACLRule) (string, string) {
+	description := types.FlattenStringPtr(rule.Description).(string)
+
+	matches := aclRuleScopeRegex.FindStringSubmatch(description)
+	if matches == nil {
+		return aclRuleScopeClient, description
+	}
+
+	return strings.TrimSpace(matches[1]), aclRuleScopeRegex.ReplaceAllString(description, "")
+}
+
+// aclRulePriority returns the priority of a rule created by scaleway_vpc_acl_rule and its description without the scope and the priority
+func aclRulePriority(rule *vpc.ACLRule) (int, string, bool) {
+	_, description := aclRuleScope(rule)
+
+	matches := aclRulePriorityRegex.FindStringSubmatch(description)
+	if matches == nil {
+		return 0, description, false
+	}
+
+	priority, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, description, false
+	}
+
+	return priority, aclRulePriorityRegex.ReplaceAllString(description, ""), true
+}
+
+func withACLRulePriority(priority int, description string) string {
+	return strings.TrimSpace(fmt.Sprintf("(Rule priority: %d) %s", priority, description))
+}
+
+// clientACLRules returns the client rules of an ACL without their scope, as expected by SetACL
+func clientACLRules(rules []*vpc.ACLRule) []*vpc.ACLRule {
+	clientRules := make([]*vpc.ACLRule, 0, len(rules))
+
+	for _, rule := range rules {
+		scope, description := aclRuleScope(rule)
+		if scope != aclRuleScopeClient {
+			continue
+		}
+
+		clientRule := *rule
+		clientRule.Description = types.ExpandStringPtr(description)
+		clientRules = append(clientRules, &clientRule)
+	}
+
+	return clientRules
+}
+
+// ClientACLRulesWithoutPriority returns the client rules of an ACL without the rules of the scaleway_vpc_acl_rule resources,
+// as expected by SetACL
+func ClientACLRulesWithoutPriority(rules []*vpc.ACLRule) []*vpc.ACLRule {
+	clientRules := []*vpc.ACLRule(nil)
+
+	for _, rule := range clientACLRules(rules) {
+		if _, _, ok := aclRulePriority(rule); !ok {
+			clientRules = append(clientRules, rule)
+		}
+	}
+
+	return clientRules
+}
+
+// findPrioritizedACLRule returns the position of the rule of the given priority, -1 when the ACL has no such rule
+func findPrioritizedACLRule(rules []*vpc.ACLRule, priority int) int {
+	for i, rule := range rules {
+		if rulePriority, _, ok := aclRulePriority(rule); ok && rulePriority == priority {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// setPrioritizedACLRule replaces the rule of the given priority, a nil rule removes it.
+// The rule is placed before the first rule of a higher priority, the rules without priority keep their position.
+func setPrioritizedACLRule(rules []*vpc.ACLRule, priority int, rule *vpc.ACLRule) []*vpc.ACLRule {
+	updatedRules := make([]*vpc.ACLRule, 0, len(rules)+1)
+	inserted := rule == nil
+
+	for _, existing := range rules {
+		existingPriority, _, ok := aclRulePriority(existing)
+		if ok && existingPriority == priority {
+			continue
+		}
+
+		if ok && !inserted && existingPriority > priority {
+			updatedRules = append(updatedRules, rule)
+			inserted = true
+		}
+
+		updatedRules = append(updatedRules, existing)
+	}
+
+	if !inserted {
+		updatedRules = append(updatedRules, rule)
+	}
+
+	return updatedRules
+}
+
+// getACL returns the ACL of a VPC, a VPC without ACL accepts all the traffic
+func getACL(ctx context.Context, vpcAPI *vpc.API, region scw.Region, vpcID string, isIPv6 bool) (*vpc.GetACLResponse, error) {
+	acl, err := vpcAPI.GetACL(&vpc.GetACLRequest{
+		VpcID:  vpcID,
+		Region: region,
+		IsIPv6: isIPv6,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return &vpc.GetACLResponse{DefaultPolicy: vpc.ActionAccept}, nil
+		}
+
+		return nil, err
+	}
+
+	return acl, nil
+}
+
+func lockACL(region scw.Region, vpcID string, isIPv6 bool) func() {
+	key := fmt.Sprintf("%s/%s/%t", region, vpcID, isIPv6)
+
+	aclMutexKV.Lock(key)
+
+	return func() {
+		aclMutexKV.Unlock(key)
+	}
+}
+
+// setACLRule replaces the rule of the given priority in the ACL of a VPC, a nil rule removes it.
+// The default policy and the other rules of the ACL are kept, mustBeNew rejects a priority already in use.
+func setACLRule(ctx context.Context, vpcAPI *vpc.API, region scw.Region, vpcID string, isIPv6 bool, priority int, rule *vpc.ACLRule, mustBeNew bool) error {
+	unlock := lockACL(region, vpcID, isIPv6)
+	defer unlock()
+
+	acl, err := getACL(ctx, vpcAPI, region, vpcID, isIPv6)
+	if err != nil {
+		return err
+	}
+
+	if mustBeNew && findPrioritizedACLRule(acl.Rules, priority) >= 0 {
+		return fmt.Errorf("the ACL of VPC %s already has a rule with priority %d, import it or use another priority", vpcID, priority)
+	}
+
+	_, err = vpcAPI.SetACL(&vpc.SetACLRequest{
+		VpcID:         vpcID,
+		Region:        region,
+		IsIPv6:        isIPv6,
+		DefaultPolicy: acl.DefaultPolicy,
+		Rules:         setPrioritizedACLRule(clientACLRules(acl.Rules), priority, rule),
+	}, scw.WithContext(ctx))
+
+	return err
+}
+
+// setACLRules replaces the default policy and the rules without priority of the ACL of a VPC, as managed by scaleway_vpc_acl.
+// The rules of the scaleway_vpc_acl_rule resources are kept after them.
+func setACLRules(ctx context.Context, vpcAPI *vpc.API, region scw.Region, vpcID string, isIPv6 bool, defaultPolicy vpc.Action, rules []*vpc.ACLRule) error {
+	unlock := lockACL(region, vpcID, isIPv6)
+	defer unlock()
+
+	acl, err := getACL(ctx, vpcAPI, region, vpcID, isIPv6)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range clientACLRules(acl.Rules) {
+		if priority, _, ok := aclRulePriority(rule); ok {
+			rules = setPrioritizedACLRule(rules, priority, rule)
+		}
+	}
+
+	_, err = vpcAPI.SetACL(&vpc.SetACLRequest{
+		VpcID:         vpcID,
+		Region:        region,
+		IsIPv6:        isIPv6,
+		DefaultPolicy: defaultPolicy,
+		Rules:         rules,
+	}, scw.WithContext(ctx))
+
+	return err
+}
+
+func aclRuleIPFamily(isIPv6 bool) string {
+	if isIPv6 {
+		return "ipv6"
+	}
+
+	return "ipv4"
+}
+
+// parseACLRuleID parses a {region}/{vpc_id}/{ipv4|ipv6}/{priority} ID of scaleway_vpc_acl_rule
+func parseACLRuleID(id string) (scw.Region, string, bool, int, error) {
+	region, vpcID, ruleID, err := regional.ParseNestedID(id)
+	if err != nil {
+		return "", "", false, 0, err
+	}
+
+	family, rawPriority, ok := strings.Cut(ruleID, "/")
+	if !ok || (family != "ipv4" && family != "ipv6") {
+		return "", "", false, 0, fmt.Errorf("invalid ACL rule ID %q, expected {region}/{vpc_id}/{ipv4|ipv6}/{priority}", id)
+	}
+
+	priority, err := strconv.Atoi(rawPriority)
+	if err != nil {
+		return "", "", false, 0, fmt.Errorf("invalid ACL rule ID %q: the priority must be a number", id)
+	}
+
+	return region, vpcID, family == "ipv6", priority, nil
+}
+
+// aclPacket is a packet evaluated against the rules of an ACL, ICMP packets have no ports
+type aclPacket struct {
+	Protocol    vpc.ACLRuleProtocol
+	Source      net.IP
+	Destination net.IP
+	SrcPort     uint32
+	DstPort     uint32
+}
+
+// evaluateACL returns the position of the first rule matching the packet and the action to take.
+// The rules are evaluated in order, the default policy applies with a -1 position when no rule matches.
+func evaluateACL(rules []*vpc.ACLRule, defaultPolicy vpc.Action, packet aclPacket) (int, vpc.Action) {
+	for i, rule := range rules {
+		if aclRuleMatches(rule, packet) {
+			return i, rule.Action
+		}
+	}
+
+	return -1, defaultPolicy
+}
+
+func aclRuleMatches(rule *vpc.ACLRule, packet aclPacket) bool {
+	if rule.Protocol != vpc.ACLRuleProtocolANY && rule.Protocol != packet.Protocol {
+		return false
+	}
+
+	return ipNetContains(rule.Source, packet.Source) &&
+		ipNetContains(rule.Destination, packet.Destination) &&
+		portInRange(rule.SrcPortLow, rule.SrcPortHigh, packet.SrcPort) &&
+		portInRange(rule.DstPortLow, rule.DstPortHigh, packet.DstPort)
+}
+
+// ipNetContains reports whether a range contains an address, an unset range contains any address
+func ipNetContains(ipNet scw.IPNet, ip net.IP) bool {
+	if ipNet.IP == nil {
+		return true
+	}
+
+	return ipNet.Contains(ip)
+}
+
+// portInRange reports whether a port is in an inclusive range, a 0-0 range matches any port and a 0 high bound a single port
+func portInRange(low, high, port uint32) bool {
+	if low == 0 && high == 0 {
+		return true
+	}
+
+	if high == 0 {
+		high = low
+	}
+
+	return port != 0 && port >= low && port <= high
+}
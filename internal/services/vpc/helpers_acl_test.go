@@ -0,0 +1,186 @@
+//nolint:testpackage // Tests need access to unexported ACL helpers.
+package vpc
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func testACLRule(t *testing.T, protocol vpc.ACLRuleProtocol, source, destination string, dstPortLow, dstPortHigh uint32, action vpc.Action, description string) *vpc.ACLRule {
+	t.Helper()
+
+	_, sourceNet, err := net.ParseCIDR(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, destinationNet, err := net.ParseCIDR(destination)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &vpc.ACLRule{
+		Protocol:    protocol,
+		Source:      scw.IPNet{IPNet: *sourceNet},
+		Destination: scw.IPNet{IPNet: *destinationNet},
+		DstPortLow:  dstPortLow,
+		DstPortHigh: dstPortHigh,
+		Action:      action,
+		Description: &description,
+	}
+}
+
+func TestEvaluateACL(t *testing.T) {
+	t.Parallel()
+
+	rules := []*vpc.ACLRule{
+		testACLRule(t, vpc.ACLRuleProtocolTCP, "0.0.0.0/0", "10.0.0.0/24", 22, 22, vpc.ActionDrop, "(Rule scope: client) (Rule priority: 10) no ssh"),
+		testACLRule(t, vpc.ACLRuleProtocolTCP, "192.168.0.0/16", "10.0.0.0/8", 1024, 65535, vpc.ActionAccept, "(Rule scope: client) high ports"),
+		testACLRule(t, vpc.ACLRuleProtocolANY, "172.16.0.0/12", "0.0.0.0/0", 0, 0, vpc.ActionAccept, "(Rule scope: system) internal"),
+	}
+
+	tests := []struct {
+		name         string
+		packet       aclPacket
+		wantPosition int
+		wantAction   vpc.Action
+	}{
+		{
+			name:         "first matching rule",
+			packet:       aclPacket{Protocol: vpc.ACLRuleProtocolTCP, Source: net.ParseIP("192.168.1.1"), Destination: net.ParseIP("10.0.0.5"), DstPort: 22},
+			wantPosition: 0,
+			wantAction:   vpc.ActionDrop,
+		},
+		{
+			name:         "port range",
+			packet:       aclPacket{Protocol: vpc.ACLRuleProtocolTCP, Source: net.ParseIP("192.168.1.1"), Destination: net.ParseIP("10.1.0.5"), DstPort: 8080},
+			wantPosition: 1,
+			wantAction:   vpc.ActionAccept,
+		},
+		{
+			name:         "port out of range",
+			packet:       aclPacket{Protocol: vpc.ACLRuleProtocolTCP, Source: net.ParseIP("192.168.1.1"), Destination: net.ParseIP("10.1.0.5"), DstPort: 80},
+			wantPosition: -1,
+			wantAction:   vpc.ActionDrop,
+		},
+		{
+			name:         "port not set",
+			packet:       aclPacket{Protocol: vpc.ACLRuleProtocolTCP, Source: net.ParseIP("192.168.1.1"), Destination: net.ParseIP("10.1.0.5")},
+			wantPosition: -1,
+			wantAction:   vpc.ActionDrop,
+		},
+		{
+			name:         "any protocol",
+			packet:       aclPacket{Protocol: vpc.ACLRuleProtocolICMP, Source: net.ParseIP("172.16.3.4"), Destination: net.ParseIP("10.0.0.5")},
+			wantPosition: 2,
+			wantAction:   vpc.ActionAccept,
+		},
+		{
+			name:         "protocol mismatch",
+			packet:       aclPacket{Protocol: vpc.ACLRuleProtocolUDP, Source: net.ParseIP("192.168.1.1"), Destination: net.ParseIP("10.0.0.5"), DstPort: 22},
+			wantPosition: -1,
+			wantAction:   vpc.ActionDrop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			position, action := evaluateACL(rules, vpc.ActionDrop, tt.packet)
+			if position != tt.wantPosition || action != tt.wantAction {
+				t.Fatalf("got rule %d with action %s, want rule %d with action %s", position, action, tt.wantPosition, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestSetPrioritizedACLRule(t *testing.T) {
+	t.Parallel()
+
+	rule := func(description string) *vpc.ACLRule {
+		return testACLRule(t, vpc.ACLRuleProtocolANY, "0.0.0.0/0", "0.0.0.0/0", 0, 0, vpc.ActionAccept, description)
+	}
+
+	summary := func(rules []*vpc.ACLRule) string {
+		descriptions := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			descriptions = append(descriptions, *rule.Description)
+		}
+
+		return strings.Join(descriptions, ", ")
+	}
+
+	rules := clientACLRules([]*vpc.ACLRule{
+		rule("(Rule scope: client) unmanaged"),
+		rule("(Rule scope: client) (Rule priority: 10) ten"),
+		rule("(Rule scope: system) system"),
+		rule("(Rule scope: client) (Rule priority: 30) thirty"),
+	})
+
+	if got, want := summary(rules), "unmanaged, (Rule priority: 10) ten, (Rule priority: 30) thirty"; got != want {
+		t.Fatalf("client rules: got %q, want %q", got, want)
+	}
+
+	rules = setPrioritizedACLRule(rules, 20, rule(withACLRulePriority(20, "twenty")))
+	if got, want := summary(rules), "unmanaged, (Rule priority: 10) ten, (Rule priority: 20) twenty, (Rule priority: 30) thirty"; got != want {
+		t.Fatalf("insert: got %q, want %q", got, want)
+	}
+
+	rules = setPrioritizedACLRule(rules, 40, rule(withACLRulePriority(40, "")))
+	if got, want := summary(rules), "unmanaged, (Rule priority: 10) ten, (Rule priority: 20) twenty, (Rule priority: 30) thirty, (Rule priority: 40)"; got != want {
+		t.Fatalf("append: got %q, want %q", got, want)
+	}
+
+	rules = setPrioritizedACLRule(rules, 10, rule(withACLRulePriority(10, "ten updated")))
+	if got, want := summary(rules), "unmanaged, (Rule priority: 10) ten updated, (Rule priority: 20) twenty, (Rule priority: 30) thirty, (Rule priority: 40)"; got != want {
+		t.Fatalf("update: got %q, want %q", got, want)
+	}
+
+	rules = setPrioritizedACLRule(rules, 20, nil)
+	if got, want := summary(rules), "unmanaged, (Rule priority: 10) ten updated, (Rule priority: 30) thirty, (Rule priority: 40)"; got != want {
+		t.Fatalf("remove: got %q, want %q", got, want)
+	}
+
+	if position := findPrioritizedACLRule(rules, 30); position != 2 {
+		t.Fatalf("position of priority 30 = %d, want 2", position)
+	}
+}
+
+func TestFlattenACLRulesSkipsPrioritizedRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []*vpc.ACLRule{
+		testACLRule(t, vpc.ACLRuleProtocolTCP, "0.0.0.0/0", "10.0.0.0/24", 22, 22, vpc.ActionDrop, "(Rule scope: client) (Rule priority: 10) no ssh"),
+		testACLRule(t, vpc.ACLRuleProtocolTCP, "192.168.0.0/16", "10.0.0.0/8", 443, 443, vpc.ActionAccept, "(Rule scope: client) https"),
+		testACLRule(t, vpc.ACLRuleProtocolANY, "172.16.0.0/12", "0.0.0.0/0", 0, 0, vpc.ActionAccept, "(Rule scope: system) internal"),
+	}
+
+	flattenedRules := flattenACLRules(rules).([]map[string]any)
+	if len(flattenedRules) != 1 || flattenedRules[0]["description"] != "https" {
+		t.Fatalf("flattened rules = %v, want the https rule only", flattenedRules)
+	}
+}
+
+func TestParseACLRuleID(t *testing.T) {
+	t.Parallel()
+
+	region, vpcID, isIPv6, priority, err := parseACLRuleID("fr-par/11111111-1111-1111-1111-111111111111/ipv6/100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if region != scw.RegionFrPar || vpcID != "11111111-1111-1111-1111-111111111111" || !isIPv6 || priority != 100 {
+		t.Fatalf("unexpected parsed ID: %s %s %t %d", region, vpcID, isIPv6, priority)
+	}
+
+	for _, id := range []string{"fr-par/11111111-1111-1111-1111-111111111111", "fr-par/11111111-1111-1111-1111-111111111111/ip/100", "fr-par/11111111-1111-1111-1111-111111111111/ipv4/first"} {
+		if _, _, _, _, err := parseACLRuleID(id); err == nil {
+			t.Errorf("%q: expected an error", id)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package vpc
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/mutexkv"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/subnet_allocation_resource.md
+var subnetAllocationResourceDescription string
+
+// SubnetAllocationTagPrefix tags a VPC with a subnet allocated in it, so that the allocation is seen by every Terraform run
+const SubnetAllocationTagPrefix = "subnet-allocation="
+
+// vpcTagsMutexKV serializes the updates of the tags of a VPC by the scaleway_vpc and scaleway_vpc_subnet_allocation resources
+var vpcTagsMutexKV = mutexkv.NewMutexKV()
+
+func ResourceSubnetAllocation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceVPCSubnetAllocationCreate,
+		ReadContext:   ResourceVPCSubnetAllocationRead,
+		DeleteContext: ResourceVPCSubnetAllocationDelete,
+		Description:   subnetAllocationResourceDescription,
+		SchemaVersion: 0,
+		SchemaFunc:    subnetAllocationSchema,
+		Importer:      identity.DefaultRegionalImporter(),
+		Identity:      identity.DefaultRegional(),
+	}
+}
+
+func subnetAllocationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"vpc_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "The ID of the VPC in which to allocate the subnet",
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+		},
+		"supernet": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsCIDR,
+			Description:  "The IP range in which to allocate the subnet (CIDR notation with subnet mask)",
+		},
+		"prefix_length": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IntBetween(1, 128),
+			Description:  "The prefix length of the subnet to allocate, e.g. 24 for a /24",
+		},
+		"exclude": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "IP ranges of the supernet which must not be allocated, e.g. used outside of the VPC",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsCIDR,
+			},
+		},
+		"region": regional.Schema(),
+		// Computed elements
+		"subnet": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The allocated subnet (CIDR notation with subnet mask)",
+		},
+		"address": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The network address of the allocated subnet",
+		},
+		"last_address": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The last address of the allocated subnet",
+		},
+		"private_network_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the private network using the allocated subnet, empty until a private network uses it",
+		},
+	}
+}
+
+// SubnetAllocationTag returns the tag recording a subnet allocated in a VPC
+func SubnetAllocationTag(subnet netip.Prefix) string {
+	return SubnetAllocationTagPrefix + subnet.String()
+}
+
+// SplitSubnetAllocationTags separates the tags set by the user from the subnets allocated in a VPC
+func SplitSubnetAllocationTags(tags []string) ([]string, []netip.Prefix) {
+	userTags := make([]string, 0, len(tags))
+	subnets := []netip.Prefix(nil)
+
+	for _, tag := range tags {
+		if rawSubnet, ok := strings.CutPrefix(tag, SubnetAllocationTagPrefix); ok {
+			subnet, err := netip.ParsePrefix(rawSubnet)
+			if err == nil {
+				subnets = append(subnets, subnet)
+
+				continue
+			}
+		}
+
+		userTags = append(userTags, tag)
+	}
+
+	return userTags, subnets
+}
+
+// listVPCSubnets returns the subnets of the private networks of a VPC, by private network ID
+func listVPCSubnets(ctx context.Context, vpcAPI *vpc.API, region scw.Region, vpcID string) (map[string][]netip.Prefix, error) {
+	res, err := vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Region: region,
+		VpcID:  &vpcID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	subnets := make(map[string][]netip.Prefix, len(res.PrivateNetworks))
+
+	for _, pn := range res.PrivateNetworks {
+		for _, subnet := range pn.Subnets {
+			prefix, err := types.IPNetToPrefix(subnet.Subnet)
+			if err != nil {
+				return nil, err
+			}
+
+			subnets[pn.ID] = append(subnets[pn.ID], prefix)
+		}
+	}
+
+	return subnets, nil
+}
+
+func ResourceVPCSubnetAllocationCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	vpcAPI, region, err := vpcAPIWithRegion(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcID := locality.ExpandID(d.Get("vpc_id").(string))
+
+	supernet, err := netip.ParsePrefix(d.Get("supernet").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	used := []netip.Prefix(nil)
+
+	for _, raw := range d.Get("exclude").([]any) {
+		prefix, err := netip.ParsePrefix(raw.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		used = append(used, prefix)
+	}
+
+	lockKey := regional.NewIDString(region, vpcID)
+
+	vpcTagsMutexKV.Lock(lockKey)
+	defer vpcTagsMutexKV.Unlock(lockKey)
+
+	res, err := vpcAPI.GetVPC(&vpc.GetVPCRequest{
+		Region: region,
+		VpcID:  vpcID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, allocated := SplitSubnetAllocationTags(res.Tags)
+	used = append(used, allocated...)
+
+	subnets, err := listVPCSubnets(ctx, vpcAPI, region, vpcID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, prefixes := range subnets {
+		used = append(used, prefixes...)
+	}
+
+	subnet, err := types.NextFreePrefix(supernet.Masked(), d.Get("prefix_length").(int), used)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("VPC %s: %w", vpcID, err))
+	}
+
+	_, err = vpcAPI.UpdateVPC(&vpc.UpdateVPCRequest{
+		Region: region,
+		VpcID:  vpcID,
+		Tags:   new(append(res.Tags, SubnetAllocationTag(subnet))),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetRegionalIdentity(d, region, fmt.Sprintf("%s/%s", vpcID, subnet))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceVPCSubnetAllocationSetState(d, region, vpcID, subnet, subnets)
+}
+
+func ResourceVPCSubnetAllocationRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	region, vpcID, rawSubnet, err := regional.ParseNestedID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subnet, err := netip.ParsePrefix(rawSubnet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcAPI, err := NewAPI(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := vpcAPI.GetVPC(&vpc.GetVPCRequest{
+		Region: region,
+		VpcID:  vpcID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	// The allocation is gone when its tag was removed from the VPC
+	_, allocated := SplitSubnetAllocationTags(res.Tags)
+	if !slices.Contains(allocated, subnet) {
+		d.SetId("")
+
+		return nil
+	}
+
+	subnets, err := listVPCSubnets(ctx, vpcAPI, region, vpcID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceVPCSubnetAllocationSetState(d, region, vpcID, subnet, subnets)
+}
+func resourceVPCSubnetAllocationSetState(d *schema.ResourceData, region scw.Region, vpcID string, subnet netip.Prefix, subnets map[string][]netip.Prefix) diag.Diagnostics {
+	privateNetworkID := ""
+
+	for pnID, prefixes := range subnets {
+		if slices.Contains(prefixes, subnet) {
+			privateNetworkID = regional.NewIDString(region, pnID)
+
+			break
+		}
+	}
+
+	_ = d.Set("vpc_id", regional.NewIDString(region, vpcID))
+	_ = d.Set("subnet", subnet.String())
+	_ = d.Set("address", subnet.Addr().String())
+	_ = d.Set("last_address", types.LastAddr(subnet).String())
+	_ = d.Set("private_network_id", privateNetworkID)
+	_ = d.Set("region", region.String())
+
+	err := identity.SetRegionalIdentity(d, region, fmt.Sprintf("%s/%s", vpcID, subnet))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func ResourceVPCSubnetAllocationDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	region, vpcID, rawSubnet, err := regional.ParseNestedID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subnet, err := netip.ParsePrefix(rawSubnet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcAPI, err := NewAPI(m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lockKey := regional.NewIDString(region, vpcID)
+
+	vpcTagsMutexKV.Lock(lockKey)
+	defer vpcTagsMutexKV.Unlock(lockKey)
+
+	res, err := vpcAPI.GetVPC(&vpc.GetVPCRequest{
+		Region: region,
+		VpcID:  vpcID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	tags := slices.DeleteFunc(slices.Clone(res.Tags), func(tag string) bool {
+		return tag == SubnetAllocationTag(subnet)
+	})
+	if len(tags) == len(res.Tags) {
+		return nil
+	}
+
+	_, err = vpcAPI.UpdateVPC(&vpc.UpdateVPCRequest{
+		Region: region,
+		VpcID:  vpcID,
+		Tags:   &tags,
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package vpc_test
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	vpcSDK "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/regional"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSubnetAllocationTags(t *testing.T) {
+	subnet := netip.MustParsePrefix("172.16.2.0/24")
+
+	userTags, subnets := vpc.SplitSubnetAllocationTags([]string{"prod", vpc.SubnetAllocationTag(subnet), "subnet-allocation=invalid"})
+	assert.Equal(t, []string{"prod", "subnet-allocation=invalid"}, userTags)
+	assert.Equal(t, []netip.Prefix{subnet}, subnets)
+
+	userTags, subnets = vpc.SplitSubnetAllocationTags([]string{"prod"})
+	assert.Equal(t, []string{"prod"}, userTags)
+	assert.Empty(t, subnets)
+}
+
+func TestAccSubnetAllocation_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	config := `
+		resource "scaleway_vpc" "vpc01" {
+		  name = "tf-vpc-subnet-allocation"
+		  tags = ["terraform-test", "subnet-allocation"]
+		}
+
+		resource "scaleway_vpc_subnet_allocation" "app" {
+		  vpc_id        = scaleway_vpc.vpc01.id
+		  supernet      = "172.16.0.0/16"
+		  prefix_length = 24
+		  exclude       = ["172.16.0.0/24"]
+		}
+
+		resource "scaleway_vpc_subnet_allocation" "db" {
+		  vpc_id        = scaleway_vpc.vpc01.id
+		  supernet      = "172.16.0.0/16"
+		  prefix_length = 24
+		  exclude       = ["172.16.0.0/24"]
+
+		  depends_on = [scaleway_vpc_subnet_allocation.app]
+		}
+
+		resource "scaleway_vpc_private_network" "app" {
+		  name   = "tf-pn-subnet-allocation"
+		  vpc_id = scaleway_vpc.vpc01.id
+
+		  ipv4_subnet {
+			subnet = scaleway_vpc_subnet_allocation.app.subnet
+		  }
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             isSubnetAllocationDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_vpc_subnet_allocation.app", "subnet", "172.16.1.0/24"),
+					resource.TestCheckResourceAttr("scaleway_vpc_subnet_allocation.app", "address", "172.16.1.0"),
+					resource.TestCheckResourceAttr("scaleway_vpc_subnet_allocation.app", "last_address", "172.16.1.255"),
+					resource.TestCheckResourceAttr("scaleway_vpc_subnet_allocation.db", "subnet", "172.16.2.0/24"),
+					resource.TestCheckResourceAttr("scaleway_vpc.vpc01", "tags.#", "2"),
+					isSubnetAllocationPresent(tt, "scaleway_vpc_subnet_allocation.app"),
+					isSubnetAllocationPresent(tt, "scaleway_vpc_subnet_allocation.db"),
+				),
+			},
+			{
+				// The private network using the subnet is read on refresh, the allocation tags are not tags of scaleway_vpc
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_vpc_subnet_allocation.app", "private_network_id", "scaleway_vpc_private_network.app", "id"),
+					resource.TestCheckResourceAttr("scaleway_vpc_subnet_allocation.db", "private_network_id", ""),
+					resource.TestCheckResourceAttr("scaleway_vpc.vpc01", "tags.#", "2"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "scaleway_vpc_subnet_allocation.db",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"supernet", "prefix_length", "exclude"},
+			},
+		},
+	})
+}
+
+// hasSubnetAllocationTag tells whether the VPC of a scaleway_vpc_subnet_allocation resource records its subnet
+func hasSubnetAllocationTag(tt *acctest.TestTools, rs *terraform.ResourceState) (bool, error) {
+	region, vpcID, rawSubnet, err := regional.ParseNestedID(rs.Primary.ID)
+	if err != nil {
+		return false, err
+	}
+
+	subnet, err := netip.ParsePrefix(rawSubnet)
+	if err != nil {
+		return false, err
+	}
+
+	vpcAPI, err := vpc.NewAPI(tt.Meta)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := vpcAPI.GetVPC(&vpcSDK.GetVPCRequest{
+		Region: region,
+		VpcID:  vpcID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	_, subnets := vpc.SplitSubnetAllocationTags(res.Tags)
+
+	return slices.Contains(subnets, subnet), nil
+}
+
+func isSubnetAllocationPresent(tt *acctest.TestTools, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		found, err := hasSubnetAllocationTag(tt, rs)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return fmt.Errorf("subnet allocation (%s) not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func isSubnetAllocationDestroyed(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_vpc_subnet_allocation" {
+				continue
+			}
+
+			found, err := hasSubnetAllocationTag(tt, rs)
+			if err != nil {
+				if httperrors.Is404(err) {
+					continue
+				}
+
+				return err
+			}
+
+			if found {
+				return fmt.Errorf("subnet allocation (%s) still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -7,14 +7,26 @@ import (
 	vpcSDK "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/logging"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpc"
 )
 
 func AddTestSweepers() {
 	resource.AddTestSweepers("scaleway_vpc", &resource.Sweeper{
 		Name:         "scaleway_vpc",
 		F:            testSweepVPC,
-		Dependencies: []string{"scaleway_vpc_private_network", "scaleway_vpc_connector"},
+		Dependencies: []string{"scaleway_vpc_private_network", "scaleway_vpc_connector", "scaleway_vpc_acl_rule", "scaleway_vpc_subnet_allocation"},
+	})
+
+	resource.AddTestSweepers("scaleway_vpc_subnet_allocation", &resource.Sweeper{
+		Name: "scaleway_vpc_subnet_allocation",
+		F:    testSweepVPCSubnetAllocation,
+	})
+
+	resource.AddTestSweepers("scaleway_vpc_acl_rule", &resource.Sweeper{
+		Name: "scaleway_vpc_acl_rule",
+		F:    testSweepVPCACLRule,
 	})
 
 	resource.AddTestSweepers("scaleway_vpc_private_network", &resource.Sweeper{
@@ -68,6 +80,85 @@ func testSweepVPC(_ string) error {
 	})
 }
 
+func testSweepVPCACLRule(_ string) error {
+	return acctest.SweepRegions(scw.AllRegions, func(scwClient *scw.Client, region scw.Region) error {
+		vpcAPI := vpcSDK.NewAPI(scwClient)
+
+		logging.L.Debugf("sweeper: removing the ACL rules with a priority in (%s)", region)
+
+		listVPCs, err := vpcAPI.ListVPCs(&vpcSDK.ListVPCsRequest{Region: region}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing VPCs in (%s) in sweeper: %w", region, err)
+		}
+
+		for _, v := range listVPCs.Vpcs {
+			for _, isIPv6 := range []bool{false, true} {
+				acl, err := vpcAPI.GetACL(&vpcSDK.GetACLRequest{
+					VpcID:  v.ID,
+					Region: region,
+					IsIPv6: isIPv6,
+				})
+				if err != nil {
+					if !httperrors.Is404(err) {
+						logging.L.Warningf("error getting the ACL of VPC %s in sweeper: %s", v.ID, err)
+					}
+
+					continue
+				}
+
+				rules := vpc.ClientACLRulesWithoutPriority(acl.Rules)
+				if len(rules) == len(acl.Rules) {
+					continue
+				}
+
+				_, err = vpcAPI.SetACL(&vpcSDK.SetACLRequest{
+					VpcID:         v.ID,
+					Region:        region,
+					IsIPv6:        isIPv6,
+					DefaultPolicy: acl.DefaultPolicy,
+					Rules:         rules,
+				})
+				if err != nil {
+					logging.L.Warningf("error removing the ACL rules of VPC %s in sweeper: %s", v.ID, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func testSweepVPCSubnetAllocation(_ string) error {
+	return acctest.SweepRegions(scw.AllRegions, func(scwClient *scw.Client, region scw.Region) error {
+		vpcAPI := vpcSDK.NewAPI(scwClient)
+
+		logging.L.Debugf("sweeper: removing the subnet allocations in (%s)", region)
+
+		listVPCs, err := vpcAPI.ListVPCs(&vpcSDK.ListVPCsRequest{Region: region}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing VPCs in (%s) in sweeper: %w", region, err)
+		}
+
+		for _, v := range listVPCs.Vpcs {
+			userTags, subnets := vpc.SplitSubnetAllocationTags(v.Tags)
+			if len(subnets) == 0 {
+				continue
+			}
+
+			_, err := vpcAPI.UpdateVPC(&vpcSDK.UpdateVPCRequest{
+				VpcID:  v.ID,
+				Region: region,
+				Tags:   &userTags,
+			})
+			if err != nil {
+				logging.L.Warningf("error removing the subnet allocations of VPC %s in sweeper: %s", v.ID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
 func testSweepVPCPrivateNetwork(_ string) error {
 	err := acctest.SweepRegions(scw.AllRegions, func(scwClient *scw.Client, region scw.Region) error {
 		vpcAPI := vpcSDK.NewAPI(scwClient)
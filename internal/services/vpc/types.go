@@ -2,7 +2,6 @@ package vpc
 
 import (
 	"net"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -183,19 +182,16 @@ func flattenACLRules(rules []*vpc.ACLRule) any {
 
 	flattenedRules := []map[string]any(nil)
 
-	ruleScopeRegex := regexp.MustCompile(`^\(Rule scope: ([^)]+)\)\s*`)
-
 	for _, rule := range rules {
-		rawDescription := types.FlattenStringPtr(rule.Description).(string)
-
-		if matches := ruleScopeRegex.FindStringSubmatch(rawDescription); matches != nil {
-			scope := strings.TrimSpace(matches[1])
-			if scope != "client" {
-				continue
-			}
+		scope, cleanDescription := aclRuleScope(rule)
+		if scope != aclRuleScopeClient {
+			continue
 		}
 
-		cleanDescription := ruleScopeRegex.ReplaceAllString(rawDescription, "")
+		// The rules of the scaleway_vpc_acl_rule resources are not managed by scaleway_vpc_acl
+		if _, _, ok := aclRulePriority(rule); ok {
+			continue
+		}
 
 		flattenedSource, err := types.FlattenIPNet(rule.Source)
 		if err != nil {
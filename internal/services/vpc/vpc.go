@@ -171,8 +171,9 @@ func setVPCState(d *schema.ResourceData, res *vpc.VPC) diag.Diagnostics {
 	_ = d.Set("enable_transitivity", res.TransitivityEnabled)
 	_ = d.Set("region", res.Region)
 
-	if len(res.Tags) > 0 {
-		_ = d.Set("tags", res.Tags)
+	// The subnets allocated by scaleway_vpc_subnet_allocation are not tags of the user
+	if userTags, _ := SplitSubnetAllocationTags(res.Tags); len(userTags) > 0 {
+		_ = d.Set("tags", userTags)
 	}
 
 	return nil
@@ -197,7 +198,28 @@ func ResourceVPCUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.
 	}
 
 	if d.HasChange("tags") {
-		updateRequest.Tags = types.ExpandUpdatedStringsPtr(d.Get("tags"))
+		lockKey := regional.NewIDString(region, ID)
+
+		vpcTagsMutexKV.Lock(lockKey)
+		defer vpcTagsMutexKV.Unlock(lockKey)
+
+		current, err := vpcAPI.GetVPC(&vpc.GetVPCRequest{
+			Region: region,
+			VpcID:  ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		tags := types.ExpandStrings(d.Get("tags"))
+
+		// Keep the subnets allocated by scaleway_vpc_subnet_allocation
+		_, allocated := SplitSubnetAllocationTags(current.Tags)
+		for _, subnet := range allocated {
+			tags = append(tags, SubnetAllocationTag(subnet))
+		}
+
+		updateRequest.Tags = &tags
 		hasChanged = true
 	}
 
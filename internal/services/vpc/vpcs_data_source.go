@@ -111,8 +111,8 @@ func DataSourceVPCsRead(ctx context.Context, d *schema.ResourceData, m any) diag
 		rawVpc["update_at"] = types.FlattenTime(virtualPrivateCloud.UpdatedAt)
 		rawVpc["is_default"] = virtualPrivateCloud.IsDefault
 
-		if len(virtualPrivateCloud.Tags) > 0 {
-			rawVpc["tags"] = virtualPrivateCloud.Tags
+		if userTags, _ := SplitSubnetAllocationTags(virtualPrivateCloud.Tags); len(userTags) > 0 {
+			rawVpc["tags"] = userTags
 		}
 
 		rawVpc["region"] = region.String()
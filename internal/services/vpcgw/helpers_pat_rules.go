@@ -0,0 +1,146 @@
+package vpcgw
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
+)
+
+// expandPATRules returns the rules of a pat_rules set, sorted by public port and protocol
+func expandPATRules(raw []any) []*vpcgw.SetPatRulesRequestRule {
+	rules := make([]*vpcgw.SetPatRulesRequestRule, 0, len(raw))
+
+	for _, rawRule := range raw {
+		rule := rawRule.(map[string]any)
+
+		rules = append(rules, &vpcgw.SetPatRulesRequestRule{
+			PublicPort:  uint32(rule["public_port"].(int)),
+			PrivateIP:   net.ParseIP(rule["private_ip"].(string)),
+			PrivatePort: uint32(rule["private_port"].(int)),
+			Protocol:    vpcgw.PatRuleProtocol(rule["protocol"].(string)),
+		})
+	}
+
+	slices.SortFunc(rules, func(a, b *vpcgw.SetPatRulesRequestRule) int {
+		return cmp.Or(cmp.Compare(a.PublicPort, b.PublicPort), strings.Compare(a.Protocol.String(), b.Protocol.String()))
+	})
+
+	return rules
+}
+
+func flattenPATRules(rules []*vpcgw.PatRule) []any {
+	flattened := make([]any, 0, len(rules))
+
+	for _, rule := range rules {
+		flattened = append(flattened, map[string]any{
+			"public_port":  int(rule.PublicPort),
+			"private_ip":   rule.PrivateIP.String(),
+			"private_port": int(rule.PrivatePort),
+			"protocol":     rule.Protocol.String(),
+		})
+	}
+
+	return flattened
+}
+
+func patRulesToSetRequestRules(rules []*vpcgw.PatRule) []*vpcgw.SetPatRulesRequestRule {
+	requestRules := make([]*vpcgw.SetPatRulesRequestRule, 0, len(rules))
+
+	for _, rule := range rules {
+		requestRules = append(requestRules, &vpcgw.SetPatRulesRequestRule{
+			PublicPort:  rule.PublicPort,
+			PrivateIP:   rule.PrivateIP,
+			PrivatePort: rule.PrivatePort,
+			Protocol:    rule.Protocol,
+		})
+	}
+
+	return requestRules
+}
+
+func patRuleKey(publicPort uint32, privateIP net.IP, privatePort uint32, protocol vpcgw.PatRuleProtocol) string {
+	return fmt.Sprintf("%d/%s -> %s:%d", publicPort, protocol, privateIP, privatePort)
+}
+
+// patRuleProtocolsOverlap reports whether two rules of the same public port would receive the same packets
+func patRuleProtocolsOverlap(a, b vpcgw.PatRuleProtocol) bool {
+	return a == b || a == vpcgw.PatRuleProtocolBoth || b == vpcgw.PatRuleProtocolBoth
+}
+
+// checkPATRuleCollisions rejects two rules forwarding the same public port and protocol
+func checkPATRuleCollisions(rules []*vpcgw.SetPatRulesRequestRule) error {
+	for i, rule := range rules {
+		for _, other := range rules[i+1:] {
+			if rule.PublicPort != other.PublicPort || !patRuleProtocolsOverlap(rule.Protocol, other.Protocol) {
+				continue
+			}
+
+			return fmt.Errorf("public port %d is forwarded twice: %s and %s",
+				rule.PublicPort,
+				patRuleKey(rule.PublicPort, rule.PrivateIP, rule.PrivatePort, rule.Protocol),
+				patRuleKey(other.PublicPort, other.PrivateIP, other.PrivatePort, other.Protocol),
+			)
+		}
+	}
+
+	return nil
+}
+
+// patRuleKeys returns the keys of the rules of a pat_rules set, as stored in managed_rules
+func patRuleKeys(rules []*vpcgw.SetPatRulesRequestRule) []string {
+	keys := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		keys = append(keys, patRuleKey(rule.PublicPort, rule.PrivateIP, rule.PrivatePort, rule.Protocol))
+	}
+
+	return keys
+}
+
+// managedPATRules returns the rules of the gateway whose key is in managed_rules
+func managedPATRules(existing []*vpcgw.PatRule, managedKeys []string) []*vpcgw.PatRule {
+	managed := []*vpcgw.PatRule(nil)
+
+	for _, rule := range existing {
+		if slices.Contains(managedKeys, patRuleKey(rule.PublicPort, rule.PrivateIP, rule.PrivatePort, rule.Protocol)) {
+			managed = append(managed, rule)
+		}
+	}
+
+	return managed
+}
+
+// foreignPATRules returns the rules of the gateway which are neither in managed_rules nor in the new rule set of the pat_rules resource,
+// they are created by scaleway_vpc_public_gateway_pat_rule resources or outside of Terraform, and SetPatRules would delete them
+func foreignPATRules(existing []*vpcgw.PatRule, managedKeys ...[]string) []*vpcgw.PatRule {
+	known := map[string]bool{}
+
+	for _, keys := range managedKeys {
+		for _, key := range keys {
+			known[key] = true
+		}
+	}
+
+	foreign := []*vpcgw.PatRule(nil)
+
+	for _, rule := range existing {
+		if !known[patRuleKey(rule.PublicPort, rule.PrivateIP, rule.PrivatePort, rule.Protocol)] {
+			foreign = append(foreign, rule)
+		}
+	}
+
+	return foreign
+}
+
+func foreignPATRulesError(gatewayID string, foreign []*vpcgw.PatRule) error {
+	keys := make([]string, 0, len(foreign))
+	for _, rule := range foreign {
+		keys = append(keys, patRuleKey(rule.PublicPort, rule.PrivateIP, rule.PrivatePort, rule.Protocol))
+	}
+
+	return fmt.Errorf("gateway %s has PAT rules which are not managed by this resource and would be deleted: %s. "+
+		"They are probably managed by scaleway_vpc_public_gateway_pat_rule resources, move them to this resource and remove the individual resources from the state", gatewayID, strings.Join(keys, ", "))
+}
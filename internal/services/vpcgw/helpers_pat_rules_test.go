@@ -0,0 +1,112 @@
+//nolint:testpackage // Tests need access to unexported PAT rules helpers.
+package vpcgw
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
+)
+
+func testPATRuleSet(publicPort uint32, privateIP string, privatePort uint32, protocol vpcgw.PatRuleProtocol) map[string]any {
+	return map[string]any{
+		"public_port":  int(publicPort),
+		"private_ip":   privateIP,
+		"private_port": int(privatePort),
+		"protocol":     protocol.String(),
+	}
+}
+
+func TestCheckPATRuleCollisions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rules   []any
+		wantErr bool
+	}{
+		{
+			name: "distinct ports",
+			rules: []any{
+				testPATRuleSet(2201, "192.168.0.1", 22, vpcgw.PatRuleProtocolBoth),
+				testPATRuleSet(2202, "192.168.0.2", 22, vpcgw.PatRuleProtocolBoth),
+			},
+		},
+		{
+			name: "same port on tcp and udp",
+			rules: []any{
+				testPATRuleSet(53, "192.168.0.1", 53, vpcgw.PatRuleProtocolTCP),
+				testPATRuleSet(53, "192.168.0.2", 53, vpcgw.PatRuleProtocolUDP),
+			},
+		},
+		{
+			name: "same port and protocol",
+			rules: []any{
+				testPATRuleSet(2201, "192.168.0.1", 22, vpcgw.PatRuleProtocolTCP),
+				testPATRuleSet(2201, "192.168.0.2", 22, vpcgw.PatRuleProtocolTCP),
+			},
+			wantErr: true,
+		},
+		{
+			name: "same port with both",
+			rules: []any{
+				testPATRuleSet(2201, "192.168.0.1", 22, vpcgw.PatRuleProtocolUDP),
+				testPATRuleSet(2201, "192.168.0.2", 22, vpcgw.PatRuleProtocolBoth),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkPATRuleCollisions(expandPATRules(tt.rules))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkPATRuleCollisions() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForeignPATRules(t *testing.T) {
+	t.Parallel()
+
+	existing := []*vpcgw.PatRule{
+		{PublicPort: 2201, PrivateIP: net.ParseIP("192.168.0.1"), PrivatePort: 22, Protocol: vpcgw.PatRuleProtocolBoth},
+		{PublicPort: 2202, PrivateIP: net.ParseIP("192.168.0.2"), PrivatePort: 22, Protocol: vpcgw.PatRuleProtocolBoth},
+		{PublicPort: 8080, PrivateIP: net.ParseIP("192.168.0.3"), PrivatePort: 80, Protocol: vpcgw.PatRuleProtocolTCP},
+	}
+
+	managed := patRuleKeys(expandPATRules([]any{testPATRuleSet(2201, "192.168.0.1", 22, vpcgw.PatRuleProtocolBoth)}))
+	desired := patRuleKeys(expandPATRules([]any{testPATRuleSet(2202, "192.168.0.2", 22, vpcgw.PatRuleProtocolBoth)}))
+
+	foreign := foreignPATRules(existing, managed, desired)
+	if len(foreign) != 1 || foreign[0].PublicPort != 8080 {
+		t.Fatalf("got foreign rules %v, want the rule of public port 8080", foreign)
+	}
+
+	if foreign := foreignPATRules(existing[:2], managed, desired); len(foreign) != 0 {
+		t.Fatalf("got foreign rules %v, want none", foreign)
+	}
+}
+
+func TestManagedPATRules(t *testing.T) {
+	t.Parallel()
+
+	existing := []*vpcgw.PatRule{
+		{PublicPort: 2201, PrivateIP: net.ParseIP("192.168.0.1"), PrivatePort: 22, Protocol: vpcgw.PatRuleProtocolBoth},
+		{PublicPort: 8080, PrivateIP: net.ParseIP("192.168.0.3"), PrivatePort: 80, Protocol: vpcgw.PatRuleProtocolTCP},
+	}
+
+	managedKeys := patRuleKeys(expandPATRules([]any{testPATRuleSet(2201, "192.168.0.1", 22, vpcgw.PatRuleProtocolBoth)}))
+
+	managed := managedPATRules(existing, managedKeys)
+	if len(managed) != 1 || managed[0].PublicPort != 2201 {
+		t.Fatalf("got managed rules %v, want the rule of public port 2201", managed)
+	}
+
+	if managed := managedPATRules(existing, nil); len(managed) != 0 {
+		t.Fatalf("got managed rules %v, want none", managed)
+	}
+}
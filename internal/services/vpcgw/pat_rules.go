@@ -0,0 +1,326 @@
+package vpcgw
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cdf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/dsf"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/identity"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/locality/zonal"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/meta"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+)
+
+//go:embed descriptions/pat_rules_resource.md
+var patRulesResourceDescription string
+
+func ResourcePATRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceVPCPublicGatewayPATRulesCreate,
+		ReadContext:   ResourceVPCPublicGatewayPATRulesRead,
+		UpdateContext: ResourceVPCPublicGatewayPATRulesUpdate,
+		DeleteContext: ResourceVPCPublicGatewayPATRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVPCPublicGatewayPATRulesImport,
+		},
+		Description:   patRulesResourceDescription,
+		Identity:      identity.DefaultZonal(),
+		SchemaVersion: 0,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultTimeout),
+			Update:  schema.DefaultTimeout(defaultTimeout),
+			Delete:  schema.DefaultTimeout(defaultTimeout),
+			Default: schema.DefaultTimeout(defaultTimeout),
+		},
+		SchemaFunc: patRulesSchema,
+		CustomizeDiff: customdiff.All(
+			cdf.LocalityCheck("gateway_id"),
+			resourceVPCPublicGatewayPATRulesCustomizeDiff,
+		),
+	}
+}
+
+func patRulesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"gateway_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: verify.IsUUIDorUUIDWithLocality(),
+			DiffSuppressFunc: dsf.Locality,
+			Description:      "The ID of the gateway whose PAT rules are managed",
+		},
+		"rule": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "The PAT rules of the gateway, the rules which are not listed are deleted",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"public_port": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						Description:  "The public port used in the PAT rule",
+					},
+					"private_ip": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsIPAddress,
+						Description:  "The private IP used in the PAT rule",
+					},
+					"private_port": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntBetween(0, math.MaxUint16),
+						Description:  "The private port used in the PAT rule",
+					},
+					"protocol": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: verify.ValidateEnum[vpcgw.PatRuleProtocol](),
+						Default:          vpcgw.PatRuleProtocolBoth.String(),
+						Description:      "The protocol used in the PAT rule (tcp, udp or both)",
+					},
+				},
+			},
+		},
+		"managed_rules": {
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The keys of the PAT rules of the gateway managed by this resource, the other rules of the gateway are reported at plan time",
+		},
+		"zone": zonal.Schema(),
+	}
+}
+
+// resourceVPCPublicGatewayPATRulesCustomizeDiff rejects the rules forwarding the same public port twice,
+// and the gateways with rules which are not managed by this resource, at every plan
+func resourceVPCPublicGatewayPATRulesCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m any) error {
+	if !diff.NewValueKnown("rule") || !diff.NewValueKnown("gateway_id") {
+		return nil
+	}
+
+	managedKeys := types.ExpandStrings(diff.Get("managed_rules").(*schema.Set).List())
+	desired := expandPATRules(diff.Get("rule").(*schema.Set).List())
+
+	if err := checkPATRuleCollisions(desired); err != nil {
+		return err
+	}
+
+	if diff.Id() == "" || diff.HasChange("rule") {
+		if err := diff.SetNew("managed_rules", patRuleKeys(desired)); err != nil {
+			return err
+		}
+	}
+
+	gatewayID := zonal.ExpandID(diff.Get("gateway_id").(string))
+	if gatewayID.Zone == "" {
+		gatewayID.Zone, _ = meta.ExtractZone(diff, m)
+	}
+
+	existing, err := listGatewayPATRules(ctx, vpcgw.NewAPI(meta.ExtractScwClient(m)), gatewayID.Zone, gatewayID.ID)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("could not list the PAT rules of gateway %s to check for unmanaged rules: %s", gatewayID, err))
+
+		return nil
+	}
+
+	if foreign := foreignPATRules(existing, managedKeys, patRuleKeys(desired)); len(foreign) > 0 {
+		return foreignPATRulesError(gatewayID.ID, foreign)
+	}
+
+	return nil
+}
+
+func listGatewayPATRules(ctx context.Context, api *vpcgw.API, zone scw.Zone, gatewayID string) ([]*vpcgw.PatRule, error) {
+	res, err := api.ListPatRules(&vpcgw.ListPatRulesRequest{
+		Zone:       zone,
+		GatewayIDs: []string{gatewayID},
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return res.PatRules, nil
+}
+
+// setGatewayPATRules replaces the PAT rules of the gateway, after checking that no unmanaged rule was added since the plan
+func setGatewayPATRules(ctx context.Context, d *schema.ResourceData, api *vpcgw.API, zone scw.Zone, gatewayID string, timeout string) error {
+	oldManagedKeys, _ := d.GetChange("managed_rules")
+	managedKeys := types.ExpandStrings(oldManagedKeys.(*schema.Set).List())
+	desired := expandPATRules(d.Get("rule").(*schema.Set).List())
+
+	_, err := waitForVPCPublicGateway(ctx, api, zone, gatewayID, d.Timeout(timeout))
+	if err != nil {
+		return err
+	}
+
+	existing, err := listGatewayPATRules(ctx, api, zone, gatewayID)
+	if err != nil {
+		return err
+	}
+
+	if foreign := foreignPATRules(existing, managedKeys, patRuleKeys(desired)); len(foreign) > 0 {
+		return foreignPATRulesError(gatewayID, foreign)
+	}
+
+	_, err = api.SetPatRules(&vpcgw.SetPatRulesRequest{
+		Zone:      zone,
+		GatewayID: gatewayID,
+		PatRules:  desired,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("managed_rules", patRuleKeys(desired))
+
+	_, err = waitForVPCPublicGateway(ctx, api, zone, gatewayID, d.Timeout(timeout))
+
+	return err
+}
+
+func ResourceVPCPublicGatewayPATRulesCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, err := newAPIWithZone(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gatewayID := zonal.ExpandID(d.Get("gateway_id").(string)).ID
+
+	err = setGatewayPATRules(ctx, d, api, zone, gatewayID, schema.TimeoutCreate)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = identity.SetZonalIdentity(d, zone, gatewayID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ResourceVPCPublicGatewayPATRulesRead(ctx, d, m)
+}
+
+func ResourceVPCPublicGatewayPATRulesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, gatewayID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = api.GetGateway(&vpcgw.GetGatewayRequest{
+		GatewayID: gatewayID,
+		Zone:      zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if httperrors.Is404(err) {
+			d.SetId("")
+
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	rules, err := listGatewayPATRules(ctx, api, zone, gatewayID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("gateway_id", zonal.NewIDString(zone, gatewayID))
+	// The rules added outside of this resource are left out of the state, the plan reports them
+	_ = d.Set("rule", flattenPATRules(managedPATRules(rules, types.ExpandStrings(d.Get("managed_rules").(*schema.Set).List()))))
+	_ = d.Set("zone", zone.String())
+
+	err = identity.SetZonalIdentity(d, zone, gatewayID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceVPCPublicGatewayPATRulesImport adopts every PAT rule of the gateway
+func resourceVPCPublicGatewayPATRulesImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	api, zone, gatewayID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := listGatewayPATRules(ctx, api, zone, gatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = d.Set("managed_rules", patRuleKeys(patRulesToSetRequestRules(rules)))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func ResourceVPCPublicGatewayPATRulesUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, gatewayID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("rule") {
+		err = setGatewayPATRules(ctx, d, api, zone, gatewayID, schema.TimeoutUpdate)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ResourceVPCPublicGatewayPATRulesRead(ctx, d, m)
+}
+
+func ResourceVPCPublicGatewayPATRulesDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	api, zone, gatewayID, err := NewAPIWithZoneAndID(m, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForVPCPublicGateway(ctx, api, zone, gatewayID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if httperrors.Is404(err) {
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	existing, err := listGatewayPATRules(ctx, api, zone, gatewayID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Keep the rules added outside of this resource
+	foreign := foreignPATRules(existing, types.ExpandStrings(d.Get("managed_rules").(*schema.Set).List()))
+
+	_, err = api.SetPatRules(&vpcgw.SetPatRulesRequest{
+		Zone:      zone,
+		GatewayID: gatewayID,
+		PatRules:  patRulesToSetRequestRules(foreign),
+	}, scw.WithContext(ctx))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitForVPCPublicGateway(ctx, api, zone, gatewayID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !httperrors.Is404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
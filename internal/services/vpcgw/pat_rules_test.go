@@ -0,0 +1,334 @@
+package vpcgw_test
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	vpcgwSDK "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/acctest"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/httperrors"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpcgw"
+	vpcgwchecks "github.com/scaleway/terraform-provider-scaleway/v2/internal/services/vpcgw/testfuncs"
+)
+
+const patRulesGatewayConfig = `
+	resource "scaleway_vpc_private_network" "main" {
+	  name = "tf-pn-pat-rules"
+
+	  ipv4_subnet {
+		subnet = "172.16.64.0/22"
+	  }
+	}
+
+	resource "scaleway_vpc_public_gateway" "main" {
+	  name = "tf-gw-pat-rules"
+	  type = "VPC-GW-S"
+	}
+
+	resource "scaleway_vpc_gateway_network" "main" {
+	  gateway_id         = scaleway_vpc_public_gateway.main.id
+	  private_network_id = scaleway_vpc_private_network.main.id
+	  enable_masquerade  = true
+	  ipam_config {
+		push_default_route = false
+	  }
+	}
+`
+
+func TestAccVPCPublicGatewayPATRules_Basic(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			vpcgwchecks.IsGatewayDestroyed(tt),
+			testAccCheckVPCPublicGatewayPATRulesDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: patRulesGatewayConfig + `
+					resource "scaleway_vpc_public_gateway_pat_rules" "main" {
+					  gateway_id = scaleway_vpc_public_gateway.main.id
+
+					  rule {
+						public_port  = 2022
+						private_ip   = "172.16.64.10"
+						private_port = 22
+						protocol     = "tcp"
+					  }
+
+					  rule {
+						public_port  = 2023
+						private_ip   = "172.16.64.11"
+						private_port = 22
+						protocol     = "tcp"
+					  }
+
+					  depends_on = [scaleway_vpc_gateway_network.main]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_vpc_public_gateway_pat_rules.main", "gateway_id", "scaleway_vpc_public_gateway.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_vpc_public_gateway_pat_rules.main", "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_vpc_public_gateway_pat_rules.main", "rule.*", map[string]string{
+						"public_port":  "2022",
+						"private_ip":   "172.16.64.10",
+						"private_port": "22",
+						"protocol":     "tcp",
+					}),
+					testAccCheckVPCPublicGatewayPATRulesCount(tt, "scaleway_vpc_public_gateway_pat_rules.main", 2),
+				),
+			},
+			{
+				Config: patRulesGatewayConfig + `
+					resource "scaleway_vpc_public_gateway_pat_rules" "main" {
+					  gateway_id = scaleway_vpc_public_gateway.main.id
+
+					  rule {
+						public_port  = 2022
+						private_ip   = "172.16.64.10"
+						private_port = 2222
+						protocol     = "tcp"
+					  }
+
+					  rule {
+						public_port  = 2023
+						private_ip   = "172.16.64.11"
+						private_port = 22
+						protocol     = "tcp"
+					  }
+
+					  rule {
+						public_port  = 5353
+						private_ip   = "172.16.64.12"
+						private_port = 53
+					  }
+
+					  depends_on = [scaleway_vpc_gateway_network.main]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_vpc_public_gateway_pat_rules.main", "rule.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_vpc_public_gateway_pat_rules.main", "rule.*", map[string]string{
+						"public_port":  "2022",
+						"private_port": "2222",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_vpc_public_gateway_pat_rules.main", "rule.*", map[string]string{
+						"public_port": "5353",
+						"protocol":    "both",
+					}),
+					testAccCheckVPCPublicGatewayPATRulesCount(tt, "scaleway_vpc_public_gateway_pat_rules.main", 3),
+				),
+			},
+			{
+				ResourceName:      "scaleway_vpc_public_gateway_pat_rules.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: patRulesGatewayConfig + `
+					resource "scaleway_vpc_public_gateway_pat_rules" "main" {
+					  gateway_id = scaleway_vpc_public_gateway.main.id
+
+					  rule {
+						public_port  = 2022
+						private_ip   = "172.16.64.10"
+						private_port = 22
+						protocol     = "tcp"
+					  }
+
+					  rule {
+						public_port  = 2022
+						private_ip   = "172.16.64.11"
+						private_port = 22
+					  }
+
+					  depends_on = [scaleway_vpc_gateway_network.main]
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`public port 2022 is forwarded twice`),
+			},
+		},
+	})
+}
+
+func TestAccVPCPublicGatewayPATRules_ConflictWithPATRule(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	patRuleConfig := patRulesGatewayConfig + `
+		resource "scaleway_vpc_public_gateway_pat_rule" "ssh" {
+		  gateway_id   = scaleway_vpc_public_gateway.main.id
+		  private_ip   = "172.16.64.10"
+		  private_port = 22
+		  public_port  = 2022
+		  protocol     = "tcp"
+
+		  depends_on = [scaleway_vpc_gateway_network.main]
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			vpcgwchecks.IsGatewayDestroyed(tt),
+			testAccCheckVPCPublicGatewayPATRulesDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: patRuleConfig,
+			},
+			{
+				Config: patRuleConfig + `
+					resource "scaleway_vpc_public_gateway_pat_rules" "main" {
+					  gateway_id = scaleway_vpc_public_gateway.main.id
+
+					  rule {
+						public_port  = 2023
+						private_ip   = "172.16.64.11"
+						private_port = 22
+						protocol     = "tcp"
+					  }
+
+					  depends_on = [scaleway_vpc_public_gateway_pat_rule.ssh]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`has PAT rules which are not managed by this resource`),
+			},
+		},
+	})
+}
+
+func TestAccVPCPublicGatewayPATRules_UnmanagedRule(t *testing.T) {
+	tt := acctest.NewTestTools(t)
+	defer tt.Cleanup()
+
+	patRulesConfig := patRulesGatewayConfig + `
+		resource "scaleway_vpc_public_gateway_pat_rules" "main" {
+		  gateway_id = scaleway_vpc_public_gateway.main.id
+
+		  rule {
+			public_port  = 2023
+			private_ip   = "172.16.64.11"
+			private_port = 22
+			protocol     = "tcp"
+		  }
+
+		  depends_on = [scaleway_vpc_gateway_network.main]
+		}
+	`
+
+	var patRulesID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             vpcgwchecks.IsGatewayDestroyed(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: patRulesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_vpc_public_gateway_pat_rules.main", "managed_rules.#", "1"),
+					resource.TestCheckTypeSetElemAttr("scaleway_vpc_public_gateway_pat_rules.main", "managed_rules.*", "2023/tcp -> 172.16.64.11:22"),
+					func(s *terraform.State) error {
+						patRulesID = s.RootModule().Resources["scaleway_vpc_public_gateway_pat_rules.main"].Primary.ID
+
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					api, zone, gatewayID, err := vpcgw.NewAPIWithZoneAndID(tt.Meta, patRulesID)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					_, err = api.CreatePatRule(&vpcgwSDK.CreatePatRuleRequest{
+						Zone:        zone,
+						GatewayID:   gatewayID,
+						PublicPort:  2022,
+						PrivateIP:   net.ParseIP("172.16.64.10"),
+						PrivatePort: 22,
+						Protocol:    vpcgwSDK.PatRuleProtocolTCP,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      patRulesConfig,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`has PAT rules which are not managed by this resource and would be deleted: 2022/tcp -> 172\.16\.64\.10:22`),
+			},
+		},
+	})
+}
+
+// listPATRulesOfGateway lists the PAT rules of the gateway of a scaleway_vpc_public_gateway_pat_rules resource
+func listPATRulesOfGateway(tt *acctest.TestTools, rs *terraform.ResourceState) ([]*vpcgwSDK.PatRule, error) {
+	api, zone, gatewayID, err := vpcgw.NewAPIWithZoneAndID(tt.Meta, rs.Primary.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := api.ListPatRules(&vpcgwSDK.ListPatRulesRequest{
+		Zone:       zone,
+		GatewayIDs: []string{gatewayID},
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, err
+	}
+
+	return res.PatRules, nil
+}
+
+func testAccCheckVPCPublicGatewayPATRulesCount(tt *acctest.TestTools, n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		rules, err := listPATRulesOfGateway(tt, rs)
+		if err != nil {
+			return err
+		}
+
+		if len(rules) != count {
+			return fmt.Errorf("gateway of %s has %d PAT rules, expected %d", rs.Primary.ID, len(rules), count)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckVPCPublicGatewayPATRulesDestroy(tt *acctest.TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_vpc_public_gateway_pat_rules" {
+				continue
+			}
+
+			rules, err := listPATRulesOfGateway(tt, rs)
+			if err != nil {
+				if httperrors.Is404(err) {
+					continue
+				}
+
+				return err
+			}
+
+			if len(rules) > 0 {
+				return fmt.Errorf("gateway of %s still has %d PAT rules", rs.Primary.ID, len(rules))
+			}
+		}
+
+		return nil
+	}
+}
@@ -19,9 +19,47 @@ func AddTestSweepers() {
 		Name: "scaleway_gateway_network",
 		F:    testSweepVPCGatewayNetwork,
 	})
+	resource.AddTestSweepers("scaleway_vpc_public_gateway_pat_rules", &resource.Sweeper{
+		Name: "scaleway_vpc_public_gateway_pat_rules",
+		F:    testSweepVPCPublicGatewayPATRules,
+	})
 	resource.AddTestSweepers("scaleway_vpc_public_gateway", &resource.Sweeper{
-		Name: "scaleway_vpc_public_gateway",
-		F:    testSweepVPCPublicGateway,
+		Name:         "scaleway_vpc_public_gateway",
+		F:            testSweepVPCPublicGateway,
+		Dependencies: []string{"scaleway_vpc_public_gateway_pat_rules"},
+	})
+}
+
+func testSweepVPCPublicGatewayPATRules(_ string) error {
+	return acctest.SweepZones(scw.AllZones, func(scwClient *scw.Client, zone scw.Zone) error {
+		api := v2.NewAPI(scwClient)
+
+		logging.L.Debugf("sweeper: deleting the PAT rules of the public gateways in (%s)", zone)
+
+		listPatRulesResponse, err := api.ListPatRules(&v2.ListPatRulesRequest{
+			Zone: zone,
+		}, scw.WithAllPages())
+		if err != nil {
+			return fmt.Errorf("error listing PAT rules in sweeper: %w", err)
+		}
+
+		gatewayIDs := map[string]bool{}
+		for _, rule := range listPatRulesResponse.PatRules {
+			gatewayIDs[rule.GatewayID] = true
+		}
+
+		for gatewayID := range gatewayIDs {
+			_, err := api.SetPatRules(&v2.SetPatRulesRequest{
+				Zone:      zone,
+				GatewayID: gatewayID,
+				PatRules:  []*v2.SetPatRulesRequestRule{},
+			})
+			if err != nil {
+				return fmt.Errorf("error deleting PAT rules of public gateway %s in sweeper: %w", gatewayID, err)
+			}
+		}
+
+		return nil
 	})
 }
 
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/netip"
+	"slices"
 	"strconv"
 
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -43,3 +45,143 @@ func FlattenIPNet(ipNet scw.IPNet) (string, error) {
 
 	return string(raw[1 : len(raw)-1]), nil // remove quotes
 }
+
+// IPNetToPrefix converts an IP range to a netip.Prefix, masked to its network address
+func IPNetToPrefix(ipNet scw.IPNet) (netip.Prefix, error) {
+	addr, ok := netip.AddrFromSlice(ipNet.IP)
+	if !ok {
+		return netip.Prefix{}, fmt.Errorf("invalid IP range %s", ipNet.String())
+	}
+
+	ones, _ := ipNet.Mask.Size()
+
+	return netip.PrefixFrom(addr.Unmap(), ones).Masked(), nil
+}
+
+// LastAddr returns the last address of a prefix, the broadcast address of an IPv4 subnet
+func LastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Masked().Addr().AsSlice()
+
+	for i := prefix.Bits(); i < len(bytes)*8; i++ {
+		bytes[i/8] |= 1 << (7 - i%8)
+	}
+
+	last, _ := netip.AddrFromSlice(bytes)
+
+	return last
+}
+
+// NextFreePrefix returns the first prefix of the given length in a supernet which overlaps none of the used prefixes
+func NextFreePrefix(supernet netip.Prefix, bits int, used []netip.Prefix) (netip.Prefix, error) {
+	if bits < supernet.Bits() || bits > supernet.Addr().BitLen() {
+		return netip.Prefix{}, fmt.Errorf("a /%d prefix does not fit in %s", bits, supernet)
+	}
+
+	candidate := netip.PrefixFrom(supernet.Masked().Addr(), bits)
+
+	for {
+		overlapping := -1
+
+		for i, prefix := range used {
+			if prefix.Overlaps(candidate) {
+				overlapping = i
+
+				break
+			}
+		}
+
+		if overlapping < 0 {
+			return candidate, nil
+		}
+
+		// Skip the candidate and the used prefix, whichever ends last, the next address is aligned on the prefix length
+		end := LastAddr(candidate)
+		if last := LastAddr(used[overlapping]); last.Compare(end) > 0 {
+			end = last
+		}
+
+		next := end.Next()
+		if !next.IsValid() || !supernet.Contains(next) {
+			return netip.Prefix{}, fmt.Errorf("no free /%d prefix left in %s", bits, supernet)
+		}
+
+		candidate = netip.PrefixFrom(next, bits)
+	}
+}
+
+// NextFreeAddrRange returns the first address of the first run of count consecutive addresses of a prefix which are not used.
+// The network and broadcast addresses of an IPv4 subnet are never part of the run.
+func NextFreeAddrRange(prefix netip.Prefix, count int, used []netip.Addr) (netip.Addr, error) {
+	if count < 1 {
+		return netip.Addr{}, fmt.Errorf("invalid number of addresses %d", count)
+	}
+
+	first, last := prefix.Masked().Addr(), LastAddr(prefix)
+	if first.Is4() && prefix.Bits() < 31 {
+		first, last = first.Next(), last.Prev()
+	}
+
+	sortedUsed := make([]netip.Addr, 0, len(used))
+
+	for _, addr := range used {
+		if prefix.Contains(addr) {
+			sortedUsed = append(sortedUsed, addr)
+		}
+	}
+
+	slices.SortFunc(sortedUsed, netip.Addr.Compare)
+
+	start := first
+
+	for _, addr := range sortedUsed {
+		if addr.Less(start) {
+			continue
+		}
+
+		end, ok := addrAfter(start, count-1)
+		if !ok || last.Less(end) {
+			break
+		}
+
+		if end.Less(addr) {
+			return start, nil
+		}
+
+		start = addr.Next()
+		if !start.IsValid() {
+			break
+		}
+	}
+
+	if end, ok := addrAfter(start, count-1); ok && start.IsValid() && !last.Less(end) {
+		return start, nil
+	}
+
+	return netip.Addr{}, fmt.Errorf("no %d consecutive free addresses left in %s", count, prefix)
+}
+
+// AddrRange returns count consecutive addresses starting at start
+func AddrRange(start netip.Addr, count int) ([]netip.Addr, error) {
+	addrs := make([]netip.Addr, 0, count)
+
+	for addr := start; len(addrs) < count; addr = addr.Next() {
+		if !addr.IsValid() {
+			return nil, fmt.Errorf("%d addresses starting at %s overflow the address space", count, start)
+		}
+
+		addrs = append(addrs, addr)
+	}
+
+	return addrs, nil
+}
+
+func addrAfter(addr netip.Addr, n int) (netip.Addr, bool) {
+	for range n {
+		addr = addr.Next()
+		if !addr.IsValid() {
+			return addr, false
+		}
+	}
+
+	return addr, true
+}
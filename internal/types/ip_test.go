@@ -0,0 +1,98 @@
+package types_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPNetToPrefix(t *testing.T) {
+	t.Parallel()
+
+	ipNet, err := types.ExpandIPNet("172.16.4.0/22")
+	require.NoError(t, err)
+
+	prefix, err := types.IPNetToPrefix(ipNet)
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParsePrefix("172.16.4.0/22"), prefix)
+	assert.Equal(t, netip.MustParseAddr("172.16.7.255"), types.LastAddr(prefix))
+	assert.Equal(t, netip.MustParseAddr("fd00::ffff:ffff:ffff:ffff"), types.LastAddr(netip.MustParsePrefix("fd00::/64")))
+}
+
+func TestNextFreePrefix(t *testing.T) {
+	t.Parallel()
+
+	supernet := netip.MustParsePrefix("10.0.0.0/16")
+	used := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/24"),
+		netip.MustParsePrefix("10.0.1.128/25"),
+		netip.MustParsePrefix("10.0.4.0/22"),
+		netip.MustParsePrefix("192.168.0.0/24"),
+	}
+
+	tests := []struct {
+		bits    int
+		want    string
+		wantErr bool
+	}{
+		{bits: 24, want: "10.0.2.0/24"},
+		{bits: 25, want: "10.0.1.0/25"},
+		{bits: 22, want: "10.0.8.0/22"},
+		{bits: 16, wantErr: true},
+		{bits: 8, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		prefix, err := types.NextFreePrefix(supernet, tt.bits, used)
+		if tt.wantErr {
+			assert.Error(t, err, "/%d", tt.bits)
+
+			continue
+		}
+
+		require.NoError(t, err, "/%d", tt.bits)
+		assert.Equal(t, tt.want, prefix.String(), "/%d", tt.bits)
+	}
+
+	prefix, err := types.NextFreePrefix(netip.MustParsePrefix("fd00::/56"), 64, []netip.Prefix{netip.MustParsePrefix("fd00::/64")})
+	require.NoError(t, err)
+	assert.Equal(t, "fd00:0:0:1::/64", prefix.String())
+}
+
+func TestNextFreeAddrRange(t *testing.T) {
+	t.Parallel()
+
+	prefix := netip.MustParsePrefix("192.168.0.0/28")
+	used := []netip.Addr{
+		netip.MustParseAddr("192.168.0.5"),
+		netip.MustParseAddr("192.168.0.2"),
+		netip.MustParseAddr("192.168.0.9"),
+		netip.MustParseAddr("10.0.0.1"),
+	}
+
+	start, err := types.NextFreeAddrRange(prefix, 1, used)
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.0.1", start.String())
+
+	start, err = types.NextFreeAddrRange(prefix, 3, used)
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.0.6", start.String())
+
+	start, err = types.NextFreeAddrRange(prefix, 5, used)
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.0.10", start.String())
+
+	_, err = types.NextFreeAddrRange(prefix, 6, used)
+	require.Error(t, err)
+
+	addrs, err := types.AddrRange(netip.MustParseAddr("192.168.0.254"), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Addr{
+		netip.MustParseAddr("192.168.0.254"),
+		netip.MustParseAddr("192.168.0.255"),
+		netip.MustParseAddr("192.168.1.0"),
+	}, addrs)
+}
@@ -0,0 +1,43 @@
+package verify
+
+// ClosestMatch returns the candidate closest to value, to suggest a fix for a typo such as "max_connection".
+// It returns an empty string when no candidate is close enough.
+func ClosestMatch(value string, candidates []string) string {
+	best := ""
+	bestDistance := len(value)/3 + 1
+
+	for _, candidate := range candidates {
+		distance := levenshteinDistance(value, candidate)
+		if distance < bestDistance || (distance == bestDistance && best != "" && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	return best
+}
+
+func levenshteinDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,18 @@
+package verify_test
+
+import (
+	"testing"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/verify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"max_connections", "maxclients", "work_mem", "timeout"}
+
+	assert.Equal(t, "max_connections", verify.ClosestMatch("max_connection", candidates))
+	assert.Equal(t, "maxclients", verify.ClosestMatch("maxclient", candidates))
+	assert.Equal(t, "timeout", verify.ClosestMatch("timeout", candidates))
+	assert.Empty(t, verify.ClosestMatch("shared_buffers", candidates))
+	assert.Empty(t, verify.ClosestMatch("anything", nil))
+}
@@ -0,0 +1,412 @@
+// Package zonefile reads and writes the RFC 1035 master file format for the records a DNS zone holds.
+// Only the subset used to move records between providers is supported: $ORIGIN and $TTL directives,
+// comments, multi-line records between parentheses and the IN class. $INCLUDE is rejected.
+package zonefile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is used when a record has no TTL and no $TTL directive precedes it
+const DefaultTTL = 3600
+
+// Record is a resource record, Name is relative to the origin and empty for the origin itself.
+// Data is the presentation format of the RDATA, e.g. "10 mx.example.com." for an MX record.
+type Record struct {
+	Name string
+	TTL  uint32
+	Type string
+	Data string
+}
+
+var classes = map[string]bool{"IN": true, "CH": true, "HS": true, "CS": true}
+
+// Parse reads the records of a zone file. origin is the zone the relative names are attached to,
+// it can be changed by $ORIGIN directives. SOA records are skipped since they are managed by the DNS provider.
+func Parse(r io.Reader, origin string) ([]Record, error) {
+	p := &parser{
+		zone:   canonical(origin),
+		origin: canonical(origin),
+		ttl:    DefaultTTL,
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNumber := 0
+	entry := ""
+	entryLine := 0
+	depth := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line, open, err := stripComment(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		if depth == 0 {
+			entry = line
+			entryLine = lineNumber
+		} else {
+			entry += " " + line
+		}
+
+		depth += open
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+		}
+
+		if depth > 0 {
+			continue
+		}
+
+		if err := p.parseEntry(entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", entryLine, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", entryLine)
+	}
+
+	return p.records, nil
+}
+
+type parser struct {
+	zone      string
+	origin    string
+	ttl       uint32
+	lastOwner string
+	records   []Record
+}
+
+func (p *parser) parseEntry(entry string) error {
+	if strings.TrimSpace(entry) == "" {
+		return nil
+	}
+
+	startsWithBlank := entry[0] == ' ' || entry[0] == '\t'
+	entry = strings.NewReplacer("(", " ", ")", " ").Replace(entry)
+
+	fields, err := splitFields(entry)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToUpper(fields[0]) {
+	case "$ORIGIN":
+		if len(fields) != 2 {
+			return errors.New("$ORIGIN expects a domain name")
+		}
+
+		p.origin = p.absolute(fields[1])
+
+		return nil
+	case "$TTL":
+		if len(fields) != 2 {
+			return errors.New("$TTL expects a duration")
+		}
+
+		ttl, err := ParseTTL(fields[1])
+		if err != nil {
+			return err
+		}
+
+		p.ttl = ttl
+
+		return nil
+	case "$INCLUDE":
+		return errors.New("$INCLUDE is not supported")
+	}
+
+	owner := p.lastOwner
+
+	if !startsWithBlank {
+		owner = p.absolute(fields[0])
+		fields = fields[1:]
+	}
+
+	if owner == "" {
+		return errors.New("the first record must have an owner name")
+	}
+
+	p.lastOwner = owner
+	ttl := p.ttl
+
+	for len(fields) > 0 {
+		if classes[strings.ToUpper(fields[0])] {
+			fields = fields[1:]
+
+			continue
+		}
+
+		if parsed, err := ParseTTL(fields[0]); err == nil {
+			ttl = parsed
+			fields = fields[1:]
+
+			continue
+		}
+
+		break
+	}
+
+	if len(fields) < 2 {
+		return errors.New("expected a type and its data")
+	}
+
+	recordType := strings.ToUpper(fields[0])
+	if recordType == "SOA" {
+		return nil
+	}
+
+	name, err := p.relative(owner)
+	if err != nil {
+		return err
+	}
+
+	p.records = append(p.records, Record{
+		Name: name,
+		TTL:  ttl,
+		Type: recordType,
+		Data: strings.Join(fields[1:], " "),
+	})
+
+	return nil
+}
+
+// absolute resolves @ and relative names against the current origin
+func (p *parser) absolute(name string) string {
+	if name == "@" {
+		return p.origin
+	}
+
+	if strings.HasSuffix(name, ".") {
+		return strings.ToLower(name)
+	}
+
+	return strings.ToLower(name) + "." + p.origin
+}
+
+// relative returns the name relative to the zone the file is read for
+func (p *parser) relative(name string) (string, error) {
+	if name == p.zone {
+		return "", nil
+	}
+
+	if prefix, ok := strings.CutSuffix(name, "."+p.zone); ok {
+		return prefix, nil
+	}
+
+	return "", fmt.Errorf("%s is out of zone %s", name, p.zone)
+}
+
+// stripComment removes the comment of a line and counts its parentheses outside of quoted strings
+func stripComment(line string) (string, int, error) {
+	inQuotes := false
+	escaped := false
+	open := 0
+
+	for i, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case c == ';':
+			return line[:i], open, nil
+		case c == '(':
+			open++
+		case c == ')':
+			open--
+		}
+	}
+
+	if inQuotes {
+		return "", 0, errors.New("unterminated quoted string")
+	}
+
+	return line, open, nil
+}
+
+// splitFields splits on blanks, quoted strings are kept as a single field with their quotes
+func splitFields(s string) ([]string, error) {
+	var (
+		fields   []string
+		current  strings.Builder
+		inQuotes bool
+		escaped  bool
+	)
+
+	flush := func() {
+		if current.Len() > 0 {
+			fields = append(fields, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, c := range s {
+		switch {
+		case escaped:
+			current.WriteRune(c)
+
+			escaped = false
+		case c == '\\':
+			current.WriteRune(c)
+
+			escaped = true
+		case c == '"':
+			current.WriteRune(c)
+
+			inQuotes = !inQuotes
+		case !inQuotes && (c == ' ' || c == '\t'):
+			flush()
+		default:
+			current.WriteRune(c)
+		}
+	}
+
+	if inQuotes {
+		return nil, errors.New("unterminated quoted string")
+	}
+
+	flush()
+
+	return fields, nil
+}
+
+// ParseTTL parses a TTL in seconds or with BIND units, e.g. 3600, 1h or 1h30m
+func ParseTTL(s string) (uint32, error) {
+	if s == "" {
+		return 0, errors.New("empty TTL")
+	}
+
+	if seconds, err := strconv.ParseUint(s, 10, 32); err == nil {
+		return uint32(seconds), nil
+	}
+
+	units := map[byte]uint64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total := uint64(0)
+	number := ""
+
+	for i := range len(s) {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			number += string(c)
+
+			continue
+		}
+
+		unit, ok := units[c|0x20]
+		if !ok || number == "" {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+
+		value, _ := strconv.ParseUint(number, 10, 32)
+		total += value * unit
+		number = ""
+	}
+
+	if number != "" || total > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+
+	return uint32(total), nil
+}
+
+// Unquote returns the content of character strings such as "v=spf1 -all" or "part 1" "part 2"
+func Unquote(data string) string {
+	fields, err := splitFields(data)
+	if err != nil || len(fields) == 0 {
+		return data
+	}
+
+	var builder strings.Builder
+
+	for _, field := range fields {
+		if len(field) < 2 || field[0] != '"' || field[len(field)-1] != '"' {
+			return data
+		}
+
+		escaped := false
+
+		for _, c := range field[1 : len(field)-1] {
+			if !escaped && c == '\\' {
+				escaped = true
+
+				continue
+			}
+
+			builder.WriteRune(c)
+
+			escaped = false
+		}
+	}
+
+	return builder.String()
+}
+
+// Quote returns data as a character string, split in 255 bytes chunks as required by RFC 1035
+func Quote(data string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	chunks := []string(nil)
+
+	for len(data) > 255 {
+		chunks = append(chunks, `"`+escaped.Replace(data[:255])+`"`)
+		data = data[255:]
+	}
+
+	chunks = append(chunks, `"`+escaped.Replace(data)+`"`)
+
+	return strings.Join(chunks, " ")
+}
+
+// Format writes records as a zone file for origin, sorted by name and type so that exports are stable
+func Format(origin string, records []Record) string {
+	sorted := append([]Record(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+
+		return sorted[i].Data < sorted[j].Data
+	})
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "$ORIGIN %s\n", canonical(origin))
+	fmt.Fprintf(&builder, "$TTL %d\n", DefaultTTL)
+
+	for _, record := range sorted {
+		name := record.Name
+		if name == "" {
+			name = "@"
+		}
+
+		fmt.Fprintf(&builder, "%s\t%d\tIN\t%s\t%s\n", name, record.TTL, record.Type, record.Data)
+	}
+
+	return builder.String()
+}
+
+func canonical(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+}
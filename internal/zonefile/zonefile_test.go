@@ -0,0 +1,132 @@
+package zonefile_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/zonefile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	zone := `$ORIGIN example.com.
+$TTL 1h
+; the SOA is managed by the provider
+@	IN	SOA	ns1.example.com. admin.example.com. (
+		2024010101 ; serial
+		3600 600 86400 300 )
+@		IN	A	192.0.2.1
+		300	IN	MX	10 mail
+www		CNAME	example.com.
+txt.example.com.	60	TXT	"v=spf1 include:_spf.example.com ~all" ; spf
+_sip._tcp	SRV	10 60 5060 sip
+$ORIGIN sub.example.com.
+api	1d	IN	AAAA	2001:db8::1
+`
+
+	records, err := zonefile.Parse(strings.NewReader(zone), "example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, []zonefile.Record{
+		{Name: "", TTL: 3600, Type: "A", Data: "192.0.2.1"},
+		{Name: "", TTL: 300, Type: "MX", Data: "10 mail"},
+		{Name: "www", TTL: 3600, Type: "CNAME", Data: "example.com."},
+		{Name: "txt", TTL: 60, Type: "TXT", Data: `"v=spf1 include:_spf.example.com ~all"`},
+		{Name: "_sip._tcp", TTL: 3600, Type: "SRV", Data: "10 60 5060 sip"},
+		{Name: "api.sub", TTL: 86400, Type: "AAAA", Data: "2001:db8::1"},
+	}, records)
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		zone    string
+		wantErr string
+	}{
+		"include": {
+			zone:    "$INCLUDE other.zone\n",
+			wantErr: "line 1: $INCLUDE is not supported",
+		},
+		"out of zone": {
+			zone:    "www.example.org. A 192.0.2.1\n",
+			wantErr: "line 1: www.example.org. is out of zone example.com.",
+		},
+		"unbalanced": {
+			zone:    "@ TXT ( \"a\"\n",
+			wantErr: "unbalanced parentheses",
+		},
+		"unterminated string": {
+			zone:    "@ TXT \"a\n",
+			wantErr: "line 1: unterminated quoted string",
+		},
+		"missing data": {
+			zone:    "\n\nwww 3600 IN A\n",
+			wantErr: "line 3: expected a type and its data",
+		},
+		"missing owner": {
+			zone:    "  A 192.0.2.1\n",
+			wantErr: "line 1: the first record must have an owner name",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := zonefile.Parse(strings.NewReader(tt.zone), "example.com.")
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	t.Parallel()
+
+	for input, expected := range map[string]uint32{"60": 60, "1h": 3600, "1h30m": 5400, "1W": 604800, "2d": 172800} {
+		ttl, err := zonefile.ParseTTL(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, ttl, input)
+	}
+
+	for _, input := range []string{"", "h", "1x", "10h5", "A"} {
+		_, err := zonefile.ParseTTL(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"say \"hi\""`, zonefile.Quote(`say "hi"`))
+	assert.Equal(t, `say "hi"`, zonefile.Unquote(zonefile.Quote(`say "hi"`)))
+	assert.Equal(t, "part 1part 2", zonefile.Unquote(`"part 1" "part 2"`))
+	assert.Equal(t, "not quoted", zonefile.Unquote("not quoted"))
+
+	long := strings.Repeat("a", 300)
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`, zonefile.Quote(long))
+	assert.Equal(t, long, zonefile.Unquote(zonefile.Quote(long)))
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	records := []zonefile.Record{
+		{Name: "www", TTL: 300, Type: "CNAME", Data: "example.com."},
+		{Name: "", TTL: 3600, Type: "MX", Data: "10 mail.example.com."},
+		{Name: "", TTL: 3600, Type: "A", Data: "192.0.2.1"},
+	}
+
+	output := zonefile.Format("example.com", records)
+	assert.Equal(t, "$ORIGIN example.com.\n$TTL 3600\n"+
+		"@\t3600\tIN\tA\t192.0.2.1\n"+
+		"@\t3600\tIN\tMX\t10 mail.example.com.\n"+
+		"www\t300\tIN\tCNAME\texample.com.\n", output)
+
+	parsed, err := zonefile.Parse(strings.NewReader(output), "example.com.")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, records, parsed)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/instance"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/ipam"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/jobs"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/k8s"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/keymanager"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/lb"
 	"github.com/scaleway/terraform-provider-scaleway/v2/internal/services/mongodb"
@@ -69,6 +70,8 @@ type ScalewayProviderModel struct {
 	APIURL         types.String `tfsdk:"api_url"`
 	Region         types.String `tfsdk:"region"`
 	Zone           types.String `tfsdk:"zone"`
+
+	DNSProtectRecords types.List `tfsdk:"dns_protect_records"`
 }
 
 func (p *ScalewayProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -106,6 +109,11 @@ func (p *ScalewayProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Description: "The zone you want to attach the resource to",
 				Optional:    true,
 			},
+			"dns_protect_records": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The DNS records that cannot be deleted, as `[name/]type` patterns, e.g. `NS`, `@/A` or `*/MX`",
+			},
 		},
 	}
 }
@@ -145,6 +153,12 @@ func modelToFrameworkConfig(model *ScalewayProviderModel) *meta.FrameworkProvide
 		config.APIURL = model.APIURL.ValueString()
 	}
 
+	for _, pattern := range model.DNSProtectRecords.Elements() {
+		if pattern, ok := pattern.(types.String); ok && !pattern.IsNull() && !pattern.IsUnknown() {
+			config.DNSProtectRecords = append(config.DNSProtectRecords, pattern.ValueString())
+		}
+	}
+
 	return config
 }
 
@@ -209,10 +223,13 @@ func (p *ScalewayProvider) Resources(_ context.Context) []func() resource.Resour
 	return []func() resource.Resource{
 		datalab.NewDatalabResource,
 		billing.NewBudgetResource,
+		iam.NewAPIKeyRotationResource,
 		iam.NewSamlResource,
 		iam.NewSamlCertificateResource,
 		iam.NewScimResource,
 		iam.NewScimTokenResource,
+		iam.NewScimUserResource,
+		iam.NewScimGroupResource,
 	}
 }
 
@@ -223,6 +240,7 @@ func (p *ScalewayProvider) EphemeralResources(_ context.Context) []func() epheme
 		keymanager.NewGenerateDataKeyEphemeralResource,
 		keymanager.NewSignEphemeralResource,
 		iam.NewApiKeyEphemeralResource,
+		k8s.NewKubeconfigEphemeralResource,
 		secret.NewVersionEphemeralResource,
 		scwconfig.NewScwConfigEphemeralResource,
 	}
@@ -237,6 +255,7 @@ func (p *ScalewayProvider) DataSources(_ context.Context) []func() datasource.Da
 		iam.NewSamlCertificateDataSource,
 		iam.NewScimDataSource,
 		iam.NewScimTokenDataSource,
+		iam.NewScimPrincipalDataSource,
 	}
 }
 
@@ -252,6 +271,7 @@ func (p *ScalewayProvider) Actions(_ context.Context) []func() action.Action {
 		instance.NewExportSnapshot,
 		instance.NewServerAction,
 		jobs.NewStartJobDefinitionAction,
+		k8s.NewClusterUpgradeAction,
 		keymanager.NewRotateKeyAction,
 		mongodb.NewInstanceSnapshotAction,
 		rdb.NewDatabaseBackupExportAction,
@@ -305,6 +325,7 @@ func (p *ScalewayProvider) ListResources(_ context.Context) []func() list.ListRe
 
 func (p *ScalewayProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
+		functions.NewDatabaseDSN,
 		functions.NewRegionFromID,
 	}
 }
@@ -125,6 +125,15 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 					Optional:    true,
 					Description: "The Scaleway API URL to use.",
 				},
+				"dns_protect_records": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "The DNS records that cannot be deleted, as `[name/]type` patterns, e.g. `NS`, `@/A` or `*/MX`",
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: domain.ValidateProtectRecordPattern(),
+					},
+				},
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
@@ -155,6 +164,7 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_datawarehouse_database":                             datawarehouse.ResourceDatabase(),
 				"scaleway_kafka_cluster":                                      kafka.ResourceCluster(),
 				"scaleway_domain_record":                                      domain.ResourceRecord(),
+				"scaleway_domain_records":                                     domain.ResourceRecords(),
 				"scaleway_domain_registration":                                domain.ResourceRegistration(),
 				"scaleway_domain_zone":                                        domain.ResourceZone(),
 				"scaleway_edge_services_backend_stage":                        edgeservices.ResourceBackendStage(),
@@ -190,8 +200,10 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_instance_placement_group":                           instance.ResourcePlacementGroup(),
 				"scaleway_instance_private_nic":                               instance.ResourcePrivateNIC(),
 				"scaleway_instance_security_group":                            instance.ResourceSecurityGroup(),
+				"scaleway_instance_security_group_rule":                       instance.ResourceSecurityGroupRule(),
 				"scaleway_instance_security_group_rules":                      instance.ResourceSecurityGroupRules(),
 				"scaleway_instance_server":                                    instance.ResourceServer(),
+				"scaleway_instance_server_fleet":                              instance.ResourceServerFleet(),
 				"scaleway_instance_snapshot":                                  instance.ResourceSnapshot(),
 				"scaleway_instance_user_data":                                 instance.ResourceUserData(),
 				"scaleway_instance_volume":                                    instance.ResourceVolume(),
@@ -202,6 +214,7 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_iot_network":                                        iot.ResourceNetwork(),
 				"scaleway_iot_route":                                          iot.ResourceRoute(),
 				"scaleway_ipam_ip":                                            ipam.ResourceIP(),
+				"scaleway_ipam_ip_block":                                      ipam.ResourceIPBlock(),
 				"scaleway_ipam_ip_reverse_dns":                                ipam.ResourceIPReverseDNS(),
 				"scaleway_job_definition":                                     jobs.ResourceDefinition(),
 				"scaleway_k8s_acl":                                            k8s.ResourceACL(),
@@ -211,11 +224,13 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_lb":                                                 lb.ResourceLb(),
 				"scaleway_lb_acl":                                             lb.ResourceACL(),
 				"scaleway_lb_backend":                                         lb.ResourceBackend(),
+				"scaleway_lb_backend_server":                                  lb.ResourceBackendServer(),
 				"scaleway_lb_certificate":                                     lb.ResourceCertificate(),
 				"scaleway_lb_frontend":                                        lb.ResourceFrontend(),
 				"scaleway_lb_ip":                                              lb.ResourceIP(),
 				"scaleway_lb_private_network":                                 lb.ResourcePrivateNetwork(),
 				"scaleway_lb_route":                                           lb.ResourceRoute(),
+				"scaleway_lb_subscriber":                                      lb.ResourceSubscriber(),
 				"scaleway_mnq_nats_account":                                   mnq.ResourceNatsAccount(),
 				"scaleway_mnq_nats_credentials":                               mnq.ResourceNatsCredentials(),
 				"scaleway_mnq_sns":                                            mnq.ResourceSNS(),
@@ -225,6 +240,8 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_mnq_sqs":                                            mnq.ResourceSQS(),
 				"scaleway_mnq_sqs_credentials":                                mnq.ResourceSQSCredentials(),
 				"scaleway_mnq_sqs_queue":                                      mnq.ResourceSQSQueue(),
+				"scaleway_mongodb_database":                                   mongodb.ResourceDatabase(),
+				"scaleway_mongodb_endpoint":                                   mongodb.ResourceEndpoint(),
 				"scaleway_mongodb_instance":                                   mongodb.ResourceInstance(),
 				"scaleway_mongodb_snapshot":                                   mongodb.ResourceSnapshot(),
 				"scaleway_mongodb_user":                                       mongodb.ResourceUser(),
@@ -239,12 +256,18 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_rdb_acl":                                            rdb.ResourceACL(),
 				"scaleway_rdb_database":                                       rdb.ResourceDatabase(),
 				"scaleway_rdb_database_backup":                                rdb.ResourceDatabaseBackup(),
+				"scaleway_rdb_grant":                                          rdb.ResourceGrant(),
 				"scaleway_rdb_instance":                                       rdb.ResourceInstance(),
+				"scaleway_rdb_instance_clone":                                 rdb.ResourceInstanceClone(),
+				"scaleway_rdb_postgresql_extension":                           rdb.ResourcePostgreSQLExtension(),
 				"scaleway_rdb_privilege":                                      rdb.ResourcePrivilege(),
 				"scaleway_rdb_read_replica":                                   rdb.ResourceReadReplica(),
+				"scaleway_rdb_schema":                                         rdb.ResourceSchema(),
 				"scaleway_rdb_user":                                           rdb.ResourceUser(),
 				"scaleway_rdb_snapshot":                                       rdb.ResourceSnapshot(),
+				"scaleway_redis_acl_rule":                                     redis.ResourceACLRule(),
 				"scaleway_redis_cluster":                                      redis.ResourceCluster(),
+				"scaleway_redis_setting":                                      redis.ResourceSetting(),
 				"scaleway_registry_namespace":                                 registry.ResourceNamespace(),
 				"scaleway_s2s_vpn_gateway":                                    s2svpn.ResourceVPNGateway(),
 				"scaleway_s2s_vpn_customer_gateway":                           s2svpn.ResourceCustomerGateway(),
@@ -259,6 +282,7 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_tem_webhook":                                        tem.ResourceWebhook(),
 				"scaleway_vpc":                                                vpc.ResourceVPC(),
 				"scaleway_vpc_acl":                                            vpc.ResourceACL(),
+				"scaleway_vpc_acl_rule":                                       vpc.ResourceACLRule(),
 				"scaleway_vpc_connector":                                      vpc.ResourceConnector(),
 				"scaleway_vpc_gateway_network":                                vpcgw.ResourceNetwork(),
 				"scaleway_vpc_ingress_rule":                                   vpc.ResourceIngressRule(),
@@ -269,7 +293,9 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_vpc_public_gateway_ip":                              vpcgw.ResourceIP(),
 				"scaleway_vpc_public_gateway_ip_reverse_dns":                  vpcgw.ResourceIPReverseDNS(),
 				"scaleway_vpc_public_gateway_pat_rule":                        vpcgw.ResourcePATRule(),
+				"scaleway_vpc_public_gateway_pat_rules":                       vpcgw.ResourcePATRules(),
 				"scaleway_vpc_route":                                          vpc.ResourceRoute(),
+				"scaleway_vpc_subnet_allocation":                              vpc.ResourceSubnetAllocation(),
 				"scaleway_webhosting":                                         webhosting.ResourceWebhosting(),
 			},
 
@@ -303,8 +329,10 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_container":                                          container.DataSourceContainer(),
 				"scaleway_container_namespace":                                container.DataSourceNamespace(),
 				"scaleway_domain_record":                                      domain.DataSourceRecord(),
+				"scaleway_domain_records":                                     domain.DataSourceRecords(),
 				"scaleway_domain_registration":                                domain.DataSourceRegistration(),
 				"scaleway_domain_zone":                                        domain.DataSourceZone(),
+				"scaleway_domain_zone_transfer":                               domain.DataSourceZoneTransfer(),
 				"scaleway_edge_services_backend_stage":                        edgeservices.DataSourceBackendStage(),
 				"scaleway_edge_services_cache_stage":                          edgeservices.DataSourceCacheStage(),
 				"scaleway_edge_services_dns_stage":                            edgeservices.DataSourceDNSStage(),
@@ -317,12 +345,15 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_function":                                           function.DataSourceFunction(),
 				"scaleway_function_namespace":                                 function.DataSourceNamespace(),
 				"scaleway_iam_application":                                    iam.DataSourceApplication(),
+				"scaleway_iam_effective_permissions":                          iam.DataSourceEffectivePermissions(),
 				"scaleway_iam_group":                                          iam.DataSourceGroup(),
+				"scaleway_iam_permission_sets":                                iam.DataSourcePermissionSets(),
 				"scaleway_iam_policy":                                         iam.DataSourcePolicy(),
 				"scaleway_iam_ssh_key":                                        iam.DataSourceSSHKey(),
 				"scaleway_iam_user":                                           iam.DataSourceUser(),
 				"scaleway_iam_api_key":                                        iam.DataSourceAPIKey(),
 				"scaleway_inference_model":                                    inference.DataSourceModel(),
+				"scaleway_instance_cloud_init":                                instance.DataSourceCloudInit(),
 				"scaleway_instance_image":                                     instance.DataSourceImage(),
 				"scaleway_instance_ip":                                        instance.DataSourceIP(),
 				"scaleway_instance_placement_group":                           instance.DataSourcePlacementGroup(),
@@ -353,6 +384,7 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_lb":                                                 lb.DataSourceLb(),
 				"scaleway_lb_acls":                                            lb.DataSourceACLs(),
 				"scaleway_lb_backend":                                         lb.DataSourceBackend(),
+				"scaleway_lb_backend_stats":                                   lb.DataSourceBackendStats(),
 				"scaleway_lb_backends":                                        lb.DataSourceBackends(),
 				"scaleway_lb_certificate":                                     lb.DataSourceCertificate(),
 				"scaleway_lb_frontend":                                        lb.DataSourceFrontend(),
@@ -375,6 +407,7 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_rdb_acl":                                            rdb.DataSourceACL(),
 				"scaleway_rdb_database":                                       rdb.DataSourceDatabase(),
 				"scaleway_rdb_database_backup":                                rdb.DataSourceDatabaseBackup(),
+				"scaleway_rdb_engine":                                         rdb.DataSourceEngine(),
 				"scaleway_rdb_instance":                                       rdb.DataSourceInstance(),
 				"scaleway_rdb_privilege":                                      rdb.DataSourcePrivilege(),
 				"scaleway_redis_cluster":                                      redis.DataSourceCluster(),
@@ -391,6 +424,7 @@ func SDKProvider(config *Config) plugin.ProviderFunc {
 				"scaleway_tem_offer_subscription":                             tem.DataSourceOfferSubscription(),
 				"scaleway_vpc":                                                vpc.DataSourceVPC(),
 				"scaleway_vpc_acl":                                            vpc.DataSourceACL(),
+				"scaleway_vpc_acl_evaluate":                                   vpc.DataSourceACLEvaluate(),
 				"scaleway_vpc_connector":                                      vpc.DataSourceConnector(),
 				"scaleway_vpc_gateway_network":                                vpcgw.DataSourceNetwork(),
 				"scaleway_vpc_ingress_rule":                                   vpc.DataSourceIngressRule(),